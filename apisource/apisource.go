@@ -0,0 +1,182 @@
+// Package apisource implements types.ProductSource against Product Hunt's
+// official GraphQL API (v2) instead of scraping its HTML. It needs a
+// developer token, read from PHTUI_PH_API_TOKEN, and isn't affected by
+// markup changes or Cloudflare challenges.
+package apisource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// Endpoint is the Product Hunt GraphQL API.
+const Endpoint = "https://api.producthunt.com/v2/api/graphql"
+
+const (
+	// cacheTTL is how long API results are served from memory.
+	cacheTTL = 15 * time.Minute
+	// maxResponseSize caps how much of an API response is read.
+	maxResponseSize = 10 << 20
+)
+
+var (
+	// ErrNoToken is returned by New when no API token is given.
+	ErrNoToken = errors.New("PHTUI_PH_API_TOKEN is not set")
+	// ErrUnauthorized is returned when the API rejects the token.
+	ErrUnauthorized = errors.New("Product Hunt API rejected the token")
+)
+
+// Source is a types.ProductSource backed by the Product Hunt API. Results
+// are cached in memory for cacheTTL.
+type Source struct {
+	endpoint string
+	token    string
+	client   *http.Client
+	cache    *scraper.MemoryCache
+	// trendingOnEmpty makes empty search queries return today's daily
+	// leaderboard instead of nothing.
+	trendingOnEmpty bool
+	// now is the source's clock; tests replace it.
+	now func() time.Time
+}
+
+// Compile-time interface checks
+var (
+	_ types.ContextProductSource   = (*Source)(nil)
+	_ types.ContextSearchSource    = (*Source)(nil)
+	_ types.LeaderboardInvalidator = (*Source)(nil)
+)
+
+// New creates a Source authenticating with token.
+func New(token string) (*Source, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	return &Source{
+		endpoint: Endpoint,
+		token:    token,
+		client:   &http.Client{Timeout: scraper.DefaultRequestTimeout},
+		cache:    scraper.NewMemoryCache(),
+		now:      time.Now,
+	}, nil
+}
+
+// TokenFromEnv returns the API token set by PHTUI_PH_API_TOKEN.
+func TokenFromEnv() string {
+	return strings.TrimSpace(os.Getenv("PHTUI_PH_API_TOKEN"))
+}
+
+// SetRequestTimeout bounds each API request. A non-positive timeout
+// restores scraper.DefaultRequestTimeout.
+func (s *Source) SetRequestTimeout(d time.Duration) {
+	if d <= 0 {
+		d = scraper.DefaultRequestTimeout
+	}
+	s.client.Timeout = d
+}
+
+// SetProxy routes API requests through proxy; see scraper.ParseProxyURL
+// for the supported schemes. A nil proxy restores the default transport,
+// which honours HTTP_PROXY and HTTPS_PROXY.
+func (s *Source) SetProxy(proxy *url.URL) {
+	var transport http.RoundTripper
+	if proxy != nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.Proxy = http.ProxyURL(proxy)
+		transport = t
+	}
+	s.client.Transport = transport
+}
+
+// SearchNote describes how this source's search differs from Product
+// Hunt's: the API has no product search, so results are a topic's posts.
+func (s *Source) SearchNote() string {
+	return "The Product Hunt API has no product search: items are the most voted posts of the topic that best matches the query, not products matching it by name."
+}
+
+// SetTrendingOnEmptyQuery controls what an empty search query returns:
+// nothing by default, today's daily leaderboard with on set.
+func (s *Source) SetTrendingOnEmptyQuery(on bool) {
+	s.trendingOnEmpty = on
+}
+
+// ClearCache drops every cached API result.
+func (s *Source) ClearCache() {
+	s.cache.Clear()
+}
+
+// graphQLError is one entry of a GraphQL response's errors list.
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// query posts a GraphQL query with variables and decodes the response's
+// data into out. ctx cancels the request.
+func (s *Source) query(ctx context.Context, query string, variables map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("encode query: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("query API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: status %d", ErrUnauthorized, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		return fmt.Errorf("API error: %s", envelope.Errors[0].Message)
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// cached returns the value stored under key unless it is older than
+// cacheTTL.
+func (s *Source) cached(key string) (any, bool) {
+	value, storedAt, ok := s.cache.GetStored(key)
+	if !ok || s.now().Sub(storedAt) > cacheTTL {
+		return nil, false
+	}
+	return value, true
+}
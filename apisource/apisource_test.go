@@ -0,0 +1,259 @@
+package apisource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// graphQLRequest is a request as the fake API sees it.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newTestSource returns a Source talking to a fake API that answers each
+// request with respond and records the requests it got.
+func newTestSource(t *testing.T, respond func(req graphQLRequest) string) (*Source, *[]graphQLRequest) {
+	t.Helper()
+	var requests []graphQLRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req graphQLRequest
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		requests = append(requests, req)
+		io.WriteString(w, respond(req))
+	}))
+	t.Cleanup(srv.Close)
+
+	s, err := New("test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.endpoint = srv.URL
+	return s, &requests
+}
+
+// postJSON returns a post node as the API sends it.
+func postJSON(slug string, votes int) string {
+	return `{"name":"` + strings.ToUpper(slug) + `","tagline":"About ` + slug + `","slug":"` + slug + `","votesCount":` + itoa(votes) +
+		`,"commentsCount":3,"reviewsCount":7,"reviewsRating":4.5,"thumbnail":{"url":"https://ph-files.imgix.net/` + slug + `.png"},` +
+		`"topics":{"edges":[{"node":{"name":"Developer Tools","slug":"developer-tools"}}]}}`
+}
+
+// postsJSON returns a posts connection of the given slugs.
+func postsJSON(hasNext bool, cursor string, total int, slugs ...string) string {
+	edges := make([]string, len(slugs))
+	for i, slug := range slugs {
+		edges[i] = `{"node":` + postJSON(slug, 11) + `}`
+	}
+	next := "false"
+	if hasNext {
+		next = "true"
+	}
+	return `{"data":{"posts":{"edges":[` + strings.Join(edges, ",") + `],"pageInfo":{"hasNextPage":` + next + `,"endCursor":"` + cursor + `"},"totalCount":` + itoa(total) + `}}}`
+}
+
+// itoa formats n as a JSON number.
+func itoa(n int) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	if _, err := New("  "); !errors.Is(err, ErrNoToken) {
+		t.Fatalf("New without a token: err = %v, want ErrNoToken", err)
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	s, requests := newTestSource(t, func(req graphQLRequest) string {
+		if req.Variables["cursor"] == nil {
+			return postsJSON(true, "c1", 3, "alpha", "beta")
+		}
+		return postsJSON(false, "c2", 3, "gamma")
+	})
+	date := time.Date(2026, 2, 18, 15, 0, 0, 0, types.Timezone())
+
+	products, err := s.GetLeaderboard(types.Daily, date)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	var slugs []string
+	for i, p := range products {
+		slugs = append(slugs, p.Slug())
+		if p.Rank() != i+1 {
+			t.Errorf("%s rank = %d, want %d", p.Slug(), p.Rank(), i+1)
+		}
+	}
+	if want := []string{"posts/alpha", "posts/beta", "posts/gamma"}; !reflect.DeepEqual(slugs, want) {
+		t.Fatalf("slugs = %v, want %v", slugs, want)
+	}
+	first := products[0]
+	if first.Name() != "ALPHA" || first.Tagline() != "About alpha" || first.VoteCount() != 11 || first.CommentCount() != 3 ||
+		first.Rating() != 4.5 || first.ThumbnailURL() != "https://ph-files.imgix.net/alpha.png" || !reflect.DeepEqual(first.Categories(), []string{"Developer Tools"}) {
+		t.Errorf("first product = %+v", first)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("requests = %d, want 2 pages", len(*requests))
+	}
+	vars := (*requests)[0].Variables
+	day := types.DayIn(date)
+	if vars["postedAfter"] != day.Format(time.RFC3339) || vars["postedBefore"] != day.AddDate(0, 0, 1).Format(time.RFC3339) {
+		t.Errorf("range = %v to %v, want the day of %s", vars["postedAfter"], vars["postedBefore"], date)
+	}
+	if (*requests)[1].Variables["cursor"] != "c1" {
+		t.Errorf("second page cursor = %v, want c1", (*requests)[1].Variables["cursor"])
+	}
+
+	// The second call is served from the cache.
+	if _, err := s.GetLeaderboard(types.Daily, date); err != nil || len(*requests) != 2 {
+		t.Fatalf("cached GetLeaderboard: %v after %d requests", err, len(*requests))
+	}
+	s.ClearCache()
+	if _, err := s.GetLeaderboard(types.Daily, date); err != nil || len(*requests) != 4 {
+		t.Fatalf("GetLeaderboard after ClearCache: %v after %d requests", err, len(*requests))
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	loc := types.Timezone()
+	date := time.Date(2026, 1, 1, 12, 0, 0, 0, loc) // a Thursday
+	for _, tt := range []struct {
+		period     types.Period
+		start, end time.Time
+	}{
+		{types.Daily, time.Date(2026, 1, 1, 0, 0, 0, 0, loc), time.Date(2026, 1, 2, 0, 0, 0, 0, loc)},
+		{types.Weekly, time.Date(2025, 12, 29, 0, 0, 0, 0, loc), time.Date(2026, 1, 5, 0, 0, 0, 0, loc)},
+		{types.Monthly, time.Date(2026, 1, 1, 0, 0, 0, 0, loc), time.Date(2026, 2, 1, 0, 0, 0, 0, loc)},
+	} {
+		start, end := periodRange(tt.period, date)
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("%s: %s to %s, want %s to %s", tt.period, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestGetProductDetail(t *testing.T) {
+	s, requests := newTestSource(t, func(req graphQLRequest) string {
+		if req.Variables["slug"] != "alpha" {
+			return `{"data":{"post":null}}`
+		}
+		post := strings.TrimSuffix(postJSON("alpha", 11), "}") +
+			`,"description":"Longer text","website":"https://alpha.example","featuredAt":"2026-02-18T00:01:00-08:00","createdAt":"2026-02-17T10:00:00-08:00",` +
+			`"makers":[{"name":"Ada","url":"https://www.producthunt.com/@ada"}],` +
+			`"media":[{"type":"image","url":"https://ph-files.imgix.net/shot.png"},{"type":"video","url":"https://ph-files.imgix.net/poster.png"},{"type":"image","url":"https://ph-files.imgix.net/shot.png"}]}`
+		return `{"data":{"post":` + post + `}}`
+	})
+
+	detail, err := s.GetProductDetail("posts/alpha")
+	if err != nil {
+		t.Fatalf("GetProductDetail: %v", err)
+	}
+	if (*requests)[0].Variables["slug"] != "alpha" {
+		t.Errorf("queried slug %v, want alpha", (*requests)[0].Variables["slug"])
+	}
+	featured := time.Date(2026, 2, 18, 0, 1, 0, 0, time.FixedZone("", -8*3600))
+	if detail.Product().Slug() != "posts/alpha" || detail.Description() != "Longer text" || detail.WebsiteURL() != "https://alpha.example" ||
+		detail.Rating() != 4.5 || detail.ReviewCount() != 7 || !detail.FirstLaunchDate().Equal(featured) ||
+		detail.MakerName() != "Ada" || detail.MakerProfileURL() != "https://www.producthunt.com/@ada" {
+		t.Errorf("detail = %+v", detail)
+	}
+	if want := []types.CategoryLink{types.NewCategoryLink("Developer Tools", "developer-tools")}; !reflect.DeepEqual(detail.Topics(), want) {
+		t.Errorf("topics = %v, want %v", detail.Topics(), want)
+	}
+	if want := []string{"https://ph-files.imgix.net/shot.png", "https://ph-files.imgix.net/poster.png"}; !reflect.DeepEqual(detail.GalleryImages(), want) {
+		t.Errorf("gallery = %v, want %v", detail.GalleryImages(), want)
+	}
+
+	if _, err := s.GetProductDetail("missing"); !errors.Is(err, types.ErrProductUnavailable) {
+		t.Fatalf("missing post err = %v, want ErrProductUnavailable", err)
+	}
+}
+
+func TestGetCategoryProducts(t *testing.T) {
+	s, requests := newTestSource(t, func(req graphQLRequest) string {
+		return postsJSON(true, "c1", 40, "alpha", "beta")
+	})
+	products, related, err := s.GetCategoryProducts("developer-tools")
+	if err != nil {
+		t.Fatalf("GetCategoryProducts: %v", err)
+	}
+	if len(products) != 2 || related != nil {
+		t.Fatalf("products = %v, related = %v", products, related)
+	}
+	// Category listings carry reviews, like the scraper's.
+	if products[0].CommentCount() != 7 {
+		t.Errorf("review count = %d, want 7", products[0].CommentCount())
+	}
+	if vars := (*requests)[0].Variables; vars["topic"] != "developer-tools" || vars["first"] != float64(categoryPageSize) {
+		t.Errorf("variables = %v", vars)
+	}
+}
+
+func TestSearchProductsPage(t *testing.T) {
+	s, requests := newTestSource(t, func(req graphQLRequest) string {
+		switch {
+		case strings.Contains(req.Query, "query TopicSearch"):
+			return `{"data":{"topics":{"edges":[{"node":{"name":"Developer Tools","slug":"developer-tools"}}]}}}`
+		case req.Variables["cursor"] == nil:
+			return postsJSON(true, "c1", 25, "alpha", "beta")
+		default:
+			return postsJSON(true, "c2", 25, "gamma")
+		}
+	})
+
+	products, page, hasPrev, hasNext, pagesCount, err := s.SearchProductsPage("dev tools", 2)
+	if err != nil {
+		t.Fatalf("SearchProductsPage: %v", err)
+	}
+	if page != 2 || !hasPrev || !hasNext || pagesCount != 3 {
+		t.Errorf("page %d, hasPrev %v, hasNext %v, pagesCount %d; want 2, true, true, 3", page, hasPrev, hasNext, pagesCount)
+	}
+	if len(products) != 1 || products[0].Slug() != "posts/gamma" || products[0].Rank() != searchPageSize+1 {
+		t.Fatalf("page 2 = %+v", products)
+	}
+	if len(*requests) != 3 || (*requests)[0].Variables["query"] != "dev tools" || (*requests)[2].Variables["cursor"] != "c1" {
+		t.Errorf("requests = %+v", *requests)
+	}
+
+	if products, _, _, _, _, err := s.SearchProductsPage(" ", 1); err != nil || products != nil {
+		t.Errorf("empty query = %v, %v; want nothing", products, err)
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	s, _ := newTestSource(t, func(graphQLRequest) string {
+		return `{"errors":[{"message":"rate limit reached"}]}`
+	})
+	if _, err := s.GetLeaderboard(types.Daily, types.Today()); err == nil || !strings.Contains(err.Error(), "rate limit reached") {
+		t.Errorf("GraphQL error = %v, want the API's message", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.GetProductDetailContext(ctx, "alpha"); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled context: err = %v, want context.Canceled", err)
+	}
+
+	s.token = "wrong"
+	if _, err := s.GetProductDetail("alpha"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("bad token: err = %v, want ErrUnauthorized", err)
+	}
+}
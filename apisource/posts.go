@@ -0,0 +1,448 @@
+package apisource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	// postsPageSize is how many posts each posts query asks for.
+	postsPageSize = 50
+	// maxLeaderboardPages caps how many pages of posts one leaderboard
+	// reads, so a monthly leaderboard stops at its top 200.
+	maxLeaderboardPages = 4
+	// categoryPageSize is how many posts a category listing holds.
+	categoryPageSize = 20
+	// searchPageSize is how many posts a search results page holds.
+	searchPageSize = 10
+)
+
+// postFields are the post fields every listing reads.
+const postFields = `
+fragment PostFields on Post {
+  name
+  tagline
+  slug
+  votesCount
+  commentsCount
+  reviewsCount
+  reviewsRating
+  thumbnail { url }
+  topics(first: 10) { edges { node { name slug } } }
+}`
+
+const leaderboardQuery = `
+query Leaderboard($postedAfter: DateTime!, $postedBefore: DateTime!, $first: Int!, $cursor: String) {
+  posts(featured: true, order: RANKING, postedAfter: $postedAfter, postedBefore: $postedBefore, first: $first, after: $cursor) {
+    edges { node { ...PostFields } }
+    pageInfo { hasNextPage endCursor }
+  }
+}` + postFields
+
+const topicPostsQuery = `
+query TopicPosts($topic: String!, $first: Int!, $cursor: String) {
+  posts(topic: $topic, order: VOTES, first: $first, after: $cursor) {
+    edges { node { ...PostFields } }
+    pageInfo { hasNextPage endCursor }
+    totalCount
+  }
+}` + postFields
+
+const topicSearchQuery = `
+query TopicSearch($query: String!) {
+  topics(query: $query, order: FOLLOWERS_COUNT, first: 1) {
+    edges { node { slug } }
+  }
+}`
+
+const postQuery = `
+query Post($slug: String!) {
+  post(slug: $slug) {
+    ...PostFields
+    description
+    website
+    featuredAt
+    createdAt
+    makers { name url }
+    media { type url }
+  }
+}` + postFields
+
+type apiTopic struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type apiPost struct {
+	Name          string  `json:"name"`
+	Tagline       string  `json:"tagline"`
+	Slug          string  `json:"slug"`
+	VotesCount    int     `json:"votesCount"`
+	CommentsCount int     `json:"commentsCount"`
+	ReviewsCount  int     `json:"reviewsCount"`
+	ReviewsRating float64 `json:"reviewsRating"`
+	Thumbnail     *struct {
+		URL string `json:"url"`
+	} `json:"thumbnail"`
+	Topics struct {
+		Edges []struct {
+			Node apiTopic `json:"node"`
+		} `json:"edges"`
+	} `json:"topics"`
+
+	// Detail fields, only queried by GetProductDetail.
+	Description string     `json:"description"`
+	Website     string     `json:"website"`
+	FeaturedAt  *time.Time `json:"featuredAt"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	Makers      []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"makers"`
+	// Media are the gallery's images and videos; a video's URL is its
+	// preview image.
+	Media []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"media"`
+}
+
+type apiPostConnection struct {
+	Edges []struct {
+		Node apiPost `json:"node"`
+	} `json:"edges"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	TotalCount int `json:"totalCount"`
+}
+
+// postPage is one page of a posts listing and the cursor after it.
+type postPage struct {
+	products []types.Product
+	hasNext  bool
+	cursor   string
+	total    int
+}
+
+// topics returns the post's topics as category links.
+func (p apiPost) topics() []types.CategoryLink {
+	links := make([]types.CategoryLink, 0, len(p.Topics.Edges))
+	for _, edge := range p.Topics.Edges {
+		links = append(links, types.NewCategoryLink(edge.Node.Name, edge.Node.Slug))
+	}
+	return links
+}
+
+// product converts the post into a product ranked rank. Posts have no
+// product page slug of their own, so they use scraper.PostSlug and link to
+// their launch page. Listings that show reviews, as categories and search
+// do, carry the review count where leaderboards carry comments.
+func (p apiPost) product(rank int, reviews bool) types.Product {
+	topics := p.topics()
+	names := make([]string, len(topics))
+	for i, t := range topics {
+		names[i] = t.Name()
+	}
+	thumbnail := ""
+	if p.Thumbnail != nil {
+		thumbnail = p.Thumbnail.URL
+	}
+	count := p.CommentsCount
+	if reviews {
+		count = p.ReviewsCount
+	}
+	return types.NewProduct(p.Name, p.Tagline, names, p.VotesCount, count, scraper.PostSlug(p.Slug), thumbnail, rank, p.ReviewsRating, false)
+}
+
+// gallery returns the post's media URLs in gallery order, each once.
+func (p apiPost) gallery() []string {
+	var images []string
+	for _, m := range p.Media {
+		if m.URL != "" && !slices.Contains(images, m.URL) {
+			images = append(images, m.URL)
+		}
+	}
+	return images
+}
+
+// detail converts a post queried with its detail fields.
+func (p apiPost) detail() types.ProductDetail {
+	var launched time.Time
+	if p.FeaturedAt != nil {
+		launched = *p.FeaturedAt
+	} else if p.CreatedAt != nil {
+		launched = *p.CreatedAt
+	}
+	var makerName, makerURL string
+	if len(p.Makers) > 0 {
+		makerName, makerURL = p.Makers[0].Name, p.Makers[0].URL
+	}
+	product := p.product(0, false)
+	return types.NewProductDetail(types.ProductDetailOptions{
+		Product:         product,
+		Description:     p.Description,
+		Rating:          p.ReviewsRating,
+		ReviewCount:     p.ReviewsCount,
+		WebsiteURL:      p.Website,
+		Categories:      product.Categories(),
+		FirstLaunch:     launched,
+		LatestLaunch:    launched,
+		MakerName:       makerName,
+		MakerProfileURL: makerURL,
+		Topics:          p.topics(),
+		GalleryImages:   p.gallery(),
+	})
+}
+
+// periodRange returns the start and end of the leaderboard containing date:
+// its day, its ISO week (Monday to Monday) or its month, in the configured
+// timezone.
+func periodRange(period types.Period, date time.Time) (time.Time, time.Time) {
+	day := types.DayIn(date)
+	switch period {
+	case types.Weekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case types.Monthly:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+// leaderboardKey is the cache key of the leaderboard starting at start.
+func leaderboardKey(period types.Period, start time.Time) string {
+	return fmt.Sprintf("leaderboard:%s:%s", period, start.Format(time.DateOnly))
+}
+
+// InvalidateLeaderboard drops the cached leaderboard of the period
+// containing date, so the next fetch queries the API.
+func (s *Source) InvalidateLeaderboard(period types.Period, date time.Time) {
+	start, _ := periodRange(period, date)
+	s.cache.Delete(leaderboardKey(period, start))
+}
+
+// GetLeaderboard returns the featured posts of the period containing date,
+// in Product Hunt's ranking order.
+func (s *Source) GetLeaderboard(period types.Period, date time.Time) ([]types.Product, error) {
+	return s.GetLeaderboardContext(context.Background(), period, date)
+}
+
+// GetLeaderboardContext is GetLeaderboard with a context that cancels the
+// API requests.
+func (s *Source) GetLeaderboardContext(ctx context.Context, period types.Period, date time.Time) ([]types.Product, error) {
+	start, end := periodRange(period, date)
+	key := leaderboardKey(period, start)
+	if val, ok := s.cached(key); ok {
+		if products, ok := val.([]types.Product); ok {
+			return products, nil
+		}
+	}
+
+	var products []types.Product
+	cursor := ""
+	for range maxLeaderboardPages {
+		var data struct {
+			Posts apiPostConnection `json:"posts"`
+		}
+		err := s.query(ctx, leaderboardQuery, map[string]any{
+			"postedAfter":  start.Format(time.RFC3339),
+			"postedBefore": end.Format(time.RFC3339),
+			"first":        postsPageSize,
+			"cursor":       nullable(cursor),
+		}, &data)
+		if err != nil {
+			return nil, fmt.Errorf("fetch leaderboard: %w", err)
+		}
+		for _, edge := range data.Posts.Edges {
+			products = append(products, edge.Node.product(len(products)+1, false))
+		}
+		if !data.Posts.PageInfo.HasNextPage || data.Posts.PageInfo.EndCursor == "" {
+			break
+		}
+		cursor = data.Posts.PageInfo.EndCursor
+	}
+
+	s.cache.Set(key, products)
+	return products, nil
+}
+
+// GetProductDetail returns a post's details. slug is a post slug, with or
+// without the scraper.PostSlug prefix listings give it.
+func (s *Source) GetProductDetail(slug string) (types.ProductDetail, error) {
+	return s.GetProductDetailContext(context.Background(), slug)
+}
+
+// GetProductDetailContext is GetProductDetail with a context that cancels
+// the API request.
+func (s *Source) GetProductDetailContext(ctx context.Context, slug string) (types.ProductDetail, error) {
+	slug = strings.TrimPrefix(slug, scraper.PostSlug(""))
+	key := "post:" + slug
+	if val, ok := s.cached(key); ok {
+		if detail, ok := val.(types.ProductDetail); ok {
+			return detail, nil
+		}
+	}
+
+	var data struct {
+		Post *apiPost `json:"post"`
+	}
+	if err := s.query(ctx, postQuery, map[string]any{"slug": slug}, &data); err != nil {
+		return types.ProductDetail{}, fmt.Errorf("fetch post: %w", err)
+	}
+	if data.Post == nil {
+		return types.ProductDetail{}, fmt.Errorf("post %q: %w", slug, types.ErrProductUnavailable)
+	}
+
+	detail := data.Post.detail()
+	s.cache.Set(key, detail)
+	return detail, nil
+}
+
+// GetCategoryProducts returns the most voted posts in the topic slug. The
+// API has topics rather than categories, so category slugs are looked up
+// as topic slugs, and no related categories are returned.
+func (s *Source) GetCategoryProducts(slug string) ([]types.Product, []types.CategoryLink, error) {
+	return s.GetCategoryProductsContext(context.Background(), slug)
+}
+
+// GetCategoryProductsContext is GetCategoryProducts with a context that
+// cancels the API request.
+func (s *Source) GetCategoryProductsContext(ctx context.Context, slug string) ([]types.Product, []types.CategoryLink, error) {
+	page, err := s.topicPosts(ctx, slug, categoryPageSize, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch category: %w", err)
+	}
+	return page.products, nil, nil
+}
+
+// SearchProductsPage returns a page of search results. The API has no
+// product search, so the query is matched against topics and the most
+// voted posts of the best match are returned.
+func (s *Source) SearchProductsPage(query string, page int) ([]types.Product, int, bool, bool, int, error) {
+	return s.SearchProductsPageContext(context.Background(), query, page)
+}
+
+// SearchProductsPageContext is SearchProductsPage with a context that
+// cancels the API requests.
+func (s *Source) SearchProductsPageContext(ctx context.Context, query string, page int) ([]types.Product, int, bool, bool, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if strings.TrimSpace(query) == "" {
+		if !s.trendingOnEmpty {
+			return nil, 1, false, false, 0, nil
+		}
+		products, err := s.GetLeaderboardContext(ctx, types.Daily, types.Today())
+		if err != nil {
+			return nil, 1, false, false, 0, err
+		}
+		return products, 1, false, false, 1, nil
+	}
+
+	topic, err := s.searchTopic(ctx, strings.TrimSpace(query))
+	if err != nil {
+		return nil, page, false, false, 0, fmt.Errorf("search topics: %w", err)
+	}
+	if topic == "" {
+		return nil, page, page > 1, false, 0, nil
+	}
+
+	// Pages are cursor-based, so walk to the requested one; earlier pages
+	// come from the cache after the first search.
+	var result postPage
+	cursor := ""
+	for n := 1; n <= page; n++ {
+		result, err = s.topicPosts(ctx, topic, searchPageSize, cursor)
+		if err != nil {
+			return nil, page, false, false, 0, fmt.Errorf("fetch search results: %w", err)
+		}
+		if n < page && !result.hasNext {
+			return nil, page, true, false, n, nil
+		}
+		cursor = result.cursor
+	}
+	pagesCount := (result.total + searchPageSize - 1) / searchPageSize
+	products := make([]types.Product, len(result.products))
+	for i, p := range result.products {
+		products[i] = types.NewProduct(p.Name(), p.Tagline(), p.Categories(), p.VoteCount(), p.CommentCount(), p.Slug(), p.ThumbnailURL(), (page-1)*searchPageSize+i+1, p.Rating(), p.Offline())
+	}
+	return products, page, page > 1, result.hasNext, pagesCount, nil
+}
+
+// searchTopic returns the slug of the topic best matching query, or "" when
+// none does.
+func (s *Source) searchTopic(ctx context.Context, query string) (string, error) {
+	key := "topic-search:" + strings.ToLower(query)
+	if val, ok := s.cached(key); ok {
+		if slug, ok := val.(string); ok {
+			return slug, nil
+		}
+	}
+	var data struct {
+		Topics struct {
+			Edges []struct {
+				Node apiTopic `json:"node"`
+			} `json:"edges"`
+		} `json:"topics"`
+	}
+	if err := s.query(ctx, topicSearchQuery, map[string]any{"query": query}, &data); err != nil {
+		return "", err
+	}
+	slug := ""
+	if len(data.Topics.Edges) > 0 {
+		slug = data.Topics.Edges[0].Node.Slug
+	}
+	s.cache.Set(key, slug)
+	return slug, nil
+}
+
+// topicPosts returns a page of first posts in topic after cursor, most
+// voted first.
+func (s *Source) topicPosts(ctx context.Context, topic string, first int, cursor string) (postPage, error) {
+	key := fmt.Sprintf("topic:%s:%d:%s", topic, first, cursor)
+	if val, ok := s.cached(key); ok {
+		if page, ok := val.(postPage); ok {
+			return page, nil
+		}
+	}
+	var data struct {
+		Posts apiPostConnection `json:"posts"`
+	}
+	err := s.query(ctx, topicPostsQuery, map[string]any{
+		"topic":  topic,
+		"first":  first,
+		"cursor": nullable(cursor),
+	}, &data)
+	if err != nil {
+		return postPage{}, err
+	}
+	page := postPage{
+		hasNext: data.Posts.PageInfo.HasNextPage,
+		cursor:  data.Posts.PageInfo.EndCursor,
+		total:   data.Posts.TotalCount,
+	}
+	for _, edge := range data.Posts.Edges {
+		page.products = append(page.products, edge.Node.product(len(page.products)+1, true))
+	}
+	s.cache.Set(key, page)
+	return page, nil
+}
+
+// nullable returns nil for an empty cursor so the first page is requested
+// without one.
+func nullable(cursor string) any {
+	if cursor == "" {
+		return nil
+	}
+	return cursor
+}
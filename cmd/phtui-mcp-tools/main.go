@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/qyinm/phtui/mcpsrv"
+)
+
+func main() {
+	cfg := mcpsrv.LoadConfig()
+	// Tools are listed, never called, so the server needs no source.
+	server := mcpsrv.NewServer(nil, "dev", &mcpsrv.ServerOptions{
+		Profile:        cfg.Profile,
+		EnableSearch:   cfg.EnableSearch,
+		EnableAdmin:    cfg.EnableAdmin,
+		StructuredOnly: cfg.StructuredOnly,
+		MaxItems:       cfg.MaxItems,
+		TrendingSearch: cfg.TrendingSearch,
+	})
+	out, err := mcpsrv.ExportToolSchemas(context.Background(), server)
+	if err != nil {
+		log.Fatalf("export tool schemas: %v", err)
+	}
+	if _, err := os.Stdout.Write(append(out, '\n')); err != nil {
+		log.Fatalf("write tool schemas: %v", err)
+	}
+}
@@ -12,23 +12,58 @@ import (
 	"time"
 
 	"github.com/qyinm/phtui/mcpsrv"
-	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/sources"
 )
 
 type cacheClearSource interface {
 	ClearCache()
 }
 
+type persistentCacheSource interface {
+	PersistentCache() bool
+}
+
+type trendingSearchSource interface {
+	SetTrendingOnEmptyQuery(on bool)
+}
+
+type requestTimeoutSource interface {
+	SetRequestTimeout(d time.Duration)
+}
+
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	cfg := mcpsrv.LoadConfig()
-	source := scraper.New()
+	source, err := sources.New(cfg.Source)
+	if err != nil {
+		log.Fatalf("select source: %v", err)
+	}
+	// sources.New has already validated PHTUI_PROXY.
+	if os.Getenv("PHTUI_PROXY") != "" {
+		log.Printf("routing Product Hunt requests through PHTUI_PROXY")
+	}
+	if trending, ok := source.(trendingSearchSource); ok && cfg.TrendingSearch {
+		trending.SetTrendingOnEmptyQuery(true)
+	}
+	if timeout, ok := source.(requestTimeoutSource); ok {
+		timeout.SetRequestTimeout(cfg.ScraperTimeout)
+	}
 	server := mcpsrv.NewServer(source, "dev", &mcpsrv.ServerOptions{
-		EnableSearch: cfg.EnableSearch,
-		EnableAdmin:  cfg.EnableAdmin,
+		Profile:         cfg.Profile,
+		EnableSearch:    cfg.EnableSearch,
+		EnableAdmin:     cfg.EnableAdmin,
+		WatchTopProduct: cfg.WatchTopProduct,
+		StructuredOnly:  cfg.StructuredOnly,
+		ToolTimeout:     cfg.ToolTimeout,
+		MaxItems:        cfg.MaxItems,
+		Progress:        cfg.Progress,
+		TrendingSearch:  cfg.TrendingSearch,
 	})
+	if cfg.WatchTopProduct {
+		go mcpsrv.WatchTopProduct(ctx, server, source, cfg.WatchInterval)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -39,11 +74,17 @@ func main() {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
+	mux.Handle("/readyz", mcpsrv.ReadyHandler(source))
 	mcpHandler := mcpsrv.NewHandler(server, mcpsrv.StreamableOptions(cfg))
 	mux.Handle("/mcp", mcpsrv.WrapMCPHandler(mcpHandler, cfg))
 
+	if persistent, ok := source.(persistentCacheSource); ok && persistent.PersistentCache() {
+		// The disk cache expires entries itself; clearing it would defeat
+		// the warm start.
+		cfg.CacheClearInterval = 0
+	}
 	if cfg.CacheClearInterval > 0 {
-		if clearable, ok := any(source).(cacheClearSource); ok {
+		if clearable, ok := source.(cacheClearSource); ok {
 			go func() {
 				ticker := time.NewTicker(cfg.CacheClearInterval)
 				defer ticker.Stop()
@@ -78,7 +119,7 @@ func main() {
 	}()
 
 	log.Printf("phtui-mcp listening on %s", httpServer.Addr)
-	err := httpServer.ListenAndServe()
+	err = httpServer.ListenAndServe()
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("server failed: %v", err)
 	}
@@ -17,9 +17,11 @@ func FromProduct(p types.Product) Product {
 		Tagline:      p.Tagline(),
 		Votes:        p.VoteCount(),
 		Comments:     p.CommentCount(),
+		Rating:       p.Rating(),
 		Rank:         p.Rank(),
 		ThumbnailURL: p.ThumbnailURL(),
 		Categories:   append([]string(nil), p.Categories()...),
+		Offline:      p.Offline(),
 	}
 }
 
@@ -43,6 +45,14 @@ func FromCategories(categories []types.CategoryLink) []Category {
 	return out
 }
 
+func FromRankHistory(points []types.RankPoint) []RankPoint {
+	out := make([]RankPoint, 0, len(points))
+	for _, p := range points {
+		out = append(out, RankPoint{Date: formatDate(p.Date()), Rank: p.Rank()})
+	}
+	return out
+}
+
 func FromProductDetail(pd types.ProductDetail) ProductDetail {
 	pricingType, pricingAmount, pricingPeriod := parsePricing(pd.PricingInfo())
 	pros := make([]ProCon, 0)
@@ -56,10 +66,7 @@ func FromProductDetail(pd types.ProductDetail) ProductDetail {
 		pros = append(pros, pc)
 	}
 
-	launchDate := ""
-	if !pd.LaunchDate().IsZero() {
-		launchDate = pd.LaunchDate().Format(time.DateOnly)
-	}
+	launchDate := formatDate(pd.FirstLaunchDate())
 
 	return ProductDetail{
 		Product:       FromProduct(pd.Product()),
@@ -70,18 +77,48 @@ func FromProductDetail(pd types.ProductDetail) ProductDetail {
 		MakerComment:  pd.MakerComment(),
 		WebsiteURL:    pd.WebsiteURL(),
 		SocialLinks:   append([]string(nil), pd.SocialLinks()...),
+		Links:         copyLinks(pd.Links()),
+		GalleryImages: append([]string(nil), pd.GalleryImages()...),
 		MakerName:     pd.MakerName(),
 		MakerProfile:  pd.MakerProfileURL(),
 		PricingInfo:   pd.PricingInfo(),
 		PricingType:   pricingType,
 		PricingAmount: pricingAmount,
 		PricingPeriod: pricingPeriod,
+		PricingRaw:    append([]string(nil), pd.PricingRaw()...),
 		LaunchDate:    launchDate,
+		FirstLaunch:   launchDate,
+		LatestLaunch:  formatDate(pd.LatestLaunchDate()),
 		Pros:          pros,
 		Cons:          cons,
 	}
 }
 
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+func copyLinks(links map[string][]string) map[string][]string {
+	if len(links) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(links))
+	for category, urls := range links {
+		out[category] = append([]string(nil), urls...)
+	}
+	return out
+}
+
+// PricingType classifies raw pricing info as "free", "paid", "unknown",
+// or "" when no pricing info is available.
+func PricingType(pricingInfo string) string {
+	pricingType, _, _ := parsePricing(pricingInfo)
+	return pricingType
+}
+
 func parsePricing(pricingInfo string) (string, string, string) {
 	s := strings.TrimSpace(pricingInfo)
 	if s == "" {
@@ -19,26 +19,32 @@ func TestDTOJSONMarshal(t *testing.T) {
 		"demo",
 		"https://img.example/demo.png",
 		1,
+		0,
+		false,
 	)
-	detail := types.NewProductDetail(
-		product,
-		"Detailed description",
-		4.8,
-		12,
-		200,
-		"Maker says hi",
-		"https://demo.example",
-		[]string{"Developer Tools"},
-		[]string{"https://x.com/demo"},
-		time.Date(2026, 2, 26, 9, 0, 0, 0, time.UTC),
-		"Maker",
-		"https://producthunt.com/@maker",
-		[]types.ProConTag{
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:         product,
+		Description:     "Detailed description",
+		Rating:          4.8,
+		ReviewCount:     12,
+		FollowerCount:   200,
+		MakerComment:    "Maker says hi",
+		WebsiteURL:      "https://demo.example",
+		Categories:      []string{"Developer Tools"},
+		SocialLinks:     []string{"https://x.com/demo"},
+		FirstLaunch:     time.Date(2026, 2, 26, 9, 0, 0, 0, time.UTC),
+		LatestLaunch:    time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC),
+		MakerName:       "Maker",
+		MakerProfileURL: "https://producthunt.com/@maker",
+		ProConTags: []types.ProConTag{
 			types.NewProConTag("Fast", "Positive", 5),
 			types.NewProConTag("Expensive", "Negative", 2),
 		},
-		"$20/month",
-	)
+		PricingInfo:   "$20/month",
+		Links:         map[string][]string{types.LinkGitHub: {"https://github.com/demo/demo"}},
+		PricingRaw:    []string{"From $20/month", "Team: $50/month billed yearly"},
+		GalleryImages: []string{"https://ph-files.imgix.net/shot.png"},
+	})
 
 	productDTO := FromProduct(product)
 	detailDTO := FromProductDetail(detail)
@@ -47,6 +53,12 @@ func TestDTOJSONMarshal(t *testing.T) {
 	if _, err := json.Marshal(productDTO); err != nil {
 		t.Fatalf("marshal product dto: %v", err)
 	}
+	if productDTO.Offline {
+		t.Fatalf("online product marked offline")
+	}
+	if !FromProduct(types.NewProduct("Gone", "", nil, 0, 0, "gone", "", 1, 0, true)).Offline {
+		t.Fatalf("offline flag not carried into dto")
+	}
 	b, err := json.Marshal(detailDTO)
 	if err != nil {
 		t.Fatalf("marshal detail dto: %v", err)
@@ -63,6 +75,12 @@ func TestDTOJSONMarshal(t *testing.T) {
 	if got["slug"] != "demo" {
 		t.Fatalf("unexpected slug: %v", got["slug"])
 	}
+	if got["votes"] != float64(42) || got["comments"] != float64(7) {
+		t.Fatalf("unexpected counts: votes %v, comments %v", got["votes"], got["comments"])
+	}
+	if gallery, ok := got["gallery_images"].([]any); !ok || len(gallery) != 1 || gallery[0] != "https://ph-files.imgix.net/shot.png" {
+		t.Fatalf("unexpected gallery_images: %v", got["gallery_images"])
+	}
 	if got["pricing_type"] != "paid" {
 		t.Fatalf("unexpected pricing_type: %v", got["pricing_type"])
 	}
@@ -75,6 +93,15 @@ func TestDTOJSONMarshal(t *testing.T) {
 	if got["launch_date"] != "2026-02-26" {
 		t.Fatalf("unexpected launch_date: %v", got["launch_date"])
 	}
+	if got["first_launch_date"] != "2026-02-26" || got["latest_launch_date"] != "2026-09-01" {
+		t.Fatalf("unexpected launch dates: %v %v", got["first_launch_date"], got["latest_launch_date"])
+	}
+	if links, ok := got["links"].(map[string]any); !ok || links["github"] == nil {
+		t.Fatalf("unexpected links: %v", got["links"])
+	}
+	if raw, ok := got["pricing_raw"].([]any); !ok || len(raw) != 2 || raw[0] != "From $20/month" {
+		t.Fatalf("unexpected pricing_raw: %v", got["pricing_raw"])
+	}
 }
 
 func TestDTOFields(t *testing.T) {
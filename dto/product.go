@@ -6,7 +6,10 @@ type Product struct {
 	Tagline      string   `json:"tagline"`
 	Votes        int      `json:"votes"`
 	Comments     int      `json:"comments"`
+	Rating       float64  `json:"rating,omitempty"`
 	Rank         int      `json:"rank"`
 	ThumbnailURL string   `json:"thumbnail_url"`
 	Categories   []string `json:"categories"`
+	// Offline is true when Product Hunt marks the product "no longer online".
+	Offline bool `json:"offline,omitempty"`
 }
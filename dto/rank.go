@@ -0,0 +1,6 @@
+package dto
+
+type RankPoint struct {
+	Date string `json:"date"`
+	Rank int    `json:"rank"`
+}
@@ -0,0 +1,252 @@
+// Package fixtures refreshes the Product Hunt pages saved in testdata from the
+// live site, so parser tests can follow markup changes, and reports fields the
+// parsers no longer find on the new pages.
+package fixtures
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// DefaultBaseURL is the site fixtures are fetched from.
+const DefaultBaseURL = "https://www.producthunt.com"
+
+const maxPageSize = 10 << 20
+
+// fixture is a testdata file, the page it was saved from, and how to count
+// the fields the parsers read from it.
+type fixture struct {
+	file   string
+	path   string
+	fields func([]byte) (map[string]int, error)
+}
+
+// The days whose leaderboards the leaderboard fixtures hold.
+var (
+	dailyFixtureDate  = time.Date(2025, 2, 18, 0, 0, 0, 0, time.UTC)
+	weeklyFixtureDate = time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+)
+
+// all lists the fixtures saved from real pages. Hand-written fixtures such
+// as leaderboard_empty.html, category_products_page2.html, upcoming.html and
+// product_unavailable.html are not refreshed. There is no search fixture:
+// search pages sit behind a Cloudflare challenge.
+var all = []fixture{
+	{"leaderboard_daily.html", types.Daily.URLPath(dailyFixtureDate), leaderboardFields(types.Daily)},
+	{"leaderboard_weekly.html", types.Weekly.URLPath(weeklyFixtureDate), leaderboardFields(types.Weekly)},
+	{"product_detail.html", "/products/tanka", detailFields},
+	{"category_products.html", "/categories/ai-agents", categoryFields},
+	{"category_tree.html", "/categories/ai-coding-agents", treeFields("ai-coding-agents")},
+}
+
+// Report describes one fixture after a refresh.
+type Report struct {
+	File string
+	URL  string
+	// Changed is set when the live page differs from the saved fixture.
+	Changed bool
+	// Written is set when the fixture file was overwritten.
+	Written bool
+	// EmptyFields lists fields the saved fixture has but the live page no
+	// longer yields, e.g. "taglines" when no product has a tagline.
+	EmptyFields []string
+	// ParseErr is set when the parsers fail on the live page. The page is
+	// still saved with --write: it is the fixture a parser fix needs.
+	ParseErr error
+	// Err is set when the page couldn't be fetched or saved.
+	Err error
+}
+
+// Refresh fetches every fixture's page from base and compares it with the
+// copy in dir. Changed fixtures are overwritten only when write is set, so
+// a call without it is a dry run.
+func Refresh(client *http.Client, base, dir string, write bool) []Report {
+	base = strings.TrimRight(base, "/")
+	reports := make([]Report, 0, len(all))
+	for _, f := range all {
+		r := Report{File: f.file, URL: base + f.path}
+		refreshOne(client, &r, filepath.Join(dir, f.file), f.fields, write)
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+func refreshOne(client *http.Client, r *Report, path string, fields func([]byte) (map[string]int, error), write bool) {
+	live, err := fetch(client, r.URL)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		r.Err = err
+		return
+	}
+
+	newFields, err := fields(live)
+	if err != nil {
+		r.ParseErr = err
+	} else if saved != nil {
+		if oldFields, err := fields(saved); err == nil {
+			r.EmptyFields = newlyEmpty(oldFields, newFields)
+		}
+	}
+
+	r.Changed = !bytes.Equal(saved, live)
+	if !r.Changed || !write {
+		return
+	}
+	if err := os.WriteFile(path, live, 0o644); err != nil {
+		r.Err = err
+		return
+	}
+	r.Written = true
+}
+
+func fetch(client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", scraper.UserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxPageSize {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", scraper.ErrBodyTooLarge, maxPageSize)
+	}
+	return body, nil
+}
+
+// newlyEmpty returns the fields, sorted, that old has and new lacks.
+func newlyEmpty(old, new map[string]int) []string {
+	var empty []string
+	for field, n := range old {
+		if n > 0 && new[field] == 0 {
+			empty = append(empty, field)
+		}
+	}
+	sort.Strings(empty)
+	return empty
+}
+
+// Print writes one line per report to w, plus a warning line for each
+// fixture whose live page lost fields.
+func Print(w io.Writer, reports []Report, write bool) {
+	for _, r := range reports {
+		switch {
+		case r.Err != nil:
+			fmt.Fprintf(w, "%-12s %s: %v\n", "error", r.File, r.Err)
+		case r.Written:
+			fmt.Fprintf(w, "%-12s %s\n", "updated", r.File)
+		case r.Changed:
+			fmt.Fprintf(w, "%-12s %s\n", "would update", r.File)
+		default:
+			fmt.Fprintf(w, "%-12s %s\n", "unchanged", r.File)
+		}
+		if r.ParseErr != nil {
+			fmt.Fprintf(w, "  warning: parsing %s fails: %v\n", r.URL, r.ParseErr)
+		}
+		if len(r.EmptyFields) > 0 {
+			fmt.Fprintf(w, "  warning: %s no longer yields %s\n", r.URL, strings.Join(r.EmptyFields, ", "))
+		}
+	}
+	if !write {
+		fmt.Fprintln(w, "dry run; pass --write to overwrite the fixtures")
+	}
+}
+
+// productFields counts the products and how many of them have each field.
+func productFields(products []types.Product) map[string]int {
+	fields := map[string]int{"products": len(products)}
+	for _, p := range products {
+		add := func(field string, ok bool) {
+			if ok {
+				fields[field]++
+			}
+		}
+		add("names", p.Name() != "")
+		add("taglines", p.Tagline() != "")
+		add("votes", p.VoteCount() > 0)
+		add("thumbnails", p.ThumbnailURL() != "")
+		add("categories", len(p.Categories()) > 0)
+	}
+	return fields
+}
+
+func leaderboardFields(period types.Period) func([]byte) (map[string]int, error) {
+	return func(page []byte) (map[string]int, error) {
+		products, err := scraper.ParseLeaderboard(bytes.NewReader(page), period)
+		if err != nil {
+			return nil, err
+		}
+		return productFields(products), nil
+	}
+}
+
+func detailFields(page []byte) (map[string]int, error) {
+	detail, err := scraper.ParseProductDetail(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	has := func(ok bool) int {
+		if ok {
+			return 1
+		}
+		return 0
+	}
+	return map[string]int{
+		"name":        has(detail.Product().Name() != ""),
+		"tagline":     has(detail.Product().Tagline() != ""),
+		"description": has(detail.Description() != ""),
+		"rating":      has(detail.Rating() > 0),
+		"website":     has(detail.WebsiteURL() != ""),
+		"maker":       has(detail.MakerName() != ""),
+		"categories":  len(detail.Categories()),
+		"launch date": has(!detail.LaunchDate().IsZero()),
+		"gallery":     len(detail.GalleryImages()),
+	}, nil
+}
+
+func categoryFields(page []byte) (map[string]int, error) {
+	products, related, err := scraper.ParseCategoryProducts(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	fields := productFields(products)
+	fields["related categories"] = len(related)
+	return fields, nil
+}
+
+func treeFields(slug string) func([]byte) (map[string]int, error) {
+	return func(page []byte) (map[string]int, error) {
+		tree, err := scraper.ParseCategoryTree(bytes.NewReader(page), slug)
+		if err != nil {
+			return nil, err
+		}
+		parent := 0
+		if tree.Parent() != nil {
+			parent = 1
+		}
+		return map[string]int{"parent": parent, "subcategories": len(tree.Children())}, nil
+	}
+}
@@ -0,0 +1,99 @@
+package fixtures
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const savedLeaderboard = `<html><body><main>
+<h1 data-test="leaderboard-title">Best of February 18, 2025</h1>
+<section data-test="post-item-1">
+<div data-test="post-name-1"><a href="/products/alpha">Alpha</a></div>
+<span class="text-secondary">Notes that write themselves</span>
+<button data-test="vote-button"><p>120</p></button>
+</section>
+</main></body></html>`
+
+// liveLeaderboard is savedLeaderboard after a markup change that moved the
+// tagline out of the parser's reach.
+var liveLeaderboard = strings.Replace(savedLeaderboard, `<span class="text-secondary">`, `<span class="tagline">`, 1)
+
+const categoryPage = `<html><body><main><a href="/products/soon">Soon</a></main></body></html>`
+
+func TestRefreshDryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/leaderboard/daily/2025/2/18":
+			w.Write([]byte(liveLeaderboard))
+		case "/categories/ai-agents":
+			w.Write([]byte(categoryPage))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"leaderboard_daily.html": savedLeaderboard,
+		"category_products.html": categoryPage,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reports := Refresh(srv.Client(), srv.URL, dir, false)
+	byFile := make(map[string]Report, len(reports))
+	for _, r := range reports {
+		byFile[r.File] = r
+	}
+
+	daily := byFile["leaderboard_daily.html"]
+	if daily.Err != nil || !daily.Changed || daily.Written {
+		t.Fatalf("daily = %+v, want changed and not written", daily)
+	}
+	if !reflect.DeepEqual(daily.EmptyFields, []string{"taglines"}) {
+		t.Errorf("daily empty fields = %v, want [taglines]", daily.EmptyFields)
+	}
+	if saved, _ := os.ReadFile(filepath.Join(dir, "leaderboard_daily.html")); string(saved) != savedLeaderboard {
+		t.Error("dry run overwrote the fixture")
+	}
+	if category := byFile["category_products.html"]; category.Err != nil || category.Changed {
+		t.Errorf("category = %+v, want unchanged", category)
+	}
+	if _, ok := byFile["upcoming.html"]; ok {
+		t.Error("hand-written upcoming.html should not be refreshed")
+	}
+	if detail := byFile["product_detail.html"]; detail.Err == nil {
+		t.Error("a page that can't be fetched should report an error")
+	}
+
+	var out bytes.Buffer
+	Print(&out, reports, false)
+	for _, want := range []string{
+		"would update leaderboard_daily.html",
+		"no longer yields taglines",
+		"unchanged    category_products.html",
+		"error        product_detail.html",
+		"dry run; pass --write",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	reports = Refresh(srv.Client(), srv.URL, dir, true)
+	if saved, _ := os.ReadFile(filepath.Join(dir, "leaderboard_daily.html")); string(saved) != liveLeaderboard {
+		t.Error("--write should overwrite the changed fixture")
+	}
+	if !reports[0].Written {
+		t.Errorf("daily report = %+v, want written", reports[0])
+	}
+}
@@ -1,16 +1,42 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/fixtures"
+	"github.com/qyinm/phtui/sources"
 	"github.com/qyinm/phtui/ui"
 )
 
+// trendingSearchSource is implemented by sources that can answer an empty
+// search query with today's leaderboard.
+type trendingSearchSource interface {
+	SetTrendingOnEmptyQuery(on bool)
+}
+
 func main() {
-	source := scraper.New()
+	sourceName := flag.String("source", os.Getenv("PHTUI_SOURCE"),
+		"data source: "+strings.Join(sources.Names(), ", ")+" (default "+sources.Default+"; env PHTUI_SOURCE)")
+	flag.Parse()
+
+	if flag.Arg(0) == "refresh-fixtures" {
+		os.Exit(refreshFixtures(flag.Args()[1:]))
+	}
+
+	source, err := sources.New(*sourceName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	if trending, ok := source.(trendingSearchSource); ok {
+		trending.SetTrendingOnEmptyQuery(true)
+	}
 	m := ui.NewModel(source)
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
@@ -18,3 +44,26 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// refreshFixtures runs "phtui refresh-fixtures": it fetches the live pages
+// behind the parser fixtures and reports which would change. Only --write
+// overwrites them.
+func refreshFixtures(args []string) int {
+	fs := flag.NewFlagSet("refresh-fixtures", flag.ContinueOnError)
+	write := fs.Bool("write", false, "overwrite changed fixtures (default: dry run)")
+	dir := fs.String("dir", "testdata", "fixture directory")
+	base := fs.String("base", fixtures.DefaultBaseURL, "site to fetch the pages from")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	reports := fixtures.Refresh(client, *base, *dir, *write)
+	fixtures.Print(os.Stdout, reports, *write)
+	for _, r := range reports {
+		if r.Err != nil {
+			return 1
+		}
+	}
+	return 0
+}
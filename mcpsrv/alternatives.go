@@ -0,0 +1,192 @@
+package mcpsrv
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	defaultAlternativesLimit = 5
+	// maxAlternativesLimit caps how many search results one find_alternatives
+	// call fetches details for.
+	maxAlternativesLimit    = 10
+	alternativesConcurrency = 4
+	// alternativesFetchBudget bounds how long a call waits for detail pages;
+	// products still loading after it are reported as failed.
+	alternativesFetchBudget = 20 * time.Second
+)
+
+type findAlternativesArgs struct {
+	Query string `json:"query" jsonschema:"Search query, e.g. a product name or what it does"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Optional number of search results to compare (default 5, at most 10)"`
+}
+
+// alternative is one search result with the detail fields useful for
+// comparing it with the others.
+type alternative struct {
+	dto.Product
+	Rating        float64      `json:"rating"`
+	ReviewCount   int          `json:"review_count"`
+	PricingInfo   string       `json:"pricing_info"`
+	PricingType   string       `json:"pricing_type"`
+	PricingAmount string       `json:"pricing_amount"`
+	PricingPeriod string       `json:"pricing_period"`
+	Pros          []dto.ProCon `json:"pros"`
+	Cons          []dto.ProCon `json:"cons"`
+	Error         string       `json:"error,omitempty"`
+}
+
+type findAlternativesOutput struct {
+	Query     string        `json:"query"`
+	Total     int           `json:"total"`
+	Failed    int           `json:"failed"`
+	Items     []alternative `json:"items"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// findAlternativesHandler searches for the query and fetches the details of
+// the top results, so an agent can compare them in one call. A product
+// whose detail can't be fetched within the budget keeps its search fields
+// and an error instead of failing the call.
+func findAlternativesHandler(ctx context.Context, _ *mcp.CallToolRequest, args findAlternativesArgs, source types.ProductSource) (*mcp.CallToolResult, findAlternativesOutput, error) {
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		return errorToolResult("query is required"), findAlternativesOutput{}, nil
+	}
+	if args.Limit < 0 {
+		return errorToolResult("limit must not be negative"), findAlternativesOutput{}, nil
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultAlternativesLimit
+	}
+	limit = min(limit, maxAlternativesLimit)
+
+	searchSource, ok := source.(types.SearchSource)
+	if !ok {
+		return errorToolResult("search is not supported by this source"), findAlternativesOutput{}, nil
+	}
+	products, _, _, _, _, err := types.FetchSearchProductsPage(ctx, searchSource, query, 1)
+	if err != nil {
+		return errorToolResult(searchErrorMessage(err)), findAlternativesOutput{}, nil
+	}
+
+	var candidates []types.Product
+	for _, p := range products {
+		if p.Slug() != "" && len(candidates) < limit {
+			candidates = append(candidates, p)
+		}
+	}
+	items := fetchAlternatives(ctx, source, candidates, alternativesFetchBudget)
+
+	failed := 0
+	for _, item := range items {
+		if item.Error != "" {
+			failed++
+		}
+	}
+	return nil, findAlternativesOutput{
+		Query:  query,
+		Total:  len(items),
+		Failed: failed,
+		Items:  items,
+	}, nil
+}
+
+// fetchAlternatives fetches each product's detail with bounded concurrency,
+// waiting at most budget (or until ctx is done) for all of them.
+func fetchAlternatives(ctx context.Context, source types.ProductSource, products []types.Product, budget time.Duration) []alternative {
+	items := make([]alternative, len(products))
+	for i, p := range products {
+		items[i] = alternative{Product: dto.FromProduct(p), Error: "detail fetch timed out"}
+	}
+	if len(products) == 0 {
+		return items
+	}
+	// Fetches still running when the budget runs out are canceled.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type fetched struct {
+		i      int
+		detail types.ProductDetail
+		err    error
+	}
+	// Buffered so fetches that finish after the budget don't block.
+	results := make(chan fetched, len(products))
+	progress := progressFrom(ctx)
+	sem := make(chan struct{}, alternativesConcurrency)
+	for i, p := range products {
+		go func(i int, slug string) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			detail, err := types.FetchProductDetail(ctx, source, slug)
+			if err != nil {
+				progress.step(ctx, len(products), slug+": lookup failed")
+			} else {
+				progress.step(ctx, len(products), slug+": fetched")
+			}
+			results <- fetched{i, detail, err}
+		}(i, p.Slug())
+	}
+
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+	for range products {
+		select {
+		case r := <-results:
+			if r.err != nil {
+				items[r.i].Error = detailErrorMessage(r.err)
+				continue
+			}
+			items[r.i] = withDetail(items[r.i].Product, r.detail)
+		case <-timer.C:
+			return items
+		case <-ctx.Done():
+			return items
+		}
+	}
+	return items
+}
+
+// withDetail fills in the comparison fields of a search result from its
+// detail page.
+func withDetail(product dto.Product, detail types.ProductDetail) alternative {
+	d := dto.FromProductDetail(detail)
+	return alternative{
+		Product:       product,
+		Rating:        d.Rating,
+		ReviewCount:   d.ReviewCount,
+		PricingInfo:   d.PricingInfo,
+		PricingType:   d.PricingType,
+		PricingAmount: d.PricingAmount,
+		PricingPeriod: d.PricingPeriod,
+		Pros:          d.Pros,
+		Cons:          d.Cons,
+	}
+}
+
+// searchErrorMessage describes a failed search, calling out Cloudflare
+// blocks since retrying those right away doesn't help.
+func searchErrorMessage(err error) string {
+	if strings.Contains(strings.ToLower(err.Error()), "cloudflare") {
+		return "search blocked by Cloudflare challenge; retryable=false"
+	}
+	return "search failed"
+}
+
+// detailErrorMessage describes a failed detail fetch, calling out products
+// Product Hunt has hidden or taken offline so they aren't retried as parse
+// or network failures.
+func detailErrorMessage(err error) string {
+	if errors.Is(err, types.ErrProductUnavailable) {
+		return "product is hidden or no longer available on Product Hunt; retryable=false"
+	}
+	return "fetch product detail failed"
+}
@@ -11,6 +11,7 @@ import (
 
 type Config struct {
 	Port               string
+	Profile            string
 	AllowedOrigins     []string
 	Stateless          bool
 	EnableSearch       bool
@@ -19,6 +20,16 @@ type Config struct {
 	Burst              int
 	SessionTimeout     time.Duration
 	CacheClearInterval time.Duration
+	WatchTopProduct    bool
+	WatchInterval      time.Duration
+	StructuredOnly     bool
+	ToolTimeout        time.Duration
+	ShutdownGrace      time.Duration
+	MaxItems           int
+	Progress           bool
+	TrendingSearch     bool
+	Source             string
+	ScraperTimeout     time.Duration
 }
 
 func LoadConfig() Config {
@@ -29,6 +40,7 @@ func LoadConfig() Config {
 
 	cfg := Config{
 		Port:               port,
+		Profile:            parseProfile(os.Getenv("PHTUI_MCP_PROFILE")),
 		AllowedOrigins:     parseCSV(os.Getenv("PHTUI_MCP_ALLOWED_ORIGINS")),
 		Stateless:          parseBool(os.Getenv("PHTUI_MCP_STATELESS"), false),
 		EnableSearch:       parseBool(os.Getenv("PHTUI_MCP_ENABLE_SEARCH"), false),
@@ -37,6 +49,16 @@ func LoadConfig() Config {
 		Burst:              parseInt(os.Getenv("PHTUI_MCP_BURST"), 5),
 		SessionTimeout:     parseDuration(os.Getenv("PHTUI_MCP_SESSION_TIMEOUT"), 15*time.Minute),
 		CacheClearInterval: parseDuration(os.Getenv("PHTUI_MCP_CACHE_CLEAR_INTERVAL"), 30*time.Minute),
+		WatchTopProduct:    parseBool(os.Getenv("PHTUI_MCP_WATCH_TOP"), false),
+		WatchInterval:      parseDuration(os.Getenv("PHTUI_MCP_WATCH_INTERVAL"), 5*time.Minute),
+		StructuredOnly:     parseBool(os.Getenv("PHTUI_MCP_STRUCTURED_ONLY"), false),
+		ToolTimeout:        parseDuration(os.Getenv("PHTUI_MCP_TOOL_TIMEOUT"), 20*time.Second),
+		ShutdownGrace:      parseDuration(os.Getenv("PHTUI_MCP_SHUTDOWN_GRACE"), 5*time.Second),
+		MaxItems:           parseInt(os.Getenv("PHTUI_MCP_MAX_ITEMS"), 0),
+		Progress:           parseBool(os.Getenv("PHTUI_MCP_PROGRESS"), false),
+		TrendingSearch:     parseBool(os.Getenv("PHTUI_MCP_SEARCH_EMPTY_TRENDING"), false),
+		Source:             strings.TrimSpace(os.Getenv("PHTUI_SOURCE")),
+		ScraperTimeout:     parseDuration(os.Getenv("PHTUI_SCRAPER_TIMEOUT"), 0),
 	}
 
 	if cfg.RPS <= 0 {
@@ -68,6 +90,15 @@ func parseCSV(raw string) []string {
 	return out
 }
 
+// parseProfile returns the tool profile named by raw, falling back to
+// ProfileFull for empty or unknown values.
+func parseProfile(raw string) string {
+	if strings.EqualFold(strings.TrimSpace(raw), ProfileMinimal) {
+		return ProfileMinimal
+	}
+	return ProfileFull
+}
+
 func parseBool(raw string, fallback bool) bool {
 	v := strings.TrimSpace(raw)
 	if v == "" {
@@ -0,0 +1,124 @@
+package mcpsrv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	categoryCountConcurrency = 4
+	// maxCategoryCountSlugs caps how many categories one category_counts call
+	// may ask for.
+	maxCategoryCountSlugs = 20
+)
+
+type categoryCountsArgs struct {
+	Slugs []string `json:"slugs" jsonschema:"Category slugs to count products for (at most 20)"`
+}
+
+type categoryCount struct {
+	Slug  string `json:"slug"`
+	Count int    `json:"count"`
+	// Pages is the category's page count; 0 when the source doesn't page
+	// categories and Count is the first listing only.
+	Pages int    `json:"pages,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type categoryCountsOutput struct {
+	Total     int             `json:"total"`
+	Failed    int             `json:"failed"`
+	Items     []categoryCount `json:"items"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// categoryCountsHandler returns how many products each requested category
+// lists. Categories are fetched with bounded concurrency through the source,
+// which caches category pages, so counting a category first makes a later
+// category_get_products for it cheap. A failed fetch is reported on its own
+// item instead of failing the call.
+func categoryCountsHandler(ctx context.Context, _ *mcp.CallToolRequest, args categoryCountsArgs, source types.ProductSource) (*mcp.CallToolResult, categoryCountsOutput, error) {
+	var slugs []string
+	seen := make(map[string]struct{}, len(args.Slugs))
+	for _, raw := range args.Slugs {
+		slug := strings.TrimSpace(raw)
+		if slug == "" {
+			continue
+		}
+		if _, ok := seen[slug]; ok {
+			continue
+		}
+		seen[slug] = struct{}{}
+		slugs = append(slugs, slug)
+	}
+	if len(slugs) == 0 {
+		return errorToolResult("slugs is required"), categoryCountsOutput{}, nil
+	}
+	if len(slugs) > maxCategoryCountSlugs {
+		return errorToolResult(fmt.Sprintf("too many slugs: %d; at most %d per call", len(slugs), maxCategoryCountSlugs)), categoryCountsOutput{}, nil
+	}
+
+	items := make([]categoryCount, len(slugs))
+	progress := progressFrom(ctx)
+	sem := make(chan struct{}, categoryCountConcurrency)
+	var wg sync.WaitGroup
+	for i, slug := range slugs {
+		wg.Add(1)
+		go func(i int, slug string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			items[i].Slug = slug
+			count, pages, err := countCategoryProducts(ctx, source, slug)
+			if err != nil {
+				items[i].Error = "fetch category products failed"
+				progress.step(ctx, len(slugs), slug+": fetch failed")
+				return
+			}
+			items[i].Count, items[i].Pages = count, pages
+			progress.step(ctx, len(slugs), fmt.Sprintf("%s: %d products", slug, count))
+		}(i, slug)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, item := range items {
+		if item.Error != "" {
+			failed++
+		}
+	}
+	return nil, categoryCountsOutput{
+		Total:  len(items),
+		Failed: failed,
+		Items:  items,
+	}, nil
+}
+
+// countCategoryProducts counts the products a category lists across all its
+// pages. Every page but the last holds as many products as the first, so
+// only those two are fetched. Sources without category pages count their
+// single listing and report 0 pages.
+func countCategoryProducts(ctx context.Context, source types.ProductSource, slug string) (int, int, error) {
+	pageSource, ok := source.(types.CategoryPageSource)
+	if !ok {
+		products, _, err := types.FetchCategoryProducts(ctx, source, slug)
+		return len(products), 0, err
+	}
+	first, _, _, pages, err := types.FetchCategoryProductsPage(ctx, pageSource, slug, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pages <= 1 {
+		return len(first), 1, nil
+	}
+	last, _, _, _, err := types.FetchCategoryProductsPage(ctx, pageSource, slug, pages)
+	if err != nil {
+		return 0, 0, err
+	}
+	return (pages-1)*len(first) + len(last), pages, nil
+}
@@ -0,0 +1,129 @@
+package mcpsrv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	// categoryFeedPrefix starts every category feed URI.
+	categoryFeedPrefix = "phtui://category/"
+	// CategoryFeedTemplate is the first page of a category's products.
+	CategoryFeedTemplate = categoryFeedPrefix + "{slug}"
+	// CategoryFeedPageTemplate is a later page of a category's products.
+	CategoryFeedPageTemplate = categoryFeedPrefix + "{slug}/page/{n}"
+)
+
+type categoryFeedOutput struct {
+	Slug       string        `json:"slug"`
+	Name       string        `json:"name,omitempty"`
+	Page       int           `json:"page"`
+	Pages      int           `json:"pages"`
+	ItemsCount int           `json:"items_count"`
+	Items      []dto.Product `json:"items"`
+	NextURI    string        `json:"next_uri,omitempty"`
+}
+
+// categoryFeedURI returns the URI of page n of the category's feed.
+func categoryFeedURI(slug string, page int) string {
+	if page <= 1 {
+		return categoryFeedPrefix + slug
+	}
+	return fmt.Sprintf("%s%s/page/%d", categoryFeedPrefix, slug, page)
+}
+
+// parseCategoryFeedURI returns the slug and page a feed URI names.
+func parseCategoryFeedURI(uri string) (string, int, bool) {
+	rest, ok := strings.CutPrefix(uri, categoryFeedPrefix)
+	if !ok {
+		return "", 0, false
+	}
+	slug, pageStr, paged := strings.Cut(rest, "/page/")
+	if slug == "" || strings.Contains(slug, "/") {
+		return "", 0, false
+	}
+	if !paged {
+		return slug, 1, true
+	}
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		return "", 0, false
+	}
+	return slug, page, true
+}
+
+// addCategoryFeeds exposes each category's products as resources: the
+// CategoryFeedTemplate and CategoryFeedPageTemplate templates for any slug,
+// and one listed resource per category in AllCategories so resource clients
+// can enumerate them. Page n of a feed is page n of the category on Product
+// Hunt, as category_get_products returns it, offline products included.
+// Sources without category pages serve page 1 only.
+func addCategoryFeeds(server *mcp.Server, source types.ProductSource) {
+	handler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		slug, page, ok := parseCategoryFeedURI(uri)
+		if !ok {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		var (
+			products []types.Product
+			pages    = 1
+			err      error
+		)
+		if pageSource, ok := source.(types.CategoryPageSource); ok {
+			products, _, _, pages, err = types.FetchCategoryProductsPage(ctx, pageSource, slug, page)
+		} else if page == 1 {
+			products, _, err = types.FetchCategoryProducts(ctx, source, slug)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fetch category %s: %w", slug, err)
+		}
+		pages = max(pages, 1)
+		if page > pages || (page > 1 && len(products) == 0) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		out := categoryFeedOutput{Slug: slug, Page: page, Pages: pages, ItemsCount: len(products), Items: dto.FromProducts(products)}
+		if idx := types.CategoryIndexBySlug(slug); idx >= 0 {
+			out.Name = types.AllCategories[idx].Name()
+		}
+		if page < pages {
+			out.NextURI = categoryFeedURI(slug, page+1)
+		}
+		b, err := json.Marshal(out)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: "application/json", Text: string(b)},
+		}}, nil
+	}
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: CategoryFeedTemplate,
+		Name:        "category_feed",
+		Description: "Products on the first page of a category on Product Hunt. The result's next_uri links the next page.",
+		MIMEType:    "application/json",
+	}, handler)
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: CategoryFeedPageTemplate,
+		Name:        "category_feed_page",
+		Description: "Page n of a category's products, as Product Hunt pages them.",
+		MIMEType:    "application/json",
+	}, handler)
+	for _, c := range types.AllCategories {
+		server.AddResource(&mcp.Resource{
+			URI:         categoryFeedURI(c.Slug(), 1),
+			Name:        "category_" + c.Slug(),
+			Title:       c.Name(),
+			Description: "Products in the " + c.Name() + " category.",
+			MIMEType:    "application/json",
+		}, handler)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// NewHandler serves server over streamable HTTP. Every session shares the one
+// server, so a client that retries initialize on its session (with its
+// Mcp-Session-Id) is answered on that session instead of opening another.
 func NewHandler(server *mcp.Server, opts *mcp.StreamableHTTPOptions) http.Handler {
 	return mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
 		return server
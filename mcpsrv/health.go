@@ -0,0 +1,85 @@
+package mcpsrv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/types"
+)
+
+// readyCheckTimeout bounds the upstream request behind /readyz.
+const readyCheckTimeout = 10 * time.Second
+
+// readyCacheTTL is how long /readyz reuses a health check before running
+// another, so frequent probes don't each hit Product Hunt.
+const readyCacheTTL = 30 * time.Second
+
+// healthCheckSource is implemented by sources that can verify end to end that
+// they are able to fetch pages.
+type healthCheckSource interface {
+	HealthCheck(ctx context.Context) types.HealthReport
+}
+
+type healthCheckOutput struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+func healthCheckResult(report types.HealthReport) healthCheckOutput {
+	return healthCheckOutput{
+		Status:    string(report.Status()),
+		LatencyMS: report.Latency().Milliseconds(),
+		Detail:    report.Detail(),
+	}
+}
+
+// healthCheckHandler runs the source's health check: one uncached request to
+// Product Hunt reported as ok, blocked or error with its latency. A failing
+// check is a successful call whose status says so.
+func healthCheckHandler(ctx context.Context, _ *mcp.CallToolRequest, source types.ProductSource) (*mcp.CallToolResult, healthCheckOutput, error) {
+	checker, ok := source.(healthCheckSource)
+	if !ok {
+		return errorToolResult("health check is not supported by this source"), healthCheckOutput{}, nil
+	}
+	return nil, healthCheckResult(checker.HealthCheck(ctx)), nil
+}
+
+// ReadyHandler serves /readyz: it runs the source's health check and answers
+// 200 when scraping works and 503 when Product Hunt is blocking or failing,
+// with the report as JSON. A result is reused for readyCacheTTL. Sources
+// without a health check are always ready.
+func ReadyHandler(source types.ProductSource) http.Handler {
+	var (
+		mu      sync.Mutex
+		last    healthCheckOutput
+		checked time.Time
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		out := healthCheckOutput{Status: string(types.HealthOK)}
+		if checker, ok := source.(healthCheckSource); ok {
+			mu.Lock()
+			if checked.IsZero() || time.Since(checked) >= readyCacheTTL {
+				ctx, cancel := context.WithTimeout(r.Context(), readyCheckTimeout)
+				last = healthCheckResult(checker.HealthCheck(ctx))
+				cancel()
+				checked = time.Now()
+			}
+			out = last
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if out.Status != string(types.HealthOK) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	})
+}
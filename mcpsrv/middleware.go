@@ -24,7 +24,7 @@ func WrapMCPHandler(next http.Handler, cfg Config) http.Handler {
 
 	limiter := newTokenBucket(rps, burst)
 
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return recoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := strings.TrimSpace(r.Header.Get("Origin"))
 		if origin != "" {
 			if len(allowedOrigins) == 0 {
@@ -51,7 +51,7 @@ func WrapMCPHandler(next http.Handler, cfg Config) http.Handler {
 		}
 
 		next.ServeHTTP(w, r)
-	})
+	}))
 }
 
 type tokenBucket struct {
@@ -0,0 +1,116 @@
+package mcpsrv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+const pricingLookupConcurrency = 4
+
+// maxPricingLookups caps the detail pages one pricing filter fetches, so a
+// long list can't fan out into dozens of upstream requests. Products past
+// the cap are left out and the caller reports the result as partial.
+const maxPricingLookups = 20
+
+func parsePricingFilter(raw string) (string, error) {
+	v := strings.TrimSpace(strings.ToLower(raw))
+	switch v {
+	case "", "free", "paid":
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid pricing %q; expected free|paid", raw)
+	}
+}
+
+// filterByPricing keeps products whose detail pricing matches want ("free" or
+// "paid"). List pages carry no pricing, so details are fetched in list order,
+// pricingLookupConcurrency at a time, until limit products are kept (when
+// limit > 0) or maxPricingLookups details have been fetched; it reports
+// whether the cap left products unchecked. Products with unknown pricing
+// (or a failed detail fetch) are dropped unless includeUnknown is set.
+// When the call reports progress, each finished lookup sends a
+// notification naming the product and its pricing.
+func filterByPricing(ctx context.Context, source types.ProductSource, products []types.Product, want string, includeUnknown bool, limit int) ([]types.Product, bool) {
+	if want == "" {
+		return products, false
+	}
+
+	total := 0
+	for _, p := range products {
+		if p.Slug() != "" {
+			total++
+		}
+	}
+	total = min(total, maxPricingLookups)
+
+	out := make([]types.Product, 0, len(products))
+	looked := 0
+	for start := 0; start < len(products); {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		if looked == maxPricingLookups {
+			return out, true
+		}
+		end, lookups := start, 0
+		for ; end < len(products) && lookups < pricingLookupConcurrency && looked+lookups < maxPricingLookups; end++ {
+			if products[end].Slug() != "" {
+				lookups++
+			}
+		}
+		batch := products[start:end]
+		for i, pricing := range lookupPricing(ctx, source, batch, total) {
+			switch pricing {
+			case want:
+				out = append(out, batch[i])
+			case "free", "paid":
+			default:
+				if includeUnknown {
+					out = append(out, batch[i])
+				}
+			}
+		}
+		looked += lookups
+		start = end
+	}
+	return out, false
+}
+
+// lookupPricing fetches the pricing type of each product in batch at once,
+// leaving "" for products without a slug or whose lookup failed. total is
+// the lookup count progress notifications report against.
+func lookupPricing(ctx context.Context, source types.ProductSource, batch []types.Product, total int) []string {
+	pricing := make([]string, len(batch))
+	progress := progressFrom(ctx)
+	var wg sync.WaitGroup
+	for i, p := range batch {
+		if p.Slug() == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, slug string) {
+			defer wg.Done()
+			detail, err := types.FetchProductDetail(ctx, source, slug)
+			if err != nil {
+				progress.step(ctx, total, slug+": lookup failed")
+				return
+			}
+			pricing[i] = dto.PricingType(detail.PricingInfo())
+			progress.step(ctx, total, slug+": "+pricingLabel(pricing[i]))
+		}(i, p.Slug())
+	}
+	wg.Wait()
+	return pricing
+}
+
+func pricingLabel(pricing string) string {
+	if pricing == "" {
+		return "unknown pricing"
+	}
+	return pricing
+}
@@ -0,0 +1,61 @@
+package mcpsrv
+
+import (
+	"context"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type progressKey struct{}
+
+// progressReporter sends progress notifications for one tool call. A nil
+// reporter drops them.
+type progressReporter struct {
+	mu      sync.Mutex
+	session *mcp.ServerSession
+	token   any
+	done    int
+}
+
+// progressMiddleware lets tool handlers report progress on tools/call
+// requests that carry a progress token. Slow multi-fetch handlers (like the
+// per-product pricing lookups) then notify the client as each fetch finishes
+// instead of staying silent until the whole result is ready.
+func progressMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if call, ok := req.(*mcp.CallToolRequest); ok && call.Session != nil && call.Params != nil {
+			if token := call.Params.GetProgressToken(); token != nil {
+				ctx = context.WithValue(ctx, progressKey{}, &progressReporter{session: call.Session, token: token})
+			}
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// progressFrom returns the reporter for ctx, or nil when the client didn't
+// ask for progress or the server has it disabled.
+func progressFrom(ctx context.Context) *progressReporter {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(progressKey{}).(*progressReporter)
+	return p
+}
+
+// step records one finished unit of work out of total and notifies the
+// client. message describes the unit that just completed.
+func (p *progressReporter) step(ctx context.Context, total int, message string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	_ = p.session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: p.token,
+		Message:       message,
+		Progress:      float64(p.done),
+		Total:         float64(total),
+	})
+}
@@ -0,0 +1,118 @@
+package mcpsrv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+// maxRangeDays caps how many daily leaderboards one leaderboard_range call
+// fetches.
+const maxRangeDays = 31
+
+type leaderboardRangeArgs struct {
+	From   string `json:"from" jsonschema:"First day: YYYY-MM-DD, RFC3339, or today, yesterday, last-week, last-month"`
+	To     string `json:"to,omitempty" jsonschema:"Last day (default today), same formats as from; the range spans at most 31 days"`
+	ByDate bool   `json:"by_date,omitempty" jsonschema:"Group products by featured date instead of returning one list"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Optional maximum number of products per day"`
+}
+
+type leaderboardRangeOutput struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// FailedDates lists days whose leaderboard couldn't be fetched.
+	FailedDates []string `json:"failed_dates,omitempty"`
+	Total       int      `json:"total"`
+	// Items is the union of the days' products, oldest day first; each
+	// product appears once. Empty when by_date is set.
+	Items []dto.Product `json:"items,omitempty"`
+	// ByDate maps each featured date (YYYY-MM-DD) to its products.
+	ByDate    map[string][]dto.Product `json:"by_date,omitempty"`
+	Truncated bool                     `json:"truncated,omitempty"`
+}
+
+// leaderboardRangeHandler fetches the daily leaderboards from args.From to
+// args.To. A product's date is the day it was featured, in the configured
+// timezone, when the source knows featured times; otherwise the day of the
+// leaderboard it was first seen on. Days that fail are listed in
+// failed_dates; the call fails only when every day does.
+func leaderboardRangeHandler(ctx context.Context, _ *mcp.CallToolRequest, args leaderboardRangeArgs, source types.ProductSource) (*mcp.CallToolResult, leaderboardRangeOutput, error) {
+	from, err := parseDate(args.From)
+	if err != nil {
+		return errorToolResult(err.Error()), leaderboardRangeOutput{}, nil
+	}
+	to, err := parseDate(args.To)
+	if err != nil {
+		return errorToolResult(err.Error()), leaderboardRangeOutput{}, nil
+	}
+	if to.Before(from) {
+		return errorToolResult("to must not be before from"), leaderboardRangeOutput{}, nil
+	}
+	days := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	if days > maxRangeDays {
+		return errorToolResult(fmt.Sprintf("range spans %d days; at most %d", days, maxRangeDays)), leaderboardRangeOutput{}, nil
+	}
+
+	timed, _ := source.(types.FeaturedTimesSource)
+	progress := progressFrom(ctx)
+	out := leaderboardRangeOutput{From: from.Format(time.DateOnly), To: to.Format(time.DateOnly)}
+	var order []string
+	buckets := make(map[string][]types.Product)
+	seen := make(map[string]struct{})
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return errorToolResult("fetch leaderboard range cancelled"), leaderboardRangeOutput{}, nil
+		}
+		label := d.Format(time.DateOnly)
+		products, err := types.FetchLeaderboard(ctx, source, types.Daily, d)
+		if err != nil {
+			out.FailedDates = append(out.FailedDates, label)
+			progress.step(ctx, days, label+": fetch failed")
+			continue
+		}
+		var featured map[string]time.Time
+		if timed != nil {
+			featured, _ = types.FetchFeaturedTimes(ctx, timed, types.Daily, d)
+		}
+		for _, p := range applyLimit(products, args.Limit) {
+			if _, ok := seen[p.Slug()]; ok {
+				continue
+			}
+			seen[p.Slug()] = struct{}{}
+			date := label
+			if at, ok := featured[p.Slug()]; ok {
+				date = types.DayIn(at).Format(time.DateOnly)
+			}
+			if _, ok := buckets[date]; !ok {
+				order = append(order, date)
+			}
+			buckets[date] = append(buckets[date], p)
+		}
+		progress.step(ctx, days, fmt.Sprintf("%s: %d products", label, len(products)))
+	}
+	if len(out.FailedDates) == days {
+		return errorToolResult("fetch leaderboard failed"), leaderboardRangeOutput{}, nil
+	}
+
+	sort.Strings(order)
+	if args.ByDate {
+		out.ByDate = make(map[string][]dto.Product, len(buckets))
+	}
+	for _, date := range order {
+		out.Total += len(buckets[date])
+		if args.ByDate {
+			out.ByDate[date] = dto.FromProducts(buckets[date])
+		} else {
+			out.Items = append(out.Items, dto.FromProducts(buckets[date])...)
+		}
+	}
+	return nil, out, nil
+}
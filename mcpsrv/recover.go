@@ -0,0 +1,62 @@
+package mcpsrv
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// errInternal is returned in place of a request whose handler panicked.
+var errInternal = errors.New("internal error")
+
+// recoverMiddleware turns a panic while handling a request, such as a parser
+// bug reached from a tool, into an error for that request. Without it the
+// panic would take down the whole server. The panic is logged with its
+// stack; a tools/call gets an error result and other methods a JSON-RPC
+// error. Errors handlers return normally pass through untouched.
+func recoverMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (res mcp.Result, err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			name := method
+			if call, ok := req.(*mcp.CallToolRequest); ok && call.Params != nil {
+				name += " " + call.Params.Name
+			}
+			log.Printf("mcp %s panicked: %v\n%s", name, r, debug.Stack())
+			if method == "tools/call" {
+				res, err = errorToolResult(errInternal.Error()), nil
+				return
+			}
+			res, err = nil, errInternal
+		}()
+		return next(ctx, method, req)
+	}
+}
+
+// recoverHandler answers 500 when next panics on the request's own
+// goroutine instead of letting net/http drop the connection. The panic is
+// logged with its stack. http.ErrAbortHandler is re-panicked, since it is
+// how handlers deliberately abort a response.
+func recoverHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if p == http.ErrAbortHandler {
+				panic(p)
+			}
+			log.Printf("mcp http %s %s panicked: %v\n%s", r.Method, r.URL.Path, p, debug.Stack())
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
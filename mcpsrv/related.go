@@ -0,0 +1,43 @@
+package mcpsrv
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+type productRelatedLaunchesArgs struct {
+	Slug string `json:"slug" jsonschema:"Product slug"`
+}
+
+type productRelatedLaunchesOutput struct {
+	Slug      string        `json:"slug"`
+	Total     int           `json:"total"`
+	Items     []dto.Product `json:"items"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// productRelatedLaunchesHandler returns the other products the product page
+// lists as launched by the same makers. Products whose page has no such
+// section return no items rather than an error.
+func productRelatedLaunchesHandler(ctx context.Context, _ *mcp.CallToolRequest, args productRelatedLaunchesArgs, source types.ProductSource) (*mcp.CallToolResult, productRelatedLaunchesOutput, error) {
+	slug := strings.TrimSpace(args.Slug)
+	if slug == "" {
+		return errorToolResult("slug is required"), productRelatedLaunchesOutput{}, nil
+	}
+
+	detail, err := types.FetchProductDetail(ctx, source, slug)
+	if err != nil {
+		return errorToolResult(detailErrorMessage(err)), productRelatedLaunchesOutput{}, nil
+	}
+
+	related := detail.RelatedLaunches()
+	return nil, productRelatedLaunchesOutput{
+		Slug:  slug,
+		Total: len(related),
+		Items: dto.FromProducts(related),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package mcpsrv
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	defaultResolveLimit = 5
+	maxResolveLimit     = 25
+)
+
+// Match kinds for category_resolve, best first.
+const (
+	matchExact   = "exact"
+	matchPrefix  = "prefix"
+	matchPartial = "partial"
+	matchWords   = "words"
+)
+
+var matchScores = map[string]int{matchExact: 4, matchPrefix: 3, matchPartial: 2, matchWords: 1}
+
+type categoryResolveArgs struct {
+	Query string `json:"query" jsonschema:"Category name or slug, in full or in part"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Optional maximum number of candidates (default 5, max 25)"`
+}
+
+type categoryCandidate struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Match string `json:"match" jsonschema:"How the query matched: exact, prefix, partial or words"`
+}
+
+type categoryResolveOutput struct {
+	Query     string              `json:"query"`
+	Slug      string              `json:"slug,omitempty" jsonschema:"The best match's slug, empty when nothing matched"`
+	Total     int                 `json:"total"`
+	Items     []categoryCandidate `json:"items"`
+	Truncated bool                `json:"truncated,omitempty"`
+}
+
+// categoryResolveHandler matches query against AllCategories so an agent
+// holding a category's name can find the slug category_get_products needs.
+// Candidates are ranked exact, then prefix, then substring (the category_list
+// filter), then those containing every word of the query; ties go to the
+// shorter name, then category order. No match is not an error: the result
+// is empty with no slug.
+func categoryResolveHandler(_ context.Context, _ *mcp.CallToolRequest, args categoryResolveArgs) (*mcp.CallToolResult, categoryResolveOutput, error) {
+	query := strings.ToLower(strings.TrimSpace(args.Query))
+	if query == "" {
+		return errorToolResult("query is required"), categoryResolveOutput{}, nil
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultResolveLimit
+	}
+	limit = min(limit, maxResolveLimit)
+
+	words := strings.FieldsFunc(query, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	var candidates []categoryCandidate
+	for _, c := range types.AllCategories {
+		if match := matchCategory(c, query, words); match != "" {
+			candidates = append(candidates, categoryCandidate{Slug: c.Slug(), Name: c.Name(), Match: match})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if matchScores[a.Match] != matchScores[b.Match] {
+			return matchScores[a.Match] > matchScores[b.Match]
+		}
+		return len(a.Name) < len(b.Name)
+	})
+
+	out := categoryResolveOutput{Query: args.Query, Total: len(candidates), Items: candidates}
+	if len(out.Items) > limit {
+		out.Items = out.Items[:limit]
+	}
+	if out.Items == nil {
+		out.Items = []categoryCandidate{}
+	}
+	if len(out.Items) > 0 {
+		out.Slug = out.Items[0].Slug
+	}
+	return nil, out, nil
+}
+
+// matchCategory returns how c matches query, which is already lowercased
+// and split into words, or "" when it doesn't. The words joined by hyphens
+// are also compared as a slug, so "AI Agents" and "a/b testing" match
+// ai-agents and a-b-testing exactly.
+func matchCategory(c types.CategoryLink, query string, words []string) string {
+	name := strings.ToLower(c.Name())
+	asSlug := strings.Join(words, "-")
+	switch {
+	case name == query || c.Slug() == query || c.Slug() == asSlug:
+		return matchExact
+	case strings.HasPrefix(name, query) || strings.HasPrefix(c.Slug(), query) || (asSlug != "" && strings.HasPrefix(c.Slug(), asSlug)):
+		return matchPrefix
+	case categoryMatches(c, query):
+		return matchPartial
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	for _, w := range words {
+		if !strings.Contains(name, w) && !strings.Contains(c.Slug(), w) {
+			return ""
+		}
+	}
+	return matchWords
+}
@@ -0,0 +1,67 @@
+package mcpsrv
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/types"
+)
+
+type productGetReviewsSummaryArgs struct {
+	Slug string `json:"slug" jsonschema:"Product slug"`
+}
+
+type ratingBucket struct {
+	Stars int `json:"stars"`
+	Count int `json:"count"`
+}
+
+type productGetReviewsSummaryOutput struct {
+	Slug string `json:"slug"`
+	// Available is false when the product page publishes no per-star
+	// histogram; Average and Total then come from the page header and
+	// Distribution is empty.
+	Available    bool           `json:"available"`
+	Average      float64        `json:"average"`
+	Total        int            `json:"total"`
+	Distribution []ratingBucket `json:"distribution"`
+}
+
+// productGetReviewsSummaryHandler summarizes a product's reviews: how many
+// there are at each star rating, five stars first, with their average and
+// total.
+func productGetReviewsSummaryHandler(ctx context.Context, _ *mcp.CallToolRequest, args productGetReviewsSummaryArgs, source types.ProductSource) (*mcp.CallToolResult, productGetReviewsSummaryOutput, error) {
+	slug := strings.TrimSpace(args.Slug)
+	if slug == "" {
+		return errorToolResult("slug is required"), productGetReviewsSummaryOutput{}, nil
+	}
+
+	detail, err := types.FetchProductDetail(ctx, source, slug)
+	if err != nil {
+		return errorToolResult(detailErrorMessage(err)), productGetReviewsSummaryOutput{}, nil
+	}
+
+	out := productGetReviewsSummaryOutput{
+		Slug:         slug,
+		Average:      detail.Rating(),
+		Total:        detail.ReviewCount(),
+		Distribution: []ratingBucket{},
+	}
+	if !detail.HasRatingDistribution() {
+		return nil, out, nil
+	}
+
+	dist := detail.RatingDistribution()
+	total, sum := 0, 0
+	for i := len(dist) - 1; i >= 0; i-- {
+		out.Distribution = append(out.Distribution, ratingBucket{Stars: i + 1, Count: dist[i]})
+		total += dist[i]
+		sum += (i + 1) * dist[i]
+	}
+	out.Available = true
+	out.Total = total
+	out.Average = math.Round(float64(sum)/float64(total)*100) / 100
+	return nil, out, nil
+}
@@ -0,0 +1,45 @@
+package mcpsrv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolSchemas is the document ExportToolSchemas writes. It has the shape of
+// a tools/list result so generators written against the protocol read it
+// as is.
+type toolSchemas struct {
+	Tools []*mcp.Tool `json:"tools"`
+}
+
+// ExportToolSchemas returns the tools registered on server, with their
+// descriptions and input and output JSON schemas, as indented JSON. It asks
+// the server itself over an in-memory session, so the export matches what a
+// client would list under the same options.
+func ExportToolSchemas(ctx context.Context, server *mcp.Server) ([]byte, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connect server: %w", err)
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "phtui-schema-export", Version: "1"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connect client: %w", err)
+	}
+	defer session.Close()
+
+	var out toolSchemas
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("list tools: %w", err)
+		}
+		out.Tools = append(out.Tools, tool)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
@@ -3,22 +3,35 @@ package mcpsrv
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"github.com/qyinm/phtui/mcpsrv/dto"
+	"github.com/qyinm/phtui/dto"
 	"github.com/qyinm/phtui/types"
 )
 
 type leaderboardGetArgs struct {
 	Period string `json:"period" jsonschema:"Leaderboard period: daily, weekly, monthly"`
-	Date   string `json:"date,omitempty" jsonschema:"Optional date in YYYY-MM-DD"`
+	Date   string `json:"date,omitempty" jsonschema:"Optional date: YYYY-MM-DD, RFC3339, or today, yesterday, last-week, last-month"`
 	Limit  int    `json:"limit,omitempty" jsonschema:"Optional maximum number of items"`
+	Sort   string `json:"sort,omitempty" jsonschema:"Optional order: rank (default) or launch_time (newest featured first)"`
+}
+
+type leaderboardDigestArgs struct {
+	Period string `json:"period" jsonschema:"Leaderboard period: daily, weekly, monthly"`
+	Date   string `json:"date,omitempty" jsonschema:"Optional date: YYYY-MM-DD, RFC3339, or today, yesterday, last-week, last-month"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Optional maximum number of items (default 10)"`
+}
+
+type leaderboardSinceArgs struct {
+	Since string `json:"since" jsonschema:"RFC3339 timestamp; only products featured after it are returned"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Optional maximum number of items"`
 }
 
 type productGetDetailArgs struct {
-	Slug string `json:"slug" jsonschema:"Product slug"`
+	Slug string `json:"slug" jsonschema:"Product slug, or posts/{slug} for launches without a product page"`
 }
 
 type categoryListArgs struct {
@@ -28,20 +41,68 @@ type categoryListArgs struct {
 }
 
 type categoryGetProductsArgs struct {
-	Slug  string `json:"slug" jsonschema:"Category slug"`
-	Limit int    `json:"limit,omitempty" jsonschema:"Optional maximum number of products"`
+	Slug                  string `json:"slug" jsonschema:"Category slug"`
+	Limit                 int    `json:"limit,omitempty" jsonschema:"Optional maximum number of products"`
+	Pricing               string `json:"pricing,omitempty" jsonschema:"Optional pricing filter: free, paid"`
+	IncludeUnknownPricing bool   `json:"include_unknown_pricing,omitempty" jsonschema:"Keep products with unknown pricing when filtering by pricing"`
+	MinReviews            int    `json:"min_reviews,omitempty" jsonschema:"Optional minimum review count; products with fewer reviews are left out"`
+	Page                  int    `json:"page,omitempty" jsonschema:"Optional page of the category listing (default 1)"`
+}
+
+type categoryTreeArgs struct {
+	Slug string `json:"slug" jsonschema:"Category slug"`
+}
+
+type productGetRankHistoryArgs struct {
+	Slug string `json:"slug" jsonschema:"Product slug"`
+	Days int    `json:"days,omitempty" jsonschema:"Days of daily leaderboards to scan when the product page has no rank history (default 7, max 30)"`
+}
+
+type upcomingGetArgs struct {
+	Limit int `json:"limit,omitempty" jsonschema:"Optional maximum number of items"`
 }
 
 type searchProductsArgs struct {
-	Query string `json:"query" jsonschema:"Search query"`
-	Page  int    `json:"page,omitempty" jsonschema:"Page number (1-10)"`
+	Query                 string `json:"query" jsonschema:"Search query"`
+	Page                  int    `json:"page,omitempty" jsonschema:"Page number (1-10)"`
+	Pricing               string `json:"pricing,omitempty" jsonschema:"Optional pricing filter: free, paid"`
+	IncludeUnknownPricing bool   `json:"include_unknown_pricing,omitempty" jsonschema:"Keep products with unknown pricing when filtering by pricing"`
+	Sort                  string `json:"sort,omitempty" jsonschema:"Optional sort: relevance (default), votes, reviews, rating"`
+	HideOffline           bool   `json:"hide_offline,omitempty" jsonschema:"Drop products marked no longer online"`
+	FeaturedOnly          bool   `json:"featured_only,omitempty" jsonschema:"Keep only established products: online, with at least one review or a rating"`
 }
 
 type leaderboardGetOutput struct {
 	Period string        `json:"period"`
 	Date   string        `json:"date"`
+	Sort   string        `json:"sort"`
 	Total  int           `json:"total"`
 	Items  []dto.Product `json:"items"`
+	// Fallback is true when launch_time order was asked for but the
+	// featured times are unavailable, so Items are in rank order.
+	Fallback bool `json:"fallback,omitempty"`
+	// Truncated is true when Items was cut to the server's MaxItems cap.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type leaderboardDigestOutput struct {
+	Period string `json:"period"`
+	Date   string `json:"date"`
+	Total  int    `json:"total"`
+	Digest string `json:"digest"`
+	// Truncated is true when the requested limit was lowered to MaxItems.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type leaderboardSinceOutput struct {
+	Date  string `json:"date"`
+	Since string `json:"since"`
+	// Fallback is true when featured times were unavailable and all of
+	// today's products were returned unfiltered.
+	Fallback  bool          `json:"fallback"`
+	Total     int           `json:"total"`
+	Items     []dto.Product `json:"items"`
+	Truncated bool          `json:"truncated,omitempty"`
 }
 
 type productGetDetailOutput struct {
@@ -56,36 +117,113 @@ type categoryListOutput struct {
 	HasMore    bool           `json:"has_more"`
 	Total      int            `json:"total"`
 	Items      []dto.Category `json:"items"`
+	Truncated  bool           `json:"truncated,omitempty"`
 }
 
 type categoryGetProductsOutput struct {
-	Slug       string         `json:"slug"`
-	Total      int            `json:"total"`
-	Categories []dto.Category `json:"categories"`
-	Items      []dto.Product  `json:"items"`
+	Slug        string         `json:"slug"`
+	Page        int            `json:"page"`
+	HasNext     bool           `json:"has_next"`
+	PagesCount  int            `json:"pages_count"`
+	Total       int            `json:"total"`
+	Categories  []dto.Category `json:"categories"`
+	Items       []dto.Product  `json:"items"`
+	FilteredOut int            `json:"filtered_out,omitempty"`
+	Truncated   bool           `json:"truncated,omitempty"`
+	// PricingPartial is true when the pricing filter stopped after
+	// maxPricingLookups detail lookups, leaving later products unchecked.
+	PricingPartial bool `json:"pricing_partial,omitempty"`
+}
+
+type categoryTreeOutput struct {
+	Slug         string         `json:"slug"`
+	Name         string         `json:"name"`
+	Parent       *dto.Category  `json:"parent"`
+	Children     []dto.Category `json:"children"`
+	Hierarchical bool           `json:"hierarchical"`
+	Truncated    bool           `json:"truncated,omitempty"`
+}
+
+type productGetRankHistoryOutput struct {
+	Slug string `json:"slug"`
+	// Source is "detail" when the ranks come from the product page, or
+	// "leaderboards" when they were rebuilt from recent daily leaderboards.
+	Source string `json:"source"`
+	// Partial is true for rebuilt histories, which only cover the scanned
+	// days.
+	Partial   bool            `json:"partial"`
+	Total     int             `json:"total"`
+	History   []dto.RankPoint `json:"history"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+type upcomingGetOutput struct {
+	Total     int           `json:"total"`
+	Items     []dto.Product `json:"items"`
+	Truncated bool          `json:"truncated,omitempty"`
 }
 
 type searchProductsOutput struct {
-	Query      string        `json:"query"`
-	Page       int           `json:"page"`
-	HasPrev    bool          `json:"has_prev"`
-	HasNext    bool          `json:"has_next"`
-	PagesCount int           `json:"pages_count"`
-	ItemsCount int           `json:"items_count"`
-	Items      []dto.Product `json:"items"`
+	Query       string        `json:"query"`
+	Page        int           `json:"page"`
+	HasPrev     bool          `json:"has_prev"`
+	HasNext     bool          `json:"has_next"`
+	PagesCount  int           `json:"pages_count"`
+	ItemsCount  int           `json:"items_count"`
+	Sort        string        `json:"sort"`
+	Items       []dto.Product `json:"items"`
+	FilteredOut int           `json:"filtered_out,omitempty"`
+	Truncated   bool          `json:"truncated,omitempty"`
+	Note        string        `json:"note,omitempty"`
+	// PricingPartial is as in categoryGetProductsOutput.
+	PricingPartial bool `json:"pricing_partial,omitempty"`
 }
 
 type cacheClearOutput struct {
 	Status string `json:"status"`
 }
 
+// Tool profiles select which tools NewServer registers.
+const (
+	// ProfileFull registers every tool, subject to the per-tool flags.
+	ProfileFull = "full"
+	// ProfileMinimal registers only minimalTools and no category feeds.
+	ProfileMinimal = "minimal"
+)
+
+// minimalTools are the tools the minimal profile exposes.
+var minimalTools = []string{"leaderboard_get", "product_get_detail"}
+
 type ServerOptions struct {
+	// Profile is ProfileFull (the default when empty) or ProfileMinimal.
+	Profile      string
 	EnableSearch bool
 	EnableAdmin  bool
+	// WatchTopProduct exposes TopProductURI with resource subscriptions.
+	// Run WatchTopProduct alongside the server to emit change notifications.
+	WatchTopProduct bool
+	// StructuredOnly drops the text content from successful tool results and
+	// adds a structured {"error": ...} payload to failed ones.
+	StructuredOnly bool
+	// ToolTimeout bounds each tool call; calls running longer return an
+	// error result wrapping ErrToolTimeout. Zero disables the deadline.
+	ToolTimeout time.Duration
+	// MaxItems caps every list a tool returns, on top of per-tool limits.
+	// Capped outputs report truncated: true. Zero disables the cap.
+	MaxItems int
+	// Progress sends progress notifications from multi-fetch tool calls to
+	// clients that pass a progress token.
+	Progress bool
+	// TrendingSearch lets search_products accept an empty query and
+	// return today's trending products instead of an error. The source must
+	// have the fallback enabled too (see scraper.SetTrendingOnEmptyQuery).
+	TrendingSearch bool
 }
 
-type searchableSource interface {
-	SearchProductsPage(query string, page int) ([]types.Product, int, bool, bool, int, error)
+// searchNoteSource is implemented by sources whose search works differently
+// from Product Hunt's; the note is returned with every search result.
+type searchNoteSource interface {
+	SearchNote() string
 }
 
 type cacheClearSource interface {
@@ -100,58 +238,256 @@ func NewServer(source types.ProductSource, version string, opts *ServerOptions)
 		opts = &ServerOptions{}
 	}
 
-	server := mcp.NewServer(&mcp.Implementation{Name: "phtui", Version: version}, nil)
+	var serverOpts *mcp.ServerOptions
+	if opts.WatchTopProduct {
+		serverOpts = &mcp.ServerOptions{
+			SubscribeHandler:   func(context.Context, *mcp.SubscribeRequest) error { return nil },
+			UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error { return nil },
+		}
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "phtui", Version: version}, serverOpts)
+	// Added first so it runs innermost, next to the handlers: the other
+	// middleware then see a recovered panic as an ordinary error, and the
+	// timeout middleware's handler goroutine is covered too.
+	server.AddReceivingMiddleware(recoverMiddleware)
+	if opts.StructuredOnly {
+		server.AddReceivingMiddleware(structuredOnlyMiddleware)
+	}
+	if opts.ToolTimeout > 0 {
+		server.AddReceivingMiddleware(toolTimeoutMiddleware(opts.ToolTimeout))
+	}
+	if opts.Progress {
+		server.AddReceivingMiddleware(progressMiddleware)
+	}
+	// Added last so it sees the results the other middleware produce,
+	// such as timeouts.
+	usage := newToolUsage()
+	if opts.toolEnabled("usage_stats") {
+		server.AddReceivingMiddleware(usage.middleware)
+	}
+
+	if opts.WatchTopProduct {
+		addTopProductResource(server, source)
+	}
+	// The category feeds are resources rather than tools, but the minimal
+	// profile leaves them out too.
+	if opts.Profile != ProfileMinimal {
+		addCategoryFeeds(server, source)
+	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, opts, &mcp.Tool{
 		Name:        "leaderboard_get",
 		Description: "Get leaderboard products by period/date.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args leaderboardGetArgs) (*mcp.CallToolResult, leaderboardGetOutput, error) {
-		return leaderboardGetHandler(ctx, req, args, source)
+		res, out, err := leaderboardGetHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "leaderboard_digest",
+		Description: "Get leaderboard products by period/date as a ranked Markdown digest.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args leaderboardDigestArgs) (*mcp.CallToolResult, leaderboardDigestOutput, error) {
+		var truncated bool
+		args.Limit, truncated = capLimit(args.Limit, 10, opts.MaxItems)
+		res, out, err := leaderboardDigestHandler(ctx, req, args, source)
+		out.Truncated = truncated && out.Total == opts.MaxItems
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "leaderboard_since",
+		Description: "Get today's daily leaderboard products featured after a given time.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args leaderboardSinceArgs) (*mcp.CallToolResult, leaderboardSinceOutput, error) {
+		res, out, err := leaderboardSinceHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "leaderboard_range",
+		Description: "Get daily leaderboard products over a date range, optionally grouped by featured date.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args leaderboardRangeArgs) (*mcp.CallToolResult, leaderboardRangeOutput, error) {
+		res, out, err := leaderboardRangeHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		for date, items := range out.ByDate {
+			var cut bool
+			out.ByDate[date], cut = capItems(items, opts.MaxItems)
+			out.Truncated = out.Truncated || cut
+		}
+		return res, out, err
 	})
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, opts, &mcp.Tool{
 		Name:        "product_get_detail",
 		Description: "Get product details by slug.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args productGetDetailArgs) (*mcp.CallToolResult, productGetDetailOutput, error) {
 		return productGetDetailHandler(ctx, req, args, source)
 	})
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, opts, &mcp.Tool{
+		Name:        "product_get_rank_history",
+		Description: "Get a product's daily leaderboard rank on each launch day.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args productGetRankHistoryArgs) (*mcp.CallToolResult, productGetRankHistoryOutput, error) {
+		res, out, err := productGetRankHistoryHandler(ctx, req, args, source)
+		out.History, out.Truncated = capItems(out.History, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "product_get_reviews_summary",
+		Description: "Get a product's review count at each star rating, with the average and total.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args productGetReviewsSummaryArgs) (*mcp.CallToolResult, productGetReviewsSummaryOutput, error) {
+		return productGetReviewsSummaryHandler(ctx, req, args, source)
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "product_related_launches",
+		Description: "Get other products launched by the same makers, as listed on a product's page.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args productRelatedLaunchesArgs) (*mcp.CallToolResult, productRelatedLaunchesOutput, error) {
+		res, out, err := productRelatedLaunchesHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "product_get_topics",
+		Description: "Get the topics a product is listed under, with slugs usable with category_get_products.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args productGetTopicsArgs) (*mcp.CallToolResult, productGetTopicsOutput, error) {
+		res, out, err := productGetTopicsHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
 		Name:        "category_list",
 		Description: "List available product categories.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args categoryListArgs) (*mcp.CallToolResult, categoryListOutput, error) {
-		return categoryListHandler(ctx, req, args)
+		var truncated bool
+		args.Limit, truncated = capLimit(args.Limit, 25, opts.MaxItems)
+		res, out, err := categoryListHandler(ctx, req, args)
+		out.Truncated = truncated && out.HasMore
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "category_resolve",
+		Description: "Resolve a category name or partial slug to matching category slugs, best match first.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args categoryResolveArgs) (*mcp.CallToolResult, categoryResolveOutput, error) {
+		var truncated bool
+		args.Limit, truncated = capLimit(args.Limit, defaultResolveLimit, opts.MaxItems)
+		res, out, err := categoryResolveHandler(ctx, req, args)
+		out.Truncated = truncated && out.Total > len(out.Items)
+		return res, out, err
 	})
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, opts, &mcp.Tool{
 		Name:        "category_get_products",
 		Description: "Get products for a category slug.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args categoryGetProductsArgs) (*mcp.CallToolResult, categoryGetProductsOutput, error) {
-		return categoryGetProductsHandler(ctx, req, args, source)
+		res, out, err := categoryGetProductsHandler(ctx, req, args, source)
+		var itemsCut, categoriesCut bool
+		out.Items, itemsCut = capItems(out.Items, opts.MaxItems)
+		out.Categories, categoriesCut = capItems(out.Categories, opts.MaxItems)
+		out.Truncated = itemsCut || categoriesCut
+		return res, out, err
 	})
 
-	if opts.EnableSearch {
-		mcp.AddTool(server, &mcp.Tool{
-			Name:        "search_products",
-			Description: "Search products by query.",
-		}, func(ctx context.Context, req *mcp.CallToolRequest, args searchProductsArgs) (*mcp.CallToolResult, searchProductsOutput, error) {
-			return searchProductsHandler(ctx, req, args, source)
-		})
-	}
+	addTool(server, opts, &mcp.Tool{
+		Name:        "category_counts",
+		Description: "Get the number of products listed in each of several category slugs, across all of each category's pages.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args categoryCountsArgs) (*mcp.CallToolResult, categoryCountsOutput, error) {
+		res, out, err := categoryCountsHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
 
-	if opts.EnableAdmin {
-		mcp.AddTool(server, &mcp.Tool{
-			Name:        "cache_clear",
-			Description: "Clear scraper cache (admin).",
-		}, func(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, cacheClearOutput, error) {
-			return cacheClearHandler(ctx, req, source)
-		})
-	}
+	addTool(server, opts, &mcp.Tool{
+		Name:        "category_tree",
+		Description: "Get the parent and subcategories of a category slug.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args categoryTreeArgs) (*mcp.CallToolResult, categoryTreeOutput, error) {
+		res, out, err := categoryTreeHandler(ctx, req, args, source)
+		out.Children, out.Truncated = capItems(out.Children, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "upcoming_get",
+		Description: "Get upcoming (coming soon) products that haven't launched yet.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args upcomingGetArgs) (*mcp.CallToolResult, upcomingGetOutput, error) {
+		res, out, err := upcomingGetHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "search_products",
+		Description: "Search products by query.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args searchProductsArgs) (*mcp.CallToolResult, searchProductsOutput, error) {
+		res, out, err := searchProductsHandler(ctx, req, args, source, opts.TrendingSearch)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "find_alternatives",
+		Description: "Search for a query and compare the top results: rating, reviews, pricing, pros and cons.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args findAlternativesArgs) (*mcp.CallToolResult, findAlternativesOutput, error) {
+		res, out, err := findAlternativesHandler(ctx, req, args, source)
+		out.Items, out.Truncated = capItems(out.Items, opts.MaxItems)
+		return res, out, err
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "cache_clear",
+		Description: "Clear scraper cache (admin).",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, cacheClearOutput, error) {
+		return cacheClearHandler(ctx, req, source)
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "health_check",
+		Description: "Fetch today's leaderboard uncached and report whether scraping works: ok, blocked or error, with latency (admin).",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, healthCheckOutput, error) {
+		return healthCheckHandler(ctx, req, source)
+	})
+
+	addTool(server, opts, &mcp.Tool{
+		Name:        "usage_stats",
+		Description: "Get per-tool call and error counts since server start or the last reset (admin).",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args usageStatsArgs) (*mcp.CallToolResult, usageStatsOutput, error) {
+		return usageStatsHandler(ctx, req, args, usage)
+	})
 
 	return server
 }
 
-func leaderboardGetHandler(_ context.Context, _ *mcp.CallToolRequest, args leaderboardGetArgs, source types.ProductSource) (*mcp.CallToolResult, leaderboardGetOutput, error) {
+// addTool registers tool on server unless opts leave it disabled.
+func addTool[In, Out any](server *mcp.Server, opts *ServerOptions, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	if !opts.toolEnabled(tool.Name) {
+		return
+	}
+	mcp.AddTool(server, tool, handler)
+}
+
+// toolEnabled reports whether the tool called name is registered. The
+// profile is applied first: the minimal profile keeps only its own tools
+// whatever the other flags say. Within a profile, the search tools need
+// EnableSearch and the admin tools need EnableAdmin.
+func (opts *ServerOptions) toolEnabled(name string) bool {
+	if opts.Profile == ProfileMinimal && !slices.Contains(minimalTools, name) {
+		return false
+	}
+	switch name {
+	case "search_products", "find_alternatives":
+		return opts.EnableSearch
+	case "cache_clear", "usage_stats", "health_check":
+		return opts.EnableAdmin
+	}
+	return true
+}
+
+func leaderboardGetHandler(ctx context.Context, _ *mcp.CallToolRequest, args leaderboardGetArgs, source types.ProductSource) (*mcp.CallToolResult, leaderboardGetOutput, error) {
 	period, err := parsePeriod(args.Period)
 	if err != nil {
 		return errorToolResult(err.Error()), leaderboardGetOutput{}, nil
@@ -162,30 +498,138 @@ func leaderboardGetHandler(_ context.Context, _ *mcp.CallToolRequest, args leade
 		return errorToolResult(err.Error()), leaderboardGetOutput{}, nil
 	}
 
-	products, err := source.GetLeaderboard(period, date)
+	order, err := parseLeaderboardSort(args.Sort)
+	if err != nil {
+		return errorToolResult(err.Error()), leaderboardGetOutput{}, nil
+	}
+
+	products, err := types.FetchLeaderboard(ctx, source, period, date)
 	if err != nil {
 		return errorToolResult("fetch leaderboard failed"), leaderboardGetOutput{}, nil
 	}
 
+	var fallback bool
+	if order == sortLaunchTime {
+		var featured map[string]time.Time
+		if timed, ok := source.(types.FeaturedTimesSource); ok {
+			// Timing is best effort; a failure falls back to rank order.
+			featured, _ = types.FetchFeaturedTimes(ctx, timed, period, date)
+		}
+		fallback = len(featured) == 0
+		products = types.SortByLaunchTime(products, featured)
+	}
 	products = applyLimit(products, args.Limit)
 
 	return nil, leaderboardGetOutput{
+		Period:   period.String(),
+		Date:     date.Format(time.DateOnly),
+		Sort:     order,
+		Total:    len(products),
+		Items:    dto.FromProducts(products),
+		Fallback: fallback,
+	}, nil
+}
+
+func leaderboardDigestHandler(ctx context.Context, _ *mcp.CallToolRequest, args leaderboardDigestArgs, source types.ProductSource) (*mcp.CallToolResult, leaderboardDigestOutput, error) {
+	period, err := parsePeriod(args.Period)
+	if err != nil {
+		return errorToolResult(err.Error()), leaderboardDigestOutput{}, nil
+	}
+
+	date, err := parseDate(args.Date)
+	if err != nil {
+		return errorToolResult(err.Error()), leaderboardDigestOutput{}, nil
+	}
+
+	products, err := types.FetchLeaderboard(ctx, source, period, date)
+	if err != nil {
+		return errorToolResult("fetch leaderboard failed"), leaderboardDigestOutput{}, nil
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	products = applyLimit(products, limit)
+	digest := formatLeaderboardDigest(period, date, products)
+
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: digest}},
+	}
+	return result, leaderboardDigestOutput{
 		Period: period.String(),
 		Date:   date.Format(time.DateOnly),
 		Total:  len(products),
-		Items:  dto.FromProducts(products),
+		Digest: digest,
 	}, nil
 }
 
-func productGetDetailHandler(_ context.Context, _ *mcp.CallToolRequest, args productGetDetailArgs, source types.ProductSource) (*mcp.CallToolResult, productGetDetailOutput, error) {
+// formatLeaderboardDigest renders products as a ranked Markdown list.
+func formatLeaderboardDigest(period types.Period, date time.Time, products []types.Product) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Product Hunt %s leaderboard (%s)\n\n", period.String(), date.Format(time.DateOnly))
+	if len(products) == 0 {
+		b.WriteString("_No products found._\n")
+		return b.String()
+	}
+	for _, p := range products {
+		fmt.Fprintf(&b, "%d. **%s**", p.Rank(), p.Name())
+		if p.Tagline() != "" {
+			fmt.Fprintf(&b, " — %s", p.Tagline())
+		}
+		fmt.Fprintf(&b, " (▲ %d)\n", p.VoteCount())
+	}
+	return b.String()
+}
+
+func leaderboardSinceHandler(ctx context.Context, _ *mcp.CallToolRequest, args leaderboardSinceArgs, source types.ProductSource) (*mcp.CallToolResult, leaderboardSinceOutput, error) {
+	since, err := time.Parse(time.RFC3339, strings.TrimSpace(args.Since))
+	if err != nil {
+		return errorToolResult(fmt.Sprintf("invalid since %q; expected RFC3339", args.Since)), leaderboardSinceOutput{}, nil
+	}
+
+	date := types.Today()
+	products, err := types.FetchLeaderboard(ctx, source, types.Daily, date)
+	if err != nil {
+		return errorToolResult("fetch leaderboard failed"), leaderboardSinceOutput{}, nil
+	}
+
+	var featured map[string]time.Time
+	if timed, ok := source.(types.FeaturedTimesSource); ok {
+		// Timing is best effort; a failure falls back to the full list.
+		featured, _ = types.FetchFeaturedTimes(ctx, timed, types.Daily, date)
+	}
+
+	fallback := len(featured) == 0
+	if !fallback {
+		recent := make([]types.Product, 0, len(products))
+		for _, p := range products {
+			if at, ok := featured[p.Slug()]; ok && at.After(since) {
+				recent = append(recent, p)
+			}
+		}
+		products = recent
+	}
+	products = applyLimit(products, args.Limit)
+
+	return nil, leaderboardSinceOutput{
+		Date:     date.Format(time.DateOnly),
+		Since:    since.Format(time.RFC3339),
+		Fallback: fallback,
+		Total:    len(products),
+		Items:    dto.FromProducts(products),
+	}, nil
+}
+
+func productGetDetailHandler(ctx context.Context, _ *mcp.CallToolRequest, args productGetDetailArgs, source types.ProductSource) (*mcp.CallToolResult, productGetDetailOutput, error) {
 	slug := strings.TrimSpace(args.Slug)
 	if slug == "" {
 		return errorToolResult("slug is required"), productGetDetailOutput{}, nil
 	}
 
-	detail, err := source.GetProductDetail(slug)
+	detail, err := types.FetchProductDetail(ctx, source, slug)
 	if err != nil {
-		return errorToolResult("fetch product detail failed"), productGetDetailOutput{}, nil
+		return errorToolResult(detailErrorMessage(err)), productGetDetailOutput{}, nil
 	}
 
 	return nil, productGetDetailOutput{Item: dto.FromProductDetail(detail)}, nil
@@ -200,7 +644,7 @@ func categoryListHandler(_ context.Context, _ *mcp.CallToolRequest, args categor
 			filtered = append(filtered, c)
 			continue
 		}
-		if strings.Contains(strings.ToLower(c.Name()), query) || strings.Contains(strings.ToLower(c.Slug()), query) {
+		if categoryMatches(c, query) {
 			filtered = append(filtered, c)
 		}
 	}
@@ -242,30 +686,218 @@ func categoryListHandler(_ context.Context, _ *mcp.CallToolRequest, args categor
 	}, nil
 }
 
-func categoryGetProductsHandler(_ context.Context, _ *mcp.CallToolRequest, args categoryGetProductsArgs, source types.ProductSource) (*mcp.CallToolResult, categoryGetProductsOutput, error) {
+// categoryMatches reports whether c's name or slug contains query, which is
+// already lowercased.
+func categoryMatches(c types.CategoryLink, query string) bool {
+	return strings.Contains(strings.ToLower(c.Name()), query) || strings.Contains(strings.ToLower(c.Slug()), query)
+}
+
+func categoryGetProductsHandler(ctx context.Context, _ *mcp.CallToolRequest, args categoryGetProductsArgs, source types.ProductSource) (*mcp.CallToolResult, categoryGetProductsOutput, error) {
 	slug := strings.TrimSpace(args.Slug)
 	if slug == "" {
 		return errorToolResult("slug is required"), categoryGetProductsOutput{}, nil
 	}
+	pricing, err := parsePricingFilter(args.Pricing)
+	if err != nil {
+		return errorToolResult(err.Error()), categoryGetProductsOutput{}, nil
+	}
+	if args.MinReviews < 0 {
+		return errorToolResult("min_reviews must be 0 or more"), categoryGetProductsOutput{}, nil
+	}
+	page := args.Page
+	if page == 0 {
+		page = 1
+	}
+	if page < 1 {
+		return errorToolResult("page must be 1 or more"), categoryGetProductsOutput{}, nil
+	}
 
-	products, categories, err := source.GetCategoryProducts(slug)
+	// Sources without pages serve the first page only, with an unknown
+	// page count.
+	var (
+		products   []types.Product
+		categories []types.CategoryLink
+		hasNext    bool
+		pagesCount int
+	)
+	if pageSource, ok := source.(types.CategoryPageSource); ok {
+		products, categories, hasNext, pagesCount, err = types.FetchCategoryProductsPage(ctx, pageSource, slug, page)
+	} else if page > 1 {
+		return errorToolResult("category pages are not supported by this source"), categoryGetProductsOutput{}, nil
+	} else {
+		products, categories, err = types.FetchCategoryProducts(ctx, source, slug)
+	}
 	if err != nil {
 		return errorToolResult("fetch category products failed"), categoryGetProductsOutput{}, nil
 	}
 
+	products, filteredOut := types.FilterByMinReviews(products, args.MinReviews)
+	products, pricingPartial := filterByPricing(ctx, source, products, pricing, args.IncludeUnknownPricing, args.Limit)
 	products = applyLimit(products, args.Limit)
 
 	return nil, categoryGetProductsOutput{
-		Slug:       slug,
-		Total:      len(products),
-		Categories: dto.FromCategories(categories),
-		Items:      dto.FromProducts(products),
+		Slug:           slug,
+		Page:           page,
+		HasNext:        hasNext,
+		PagesCount:     pagesCount,
+		Total:          len(products),
+		Categories:     dto.FromCategories(categories),
+		Items:          dto.FromProducts(products),
+		FilteredOut:    filteredOut,
+		PricingPartial: pricingPartial,
+	}, nil
+}
+
+func categoryTreeHandler(ctx context.Context, _ *mcp.CallToolRequest, args categoryTreeArgs, source types.ProductSource) (*mcp.CallToolResult, categoryTreeOutput, error) {
+	slug := strings.TrimSpace(args.Slug)
+	if slug == "" {
+		return errorToolResult("slug is required"), categoryTreeOutput{}, nil
+	}
+
+	var tree types.CategoryTree
+	if treeSource, ok := source.(types.CategoryTreeSource); ok {
+		var err error
+		tree, err = types.FetchCategoryTree(ctx, treeSource, slug)
+		if err != nil {
+			return errorToolResult("fetch category tree failed"), categoryTreeOutput{}, nil
+		}
+	} else {
+		// Sources without hierarchy support still expose related categories.
+		_, categories, err := types.FetchCategoryProducts(ctx, source, slug)
+		if err != nil {
+			return errorToolResult("fetch category tree failed"), categoryTreeOutput{}, nil
+		}
+		category := types.NewCategoryLink(slug, slug)
+		if idx := types.CategoryIndexBySlug(slug); idx >= 0 {
+			category = types.AllCategories[idx]
+		}
+		tree = types.NewCategoryTree(category, nil, categories, false)
+	}
+
+	var parent *dto.Category
+	if p := tree.Parent(); p != nil {
+		c := dto.FromCategory(*p)
+		parent = &c
+	}
+
+	return nil, categoryTreeOutput{
+		Slug:         tree.Category().Slug(),
+		Name:         tree.Category().Name(),
+		Parent:       parent,
+		Children:     dto.FromCategories(tree.Children()),
+		Hierarchical: tree.Hierarchical(),
+	}, nil
+}
+
+const (
+	defaultRankHistoryDays = 7
+	maxRankHistoryDays     = 30
+)
+
+func productGetRankHistoryHandler(ctx context.Context, _ *mcp.CallToolRequest, args productGetRankHistoryArgs, source types.ProductSource) (*mcp.CallToolResult, productGetRankHistoryOutput, error) {
+	slug := strings.TrimSpace(args.Slug)
+	if slug == "" {
+		return errorToolResult("slug is required"), productGetRankHistoryOutput{}, nil
+	}
+	days := args.Days
+	if days <= 0 {
+		days = defaultRankHistoryDays
+	}
+	if days > maxRankHistoryDays {
+		days = maxRankHistoryDays
+	}
+
+	if historySource, ok := source.(types.RankHistorySource); ok {
+		history, err := types.FetchRankHistory(ctx, historySource, slug)
+		if err == nil && len(history) > 0 {
+			return nil, productGetRankHistoryOutput{
+				Slug:    slug,
+				Source:  "detail",
+				Total:   len(history),
+				History: dto.FromRankHistory(history),
+			}, nil
+		}
+	}
+
+	history, err := scanRankHistory(ctx, source, slug, days)
+	if err != nil {
+		return errorToolResult("fetch rank history failed"), productGetRankHistoryOutput{}, nil
+	}
+	return nil, productGetRankHistoryOutput{
+		Slug:    slug,
+		Source:  "leaderboards",
+		Partial: true,
+		Total:   len(history),
+		History: dto.FromRankHistory(history),
+	}, nil
+}
+
+// scanRankHistory rebuilds a partial rank history by looking for slug on the
+// daily leaderboards of the last days days, oldest first. Days whose
+// leaderboard can't be fetched are skipped; it fails only when none can.
+func scanRankHistory(ctx context.Context, source types.ProductSource, slug string, days int) ([]types.RankPoint, error) {
+	progress := progressFrom(ctx)
+	today := types.Today()
+	var history []types.RankPoint
+	var lastErr error
+	fetched := 0
+	for i := days - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		date := today.AddDate(0, 0, -i)
+		label := date.Format(time.DateOnly)
+		products, err := types.FetchLeaderboard(ctx, source, types.Daily, date)
+		if err != nil {
+			lastErr = err
+			progress.step(ctx, days, label+": fetch failed")
+			continue
+		}
+		fetched++
+		found := false
+		for _, p := range products {
+			if p.Slug() == slug {
+				history = append(history, types.NewRankPoint(date, p.Rank()))
+				progress.step(ctx, days, fmt.Sprintf("%s: #%d", label, p.Rank()))
+				found = true
+				break
+			}
+		}
+		if !found {
+			progress.step(ctx, days, label+": not ranked")
+		}
+	}
+	if fetched == 0 {
+		return nil, lastErr
+	}
+	return history, nil
+}
+
+func upcomingGetHandler(ctx context.Context, _ *mcp.CallToolRequest, args upcomingGetArgs, source types.ProductSource) (*mcp.CallToolResult, upcomingGetOutput, error) {
+	upcoming, ok := source.(types.UpcomingSource)
+	if !ok {
+		return errorToolResult("upcoming products are not supported by this source"), upcomingGetOutput{}, nil
+	}
+
+	products, err := types.FetchUpcomingProducts(ctx, upcoming)
+	if err != nil {
+		return errorToolResult("fetch upcoming products failed"), upcomingGetOutput{}, nil
+	}
+
+	products = applyLimit(products, args.Limit)
+
+	return nil, upcomingGetOutput{
+		Total: len(products),
+		Items: dto.FromProducts(products),
 	}, nil
 }
 
-func searchProductsHandler(_ context.Context, _ *mcp.CallToolRequest, args searchProductsArgs, source types.ProductSource) (*mcp.CallToolResult, searchProductsOutput, error) {
+// searchProductsHandler searches source for args.Query. allowEmpty, set from
+// ServerOptions.TrendingSearch, lets an empty query through to the source,
+// which answers it with trending products.
+func searchProductsHandler(ctx context.Context, _ *mcp.CallToolRequest, args searchProductsArgs, source types.ProductSource, allowEmpty bool) (*mcp.CallToolResult, searchProductsOutput, error) {
 	query := strings.TrimSpace(args.Query)
-	if query == "" {
+	if query == "" && !allowEmpty {
 		return errorToolResult("query is required"), searchProductsOutput{}, nil
 	}
 	page := args.Page
@@ -275,29 +907,51 @@ func searchProductsHandler(_ context.Context, _ *mcp.CallToolRequest, args searc
 	if page < 1 || page > 10 {
 		return errorToolResult("page must be between 1 and 10"), searchProductsOutput{}, nil
 	}
+	pricing, err := parsePricingFilter(args.Pricing)
+	if err != nil {
+		return errorToolResult(err.Error()), searchProductsOutput{}, nil
+	}
+	order, err := parseSortOrder(args.Sort)
+	if err != nil {
+		return errorToolResult(err.Error()), searchProductsOutput{}, nil
+	}
 
-	searchSource, ok := source.(searchableSource)
+	searchSource, ok := source.(types.SearchSource)
 	if !ok {
 		return errorToolResult("search is not supported by this source"), searchProductsOutput{}, nil
 	}
 
-	products, currentPage, hasPrev, hasNext, pagesCount, err := searchSource.SearchProductsPage(query, page)
+	products, currentPage, hasPrev, hasNext, pagesCount, err := types.FetchSearchProductsPage(ctx, searchSource, query, page)
 	if err != nil {
-		msg := "search failed"
-		if strings.Contains(strings.ToLower(err.Error()), "cloudflare") {
-			msg = "search blocked by Cloudflare challenge; retryable=false"
-		}
-		return errorToolResult(msg), searchProductsOutput{}, nil
+		return errorToolResult(searchErrorMessage(err)), searchProductsOutput{}, nil
+	}
+
+	if args.HideOffline {
+		products = filterOnline(products)
+	}
+	var filteredOut int
+	if args.FeaturedOnly {
+		products, filteredOut = types.FilterFeatured(products)
+	}
+	products, pricingPartial := filterByPricing(ctx, source, products, pricing, args.IncludeUnknownPricing, 0)
+	products = types.SortProducts(products, order)
+	var note string
+	if n, ok := source.(searchNoteSource); ok {
+		note = n.SearchNote()
 	}
 
 	return nil, searchProductsOutput{
-		Query:      query,
-		Page:       currentPage,
-		HasPrev:    hasPrev,
-		HasNext:    hasNext,
-		PagesCount: pagesCount,
-		ItemsCount: len(products),
-		Items:      dto.FromProducts(products),
+		Query:          query,
+		Page:           currentPage,
+		HasPrev:        hasPrev,
+		HasNext:        hasNext,
+		PagesCount:     pagesCount,
+		ItemsCount:     len(products),
+		Sort:           string(order),
+		Items:          dto.FromProducts(products),
+		FilteredOut:    filteredOut,
+		Note:           note,
+		PricingPartial: pricingPartial,
 	}, nil
 }
 
@@ -310,6 +964,35 @@ func cacheClearHandler(_ context.Context, _ *mcp.CallToolRequest, source types.P
 	return nil, cacheClearOutput{Status: "ok"}, nil
 }
 
+func parseSortOrder(raw string) (types.SortOrder, error) {
+	v := types.SortOrder(strings.TrimSpace(strings.ToLower(raw)))
+	if v == "" {
+		return types.SortRelevance, nil
+	}
+	for _, order := range types.SortOrders {
+		if v == order {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid sort %q; expected relevance|votes|reviews|rating", raw)
+}
+
+// Leaderboard orders for leaderboard_get.
+const (
+	sortRank       = "rank"
+	sortLaunchTime = "launch_time"
+)
+
+func parseLeaderboardSort(raw string) (string, error) {
+	switch v := strings.TrimSpace(strings.ToLower(raw)); v {
+	case "", sortRank:
+		return sortRank, nil
+	case sortLaunchTime:
+		return v, nil
+	}
+	return "", fmt.Errorf("invalid sort %q; expected rank|launch_time", raw)
+}
+
 func errorToolResult(msg string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
 		IsError: true,
@@ -317,6 +1000,42 @@ func errorToolResult(msg string) *mcp.CallToolResult {
 	}
 }
 
+// capItems cuts items to the server-wide MaxItems cap, reporting whether
+// anything was dropped. A non-positive max disables the cap.
+func capItems[T any](items []T, max int) ([]T, bool) {
+	if max <= 0 || len(items) <= max {
+		return items, false
+	}
+	return items[:max], true
+}
+
+// capLimit lowers a tool's requested limit (or its default, when limit is
+// unset) to max, reporting whether it was lowered.
+func capLimit(limit, defaultLimit, max int) (int, bool) {
+	if max <= 0 {
+		return limit, false
+	}
+	effective := limit
+	if effective <= 0 {
+		effective = defaultLimit
+	}
+	if effective > max {
+		return max, true
+	}
+	return limit, false
+}
+
+// filterOnline drops products marked no longer online.
+func filterOnline(products []types.Product) []types.Product {
+	out := make([]types.Product, 0, len(products))
+	for _, p := range products {
+		if !p.Offline() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func applyLimit(items []types.Product, limit int) []types.Product {
 	if limit <= 0 || limit >= len(items) {
 		return items
@@ -344,13 +1063,45 @@ func parsePeriod(raw string) (types.Period, error) {
 func parseDate(raw string) (time.Time, error) {
 	v := strings.TrimSpace(raw)
 	if v == "" {
-		return time.Now(), nil
+		return types.Today(), nil
 	}
-	if d, err := time.Parse(time.DateOnly, v); err == nil {
+	if d, ok := relativeDate(v, types.Today()); ok {
+		return d, nil
+	}
+	if d, err := time.ParseInLocation(time.DateOnly, v, types.Timezone()); err == nil {
 		return d, nil
 	}
 	if ts, err := time.Parse(time.RFC3339, v); err == nil {
-		return ts, nil
+		return types.DayIn(ts), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q; expected YYYY-MM-DD, RFC3339, today, yesterday, last-week, or last-month", raw)
+}
+
+// relativeDate resolves a relative date expression against today (midnight
+// in the configured zone). "last-week" lands in the previous ISO week and
+// "last-month" in the previous calendar month, so they select the prior
+// leaderboard for the weekly and monthly periods. Spaces and underscores are
+// accepted in place of hyphens.
+func relativeDate(expr string, today time.Time) (time.Time, bool) {
+	normalized := strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(expr)))
+	switch normalized {
+	case "today", "this-week", "this-month":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "last-week":
+		return today.AddDate(0, 0, -7), true
+	case "last-month":
+		// Clamp the day so e.g. March 31 maps to February's last day instead
+		// of overflowing into March.
+		firstOfLast := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, today.Location())
+		lastDay := firstOfLast.AddDate(0, 1, -1).Day()
+		day := today.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		return firstOfLast.AddDate(0, 0, day-1), true
+	default:
+		return time.Time{}, false
 	}
-	return time.Time{}, fmt.Errorf("invalid date %q; expected YYYY-MM-DD or RFC3339", raw)
 }
@@ -4,19 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/dto"
 	"github.com/qyinm/phtui/types"
 )
 
 type fakeSource struct {
 	leaderboard []types.Product
 	detail      types.ProductDetail
+	details     map[string]types.ProductDetail
 	catProducts []types.Product
 	catLinks    []types.CategoryLink
 	search      []types.Product
@@ -25,6 +32,7 @@ type fakeSource struct {
 	failDetail  bool
 	failCat     bool
 	failSearch  bool
+	detailErr   error
 }
 
 func newFakeSource() *fakeSource {
@@ -37,23 +45,28 @@ func newFakeSource() *fakeSource {
 		"demo-product",
 		"https://img.example/demo.png",
 		1,
+		0,
+		false,
 	)
-	detail := types.NewProductDetail(
-		product,
-		"Description",
-		4.5,
-		8,
-		20,
-		"Maker comment",
-		"https://demo.example",
-		[]string{"AI Agents"},
-		[]string{"https://x.com/demo"},
-		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
-		"Maker",
-		"https://producthunt.com/@maker",
-		nil,
-		"$9/month",
-	)
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:            product,
+		Description:        "Description",
+		Rating:             4.5,
+		ReviewCount:        8,
+		FollowerCount:      20,
+		MakerComment:       "Maker comment",
+		WebsiteURL:         "https://demo.example",
+		Categories:         []string{"AI Agents"},
+		SocialLinks:        []string{"https://x.com/demo"},
+		FirstLaunch:        time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		LatestLaunch:       time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		MakerName:          "Maker",
+		MakerProfileURL:    "https://producthunt.com/@maker",
+		PricingInfo:        "$9/month",
+		RatingDistribution: [5]int{0, 0, 1, 3, 4},
+		Related:            []types.Product{types.NewProduct("Demo Lite", "The smaller demo", nil, 0, 0, "demo-lite", "", 0, 0, false)},
+		Topics:             []types.CategoryLink{types.NewCategoryLink("AI", "artificial-intelligence")},
+	})
 	return &fakeSource{
 		leaderboard: []types.Product{product},
 		detail:      detail,
@@ -77,6 +90,12 @@ func (f *fakeSource) GetProductDetail(slug string) (types.ProductDetail, error)
 	if f.failDetail {
 		return types.ProductDetail{}, errors.New("upstream detail error")
 	}
+	if f.detailErr != nil {
+		return types.ProductDetail{}, f.detailErr
+	}
+	if d, ok := f.details[slug]; ok {
+		return d, nil
+	}
 	return f.detail, nil
 }
 
@@ -108,6 +127,646 @@ func TestToolLeaderboardInvalidPeriod(t *testing.T) {
 	}
 }
 
+func TestToolLeaderboardDigest(t *testing.T) {
+	src := newFakeSource()
+	second := types.NewProduct("Second Product", "Runner up", nil, 50, 1, "second-product", "", 2, 0, false)
+	src.leaderboard = append(src.leaderboard, second)
+
+	result, out, err := leaderboardDigestHandler(context.Background(), nil, leaderboardDigestArgs{Period: "daily", Date: "2026-02-18"}, src)
+	if err != nil {
+		t.Fatalf("unexpected handler error: %v", err)
+	}
+	if result == nil || result.IsError {
+		t.Fatalf("expected successful text result")
+	}
+	if out.Total != 2 {
+		t.Fatalf("unexpected total: %d", out.Total)
+	}
+	for _, want := range []string{"1. **Demo Product**", "2. **Second Product**", "2026-02-18"} {
+		if !strings.Contains(out.Digest, want) {
+			t.Fatalf("digest missing %q:\n%s", want, out.Digest)
+		}
+	}
+	if strings.Index(out.Digest, "Demo Product") > strings.Index(out.Digest, "Second Product") {
+		t.Fatalf("digest not in rank order:\n%s", out.Digest)
+	}
+}
+
+func newPricingFakeSource() *fakeSource {
+	src := newFakeSource()
+	mk := func(name, slug, pricing string, rank int) types.Product {
+		p := types.NewProduct(name, "", nil, 10, 0, slug, "", rank, 0, false)
+		src.details[slug] = types.NewProductDetail(types.ProductDetailOptions{
+			Product:     p,
+			PricingInfo: pricing,
+		})
+		return p
+	}
+	src.details = make(map[string]types.ProductDetail)
+	products := []types.Product{
+		mk("Free App", "free-app", "Free", 1),
+		mk("Paid App", "paid-app", "$9/month", 2),
+		mk("Mystery App", "mystery-app", "", 3),
+	}
+	src.catProducts = products
+	src.search = products
+	return src
+}
+
+func productSlugs(items []dto.Product) []string {
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		out = append(out, it.Slug)
+	}
+	return out
+}
+
+type timedFakeSource struct {
+	*fakeSource
+	featured map[string]time.Time
+}
+
+func (f *timedFakeSource) GetFeaturedTimes(types.Period, time.Time) (map[string]time.Time, error) {
+	return f.featured, nil
+}
+
+func TestToolLeaderboardSince(t *testing.T) {
+	base := newFakeSource()
+	base.leaderboard = []types.Product{
+		types.NewProduct("Early", "", nil, 0, 0, "early", "", 1, 0, false),
+		types.NewProduct("Late", "", nil, 0, 0, "late", "", 2, 0, false),
+		types.NewProduct("Untimed", "", nil, 0, 0, "untimed", "", 3, 0, false),
+	}
+	since := time.Date(2026, 2, 18, 9, 0, 0, 0, time.UTC)
+	source := &timedFakeSource{
+		fakeSource: base,
+		featured: map[string]time.Time{
+			"early": since.Add(-time.Hour),
+			"late":  since.Add(time.Hour),
+		},
+	}
+
+	result, out, err := leaderboardSinceHandler(context.Background(), nil, leaderboardSinceArgs{Since: since.Format(time.RFC3339)}, source)
+	if err != nil || result != nil {
+		t.Fatalf("unexpected result: %v, %v", result, err)
+	}
+	if out.Fallback {
+		t.Fatalf("unexpected fallback with featured times")
+	}
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"late"}) {
+		t.Fatalf("got %v want [late]", got)
+	}
+
+	// Without featured times, all of today's products come back flagged.
+	_, out, err = leaderboardSinceHandler(context.Background(), nil, leaderboardSinceArgs{Since: since.Format(time.RFC3339)}, base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.Fallback || out.Total != 3 {
+		t.Fatalf("expected fallback with 3 items, got %+v", out)
+	}
+
+	result, _, _ = leaderboardSinceHandler(context.Background(), nil, leaderboardSinceArgs{Since: "yesterday"}, source)
+	if result == nil || !result.IsError {
+		t.Fatalf("expected IsError for invalid since")
+	}
+}
+
+func TestToolLeaderboardGetLaunchTimeSort(t *testing.T) {
+	base := newFakeSource()
+	base.leaderboard = []types.Product{
+		types.NewProduct("Early", "", nil, 0, 0, "early", "", 1, 0, false),
+		types.NewProduct("Untimed", "", nil, 0, 0, "untimed", "", 2, 0, false),
+		types.NewProduct("Late", "", nil, 0, 0, "late", "", 3, 0, false),
+	}
+	at := time.Date(2026, 2, 18, 0, 1, 0, 0, time.UTC)
+	source := &timedFakeSource{
+		fakeSource: base,
+		featured: map[string]time.Time{
+			"early": at,
+			"late":  at.Add(3 * time.Hour),
+		},
+	}
+
+	result, out, err := leaderboardGetHandler(context.Background(), nil, leaderboardGetArgs{Period: "daily", Sort: "launch_time"}, source)
+	if err != nil || result != nil {
+		t.Fatalf("unexpected result: %v, %v", result, err)
+	}
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"late", "early", "untimed"}) {
+		t.Fatalf("got %v want [late early untimed]", got)
+	}
+	if out.Sort != "launch_time" || out.Fallback {
+		t.Fatalf("sort = %q, fallback = %v", out.Sort, out.Fallback)
+	}
+
+	// Without featured times the leaderboard keeps rank order, flagged.
+	_, out, _ = leaderboardGetHandler(context.Background(), nil, leaderboardGetArgs{Period: "daily", Sort: "launch_time"}, base)
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"early", "untimed", "late"}) || !out.Fallback {
+		t.Fatalf("fallback order %v, fallback = %v", got, out.Fallback)
+	}
+
+	_, out, _ = leaderboardGetHandler(context.Background(), nil, leaderboardGetArgs{Period: "daily"}, source)
+	if out.Sort != "rank" || productSlugs(out.Items)[0] != "early" || out.Fallback {
+		t.Fatalf("default order: %+v", out)
+	}
+
+	result, _, _ = leaderboardGetHandler(context.Background(), nil, leaderboardGetArgs{Period: "daily", Sort: "newest"}, source)
+	if result == nil || !result.IsError {
+		t.Fatal("expected IsError for an unknown sort")
+	}
+}
+
+type upcomingFakeSource struct {
+	*fakeSource
+	upcoming []types.Product
+}
+
+func (f *upcomingFakeSource) GetUpcomingProducts() ([]types.Product, error) {
+	return f.upcoming, nil
+}
+
+// rankFakeSource serves a different daily leaderboard per date and,
+// optionally, an embedded rank history.
+type rankFakeSource struct {
+	*fakeSource
+	boards  map[string][]types.Product
+	history []types.RankPoint
+	fetches int
+}
+
+func (f *rankFakeSource) GetLeaderboard(_ types.Period, date time.Time) ([]types.Product, error) {
+	f.fetches++
+	return f.boards[date.Format(time.DateOnly)], nil
+}
+
+func (f *rankFakeSource) GetRankHistory(string) ([]types.RankPoint, error) {
+	return f.history, nil
+}
+
+// rangeFakeSource adds per-date featured times to rankFakeSource.
+type rangeFakeSource struct {
+	*rankFakeSource
+	featured map[string]map[string]time.Time
+}
+
+func (f *rangeFakeSource) GetFeaturedTimes(_ types.Period, date time.Time) (map[string]time.Time, error) {
+	return f.featured[date.Format(time.DateOnly)], nil
+}
+
+func TestToolLeaderboardRangeByDate(t *testing.T) {
+	product := func(slug string, rank int) types.Product {
+		return types.NewProduct(slug, "", nil, 1, 0, slug, "", rank, 0, false)
+	}
+	src := &rangeFakeSource{
+		rankFakeSource: &rankFakeSource{fakeSource: newFakeSource(), boards: map[string][]types.Product{
+			"2026-02-16": {product("monday", 1)},
+			"2026-02-17": {product("tuesday", 1), product("late-monday", 2), product("repeat", 3)},
+			"2026-02-18": {product("repeat", 1), product("wednesday", 2)},
+		}},
+		featured: map[string]map[string]time.Time{
+			// 07:30 UTC on the 17th is still the 16th in Pacific time.
+			"2026-02-17": {"late-monday": time.Date(2026, 2, 17, 7, 30, 0, 0, time.UTC)},
+		},
+	}
+
+	_, out, err := leaderboardRangeHandler(context.Background(), nil, leaderboardRangeArgs{From: "2026-02-16", To: "2026-02-18", ByDate: true}, src)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	got := map[string][]string{}
+	for date, items := range out.ByDate {
+		got[date] = productSlugs(items)
+	}
+	want := map[string][]string{
+		"2026-02-16": {"monday", "late-monday"},
+		"2026-02-17": {"tuesday", "repeat"},
+		"2026-02-18": {"wednesday"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("by_date = %v, want %v", got, want)
+	}
+	if out.Total != 5 || out.Items != nil {
+		t.Fatalf("total=%d items=%v, want 5 and no flat list", out.Total, out.Items)
+	}
+
+	_, flat, _ := leaderboardRangeHandler(context.Background(), nil, leaderboardRangeArgs{From: "2026-02-16", To: "2026-02-18"}, src)
+	if got := strings.Join(productSlugs(flat.Items), ","); got != "monday,late-monday,tuesday,repeat,wednesday" {
+		t.Fatalf("flat items = %s", got)
+	}
+
+	for _, args := range []leaderboardRangeArgs{
+		{From: "2026-02-18", To: "2026-02-16"},
+		{From: "2026-01-01", To: "2026-02-18"},
+	} {
+		if res, _, _ := leaderboardRangeHandler(context.Background(), nil, args, src); res == nil || !res.IsError {
+			t.Fatalf("range %s..%s must return IsError", args.From, args.To)
+		}
+	}
+}
+
+func TestToolProductGetRankHistory(t *testing.T) {
+	ctx := context.Background()
+	launch := time.Date(2025, 2, 18, 0, 0, 0, 0, time.UTC)
+	embedded := &rankFakeSource{fakeSource: newFakeSource(), history: []types.RankPoint{types.NewRankPoint(launch, 1)}}
+	_, out, err := productGetRankHistoryHandler(ctx, nil, productGetRankHistoryArgs{Slug: "tanka"}, embedded)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if out.Source != "detail" || out.Partial || len(out.History) != 1 || out.History[0] != (dto.RankPoint{Date: "2025-02-18", Rank: 1}) {
+		t.Fatalf("embedded history = %+v", out)
+	}
+	if embedded.fetches != 0 {
+		t.Fatalf("embedded history still scanned %d leaderboards", embedded.fetches)
+	}
+
+	// Without embedded history, recent daily leaderboards are scanned.
+	today := types.Today()
+	day := func(offset int) string { return today.AddDate(0, 0, -offset).Format(time.DateOnly) }
+	demo := func(rank int) types.Product {
+		return types.NewProduct("Demo", "", nil, 1, 0, "demo", "", rank, 0, false)
+	}
+	scanned := &rankFakeSource{fakeSource: newFakeSource(), boards: map[string][]types.Product{
+		day(0): {demo(3)},
+		day(1): {demo(5)},
+		day(3): {demo(9)},
+	}}
+	_, out, _ = productGetRankHistoryHandler(ctx, nil, productGetRankHistoryArgs{Slug: "demo", Days: 3}, scanned)
+	want := []dto.RankPoint{{Date: day(1), Rank: 5}, {Date: day(0), Rank: 3}}
+	if out.Source != "leaderboards" || !out.Partial || !reflect.DeepEqual(out.History, want) {
+		t.Fatalf("scanned history = %+v, want %+v", out, want)
+	}
+	if scanned.fetches != 3 {
+		t.Fatalf("scanned %d leaderboards, want 3", scanned.fetches)
+	}
+
+	scanned.fetches = 0
+	productGetRankHistoryHandler(ctx, nil, productGetRankHistoryArgs{Slug: "demo", Days: 1000}, scanned)
+	if scanned.fetches != maxRankHistoryDays {
+		t.Fatalf("lookback scanned %d days, want cap %d", scanned.fetches, maxRankHistoryDays)
+	}
+
+	if result, _, _ := productGetRankHistoryHandler(ctx, nil, productGetRankHistoryArgs{}, scanned); result == nil || !result.IsError {
+		t.Fatal("expected IsError for missing slug")
+	}
+	failing := newFakeSource()
+	failing.failLeader = true
+	if result, _, _ := productGetRankHistoryHandler(ctx, nil, productGetRankHistoryArgs{Slug: "demo"}, failing); result == nil || !result.IsError {
+		t.Fatal("expected IsError when no leaderboard can be fetched")
+	}
+}
+
+func TestToolProductGetReviewsSummary(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	_, out, err := productGetReviewsSummaryHandler(ctx, nil, productGetReviewsSummaryArgs{Slug: " demo "}, src)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	want := []ratingBucket{{5, 4}, {4, 3}, {3, 1}, {2, 0}, {1, 0}}
+	if !out.Available || out.Slug != "demo" || out.Total != 8 || out.Average != 4.38 || !reflect.DeepEqual(out.Distribution, want) {
+		t.Fatalf("summary = %+v", out)
+	}
+
+	// Without a histogram the header rating and count are still reported.
+	src.details = map[string]types.ProductDetail{"bare": types.NewProductDetail(types.ProductDetailOptions{
+		Product:     types.NewProduct("Bare", "", nil, 0, 0, "bare", "", 1, 0, false),
+		Rating:      3.5,
+		ReviewCount: 2,
+	})}
+	_, out, _ = productGetReviewsSummaryHandler(ctx, nil, productGetReviewsSummaryArgs{Slug: "bare"}, src)
+	if out.Available || out.Average != 3.5 || out.Total != 2 || out.Distribution == nil || len(out.Distribution) != 0 {
+		t.Fatalf("summary without histogram = %+v", out)
+	}
+
+	if result, _, _ := productGetReviewsSummaryHandler(ctx, nil, productGetReviewsSummaryArgs{}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError for missing slug")
+	}
+	src.failDetail = true
+	if result, _, _ := productGetReviewsSummaryHandler(ctx, nil, productGetReviewsSummaryArgs{Slug: "demo"}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError when the detail can't be fetched")
+	}
+}
+
+func TestToolProductGetTopics(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	_, out, err := productGetTopicsHandler(ctx, nil, productGetTopicsArgs{Slug: "demo"}, src)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	want := []productTopic{{Name: "AI", Slug: "artificial-intelligence", Path: "/topics/artificial-intelligence"}}
+	if out.Slug != "demo" || out.Total != 1 || !reflect.DeepEqual(out.Items, want) {
+		t.Fatalf("topics = %+v", out)
+	}
+
+	// A page without topics has no items, not an error.
+	src.details = map[string]types.ProductDetail{"solo": types.NewProductDetail(types.ProductDetailOptions{Product: types.NewProduct("Solo", "", nil, 0, 0, "solo", "", 1, 0, false)})}
+	result, out, _ := productGetTopicsHandler(ctx, nil, productGetTopicsArgs{Slug: "solo"}, src)
+	if result != nil || out.Total != 0 || out.Items == nil {
+		t.Fatalf("topics without any = %+v (result %v)", out, result)
+	}
+
+	if result, _, _ := productGetTopicsHandler(ctx, nil, productGetTopicsArgs{}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError for missing slug")
+	}
+	src.failDetail = true
+	if result, _, _ := productGetTopicsHandler(ctx, nil, productGetTopicsArgs{Slug: "demo"}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError when the detail can't be fetched")
+	}
+}
+
+// flakyDetailSource fails the detail of one slug and holds another until
+// release is closed.
+type flakyDetailSource struct {
+	*fakeSource
+	failSlug string
+	slowSlug string
+	release  chan struct{}
+}
+
+func (f *flakyDetailSource) GetProductDetail(slug string) (types.ProductDetail, error) {
+	switch slug {
+	case f.failSlug:
+		return types.ProductDetail{}, errors.New("upstream detail error")
+	case f.slowSlug:
+		<-f.release
+	}
+	return f.fakeSource.GetProductDetail(slug)
+}
+
+func TestToolFindAlternatives(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	src.search = nil
+	for _, slug := range []string{"a", "b", "c", "d"} {
+		src.search = append(src.search, types.NewProduct(strings.ToUpper(slug), "", nil, 10, 0, slug, "", 0, 0, false))
+	}
+
+	_, out, err := findAlternativesHandler(ctx, nil, findAlternativesArgs{Query: "demo", Limit: 3}, src)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if out.Query != "demo" || out.Total != 3 || out.Failed != 0 || len(out.Items) != 3 {
+		t.Fatalf("alternatives = %+v", out)
+	}
+	first := out.Items[0]
+	if first.Slug != "a" || first.Rating != 4.5 || first.ReviewCount != 8 || first.PricingType != "paid" || first.Error != "" {
+		t.Fatalf("first alternative = %+v", first)
+	}
+
+	// The limit is capped.
+	_, out, _ = findAlternativesHandler(ctx, nil, findAlternativesArgs{Query: "demo", Limit: 100}, src)
+	if out.Total != 4 {
+		t.Fatalf("uncapped search results = %d, want all 4", out.Total)
+	}
+
+	for _, args := range []findAlternativesArgs{{}, {Query: "demo", Limit: -1}} {
+		if result, _, _ := findAlternativesHandler(ctx, nil, args, src); result == nil || !result.IsError {
+			t.Fatalf("expected IsError for %+v", args)
+		}
+	}
+	src.failSearch = true
+	if result, _, _ := findAlternativesHandler(ctx, nil, findAlternativesArgs{Query: "demo"}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError when the search fails")
+	}
+}
+
+func TestFindAlternativesPartialResults(t *testing.T) {
+	src := &flakyDetailSource{fakeSource: newFakeSource(), failSlug: "b", slowSlug: "c", release: make(chan struct{})}
+	defer close(src.release)
+	var products []types.Product
+	for _, slug := range []string{"a", "b", "c"} {
+		products = append(products, types.NewProduct(strings.ToUpper(slug), "", nil, 0, 0, slug, "", 0, 0, false))
+	}
+
+	items := fetchAlternatives(context.Background(), src, products, 50*time.Millisecond)
+	if len(items) != 3 {
+		t.Fatalf("items = %+v", items)
+	}
+	if items[0].Error != "" || items[0].Rating != 4.5 {
+		t.Errorf("fetched item = %+v", items[0])
+	}
+	if items[1].Slug != "b" || items[1].Error != "fetch product detail failed" {
+		t.Errorf("failed item = %+v", items[1])
+	}
+	if items[2].Slug != "c" || items[2].Error != "detail fetch timed out" {
+		t.Errorf("item past the budget = %+v", items[2])
+	}
+}
+
+func TestToolProductRelatedLaunches(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	_, out, err := productRelatedLaunchesHandler(ctx, nil, productRelatedLaunchesArgs{Slug: "demo"}, src)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if out.Total != 1 || len(out.Items) != 1 || out.Items[0].Slug != "demo-lite" || out.Items[0].Tagline != "The smaller demo" {
+		t.Fatalf("related launches = %+v", out)
+	}
+
+	// A page without the section has no items, not an error.
+	src.details = map[string]types.ProductDetail{"solo": types.NewProductDetail(types.ProductDetailOptions{Product: types.NewProduct("Solo", "", nil, 0, 0, "solo", "", 1, 0, false)})}
+	result, out, _ := productRelatedLaunchesHandler(ctx, nil, productRelatedLaunchesArgs{Slug: "solo"}, src)
+	if result != nil || out.Total != 0 || out.Items == nil {
+		t.Fatalf("related launches without the section = %+v (result %v)", out, result)
+	}
+
+	if result, _, _ := productRelatedLaunchesHandler(ctx, nil, productRelatedLaunchesArgs{}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError for missing slug")
+	}
+	src.failDetail = true
+	if result, _, _ := productRelatedLaunchesHandler(ctx, nil, productRelatedLaunchesArgs{Slug: "demo"}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError when the detail can't be fetched")
+	}
+}
+
+func TestToolUpcomingGet(t *testing.T) {
+	source := &upcomingFakeSource{
+		fakeSource: newFakeSource(),
+		upcoming: []types.Product{
+			types.NewProduct("Soon", "Launching soon", nil, 0, 0, "soon", "", 1, 0, false),
+			types.NewProduct("Later", "", nil, 0, 0, "later", "", 2, 0, false),
+		},
+	}
+
+	result, out, err := upcomingGetHandler(context.Background(), nil, upcomingGetArgs{}, source)
+	if err != nil || result != nil {
+		t.Fatalf("unexpected result: %v, %v", result, err)
+	}
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"soon", "later"}) {
+		t.Fatalf("got %v want [soon later]", got)
+	}
+
+	_, out, _ = upcomingGetHandler(context.Background(), nil, upcomingGetArgs{Limit: 1}, source)
+	if out.Total != 1 {
+		t.Fatalf("expected limit to apply, got %+v", out)
+	}
+
+	result, _, _ = upcomingGetHandler(context.Background(), nil, upcomingGetArgs{}, newFakeSource())
+	if result == nil || !result.IsError {
+		t.Fatalf("expected IsError for source without upcoming support")
+	}
+}
+
+func TestToolPricingFilter(t *testing.T) {
+	cases := []struct {
+		pricing        string
+		includeUnknown bool
+		want           []string
+	}{
+		{"", false, []string{"free-app", "paid-app", "mystery-app"}},
+		{"free", false, []string{"free-app"}},
+		{"paid", false, []string{"paid-app"}},
+		{"PAID", true, []string{"paid-app", "mystery-app"}},
+	}
+	for _, tc := range cases {
+		src := newPricingFakeSource()
+		_, catOut, err := categoryGetProductsHandler(context.Background(), nil, categoryGetProductsArgs{Slug: "ai-agents", Pricing: tc.pricing, IncludeUnknownPricing: tc.includeUnknown}, src)
+		if err != nil {
+			t.Fatalf("category handler error: %v", err)
+		}
+		if got := productSlugs(catOut.Items); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("category pricing=%q unknown=%v: got %v, want %v", tc.pricing, tc.includeUnknown, got, tc.want)
+		}
+
+		_, searchOut, err := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "app", Pricing: tc.pricing, IncludeUnknownPricing: tc.includeUnknown}, src, false)
+		if err != nil {
+			t.Fatalf("search handler error: %v", err)
+		}
+		if got := productSlugs(searchOut.Items); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("search pricing=%q unknown=%v: got %v, want %v", tc.pricing, tc.includeUnknown, got, tc.want)
+		}
+	}
+
+	result, _, _ := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "app", Pricing: "cheap"}, newPricingFakeSource(), false)
+	if result == nil || !result.IsError {
+		t.Fatalf("expected IsError for invalid pricing filter")
+	}
+}
+
+// countingDetailSource counts detail lookups.
+type countingDetailSource struct {
+	*fakeSource
+	mu      sync.Mutex
+	lookups int
+}
+
+func (s *countingDetailSource) GetProductDetail(slug string) (types.ProductDetail, error) {
+	s.mu.Lock()
+	s.lookups++
+	s.mu.Unlock()
+	return s.fakeSource.GetProductDetail(slug)
+}
+
+func TestToolPricingFilterBoundsLookups(t *testing.T) {
+	newSource := func() *countingDetailSource {
+		src := newFakeSource()
+		src.details = make(map[string]types.ProductDetail)
+		src.catProducts = nil
+		for i := range 50 {
+			slug := fmt.Sprintf("app-%d", i)
+			p := types.NewProduct(slug, "", nil, 10, 0, slug, "", i+1, 0, false)
+			src.details[slug] = types.NewProductDetail(types.ProductDetailOptions{Product: p, PricingInfo: "Free"})
+			src.catProducts = append(src.catProducts, p)
+		}
+		src.search = src.catProducts
+		return &countingDetailSource{fakeSource: src}
+	}
+
+	// A limit stops the lookups once enough products match.
+	src := newSource()
+	_, out, _ := categoryGetProductsHandler(context.Background(), nil, categoryGetProductsArgs{Slug: "ai-agents", Pricing: "free", Limit: 3}, src)
+	if out.Total != 3 || out.PricingPartial || src.lookups > pricingLookupConcurrency {
+		t.Errorf("limit 3: total %d, partial %v, lookups %d", out.Total, out.PricingPartial, src.lookups)
+	}
+
+	// Without one the cap applies and the result says so.
+	src = newSource()
+	_, searchOut, _ := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "app", Pricing: "free"}, src, false)
+	if searchOut.ItemsCount != maxPricingLookups || !searchOut.PricingPartial || src.lookups != maxPricingLookups {
+		t.Errorf("no limit: items %d, partial %v, lookups %d; want %d capped", searchOut.ItemsCount, searchOut.PricingPartial, src.lookups, maxPricingLookups)
+	}
+}
+
+func TestToolCategoryMinReviews(t *testing.T) {
+	src := newFakeSource()
+	src.catProducts = []types.Product{
+		types.NewProduct("Established", "", nil, 0, 155, "established", "", 1, 4.8, false),
+		types.NewProduct("Newcomer", "", nil, 0, 3, "newcomer", "", 2, 5, false),
+		types.NewProduct("Steady", "", nil, 0, 20, "steady", "", 3, 4.5, false),
+	}
+
+	_, out, err := categoryGetProductsHandler(context.Background(), nil, categoryGetProductsArgs{Slug: "ai-agents", MinReviews: 20}, src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"established", "steady"}) {
+		t.Fatalf("got %v want [established steady]", got)
+	}
+	if out.Total != 2 || out.FilteredOut != 1 {
+		t.Fatalf("total = %d, filtered out = %d; want 2 and 1", out.Total, out.FilteredOut)
+	}
+
+	_, out, _ = categoryGetProductsHandler(context.Background(), nil, categoryGetProductsArgs{Slug: "ai-agents"}, src)
+	if out.Total != 3 || out.FilteredOut != 0 {
+		t.Fatalf("without a threshold: total = %d, filtered out = %d", out.Total, out.FilteredOut)
+	}
+
+	result, _, _ := categoryGetProductsHandler(context.Background(), nil, categoryGetProductsArgs{Slug: "ai-agents", MinReviews: -1}, src)
+	if result == nil || !result.IsError {
+		t.Fatal("expected IsError for a negative threshold")
+	}
+}
+
+// pagedCategorySource serves category listings of pagesCount pages, one
+// product per page.
+type pagedCategorySource struct {
+	*fakeSource
+	pagesCount int
+	pages      []int
+}
+
+func (f *pagedCategorySource) GetCategoryProductsPage(slug string, page int) ([]types.Product, []types.CategoryLink, bool, int, error) {
+	f.pages = append(f.pages, page)
+	product := types.NewProduct(fmt.Sprintf("Page %d", page), "", nil, 0, 0, fmt.Sprintf("page-%d", page), "", page, 0, false)
+	return []types.Product{product}, f.catLinks, page < f.pagesCount, f.pagesCount, nil
+}
+
+func TestToolCategoryGetProductsPage(t *testing.T) {
+	ctx := context.Background()
+	src := &pagedCategorySource{fakeSource: newFakeSource(), pagesCount: 3}
+
+	_, out, err := categoryGetProductsHandler(ctx, nil, categoryGetProductsArgs{Slug: "ai-agents", Page: 2}, src)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if out.Page != 2 || !out.HasNext || out.PagesCount != 3 || productSlugs(out.Items)[0] != "page-2" {
+		t.Fatalf("page 2 = %+v", out)
+	}
+	_, out, _ = categoryGetProductsHandler(ctx, nil, categoryGetProductsArgs{Slug: "ai-agents", Page: 3}, src)
+	if out.Page != 3 || out.HasNext {
+		t.Fatalf("last page = %+v", out)
+	}
+	_, out, _ = categoryGetProductsHandler(ctx, nil, categoryGetProductsArgs{Slug: "ai-agents"}, src)
+	if out.Page != 1 || !reflect.DeepEqual(src.pages, []int{2, 3, 1}) {
+		t.Fatalf("default page = %d, pages fetched %v", out.Page, src.pages)
+	}
+	if result, _, _ := categoryGetProductsHandler(ctx, nil, categoryGetProductsArgs{Slug: "ai-agents", Page: -1}, src); result == nil || !result.IsError {
+		t.Fatal("expected IsError for a negative page")
+	}
+
+	// A source without pages serves page 1 only.
+	plain := newFakeSource()
+	_, out, _ = categoryGetProductsHandler(ctx, nil, categoryGetProductsArgs{Slug: "ai-agents"}, plain)
+	if out.Page != 1 || out.HasNext || out.PagesCount != 0 || out.Total != 1 {
+		t.Fatalf("page 1 without paging = %+v", out)
+	}
+	if result, _, _ := categoryGetProductsHandler(ctx, nil, categoryGetProductsArgs{Slug: "ai-agents", Page: 2}, plain); result == nil || !result.IsError {
+		t.Fatal("expected IsError for page 2 from a source without pages")
+	}
+}
+
 func TestToolCategoryListPaging(t *testing.T) {
 	_, out, err := categoryListHandler(context.Background(), nil, categoryListArgs{Offset: 0, Limit: 10})
 	if err != nil {
@@ -124,8 +783,249 @@ func TestToolCategoryListPaging(t *testing.T) {
 	}
 }
 
+func TestToolCategoryResolve(t *testing.T) {
+	tests := []struct {
+		query string
+		slug  string
+		match string
+	}{
+		{"AI Agents", "ai-agents", matchExact},
+		{"a/b testing", "a-b-testing", matchExact},
+		{"Notion", "notion-templates", matchPrefix},
+		{"notetakers", "ai-meeting-notetakers", matchPartial},
+		{"agents ai", "ai-agents", matchWords},
+	}
+	for _, tt := range tests {
+		res, out, err := categoryResolveHandler(context.Background(), nil, categoryResolveArgs{Query: tt.query})
+		if err != nil || res != nil {
+			t.Fatalf("%q: unexpected result %+v, %v", tt.query, res, err)
+		}
+		if out.Slug != tt.slug || len(out.Items) == 0 || out.Items[0].Match != tt.match {
+			t.Errorf("%q resolved to %q (%+v), want %q by %s", tt.query, out.Slug, out.Items, tt.slug, tt.match)
+		}
+		for i := 1; i < len(out.Items); i++ {
+			if matchScores[out.Items[i].Match] > matchScores[out.Items[i-1].Match] {
+				t.Errorf("%q candidates out of order: %+v", tt.query, out.Items)
+			}
+		}
+	}
+
+	_, out, _ := categoryResolveHandler(context.Background(), nil, categoryResolveArgs{Query: "ai", Limit: 3})
+	if len(out.Items) != 3 || out.Total <= 3 {
+		t.Errorf("limit 3: %d items of %d", len(out.Items), out.Total)
+	}
+
+	_, out, _ = categoryResolveHandler(context.Background(), nil, categoryResolveArgs{Query: "zzzz"})
+	if out.Slug != "" || out.Total != 0 || out.Items == nil {
+		t.Errorf("no match = %+v", out)
+	}
+	if res, _, _ := categoryResolveHandler(context.Background(), nil, categoryResolveArgs{Query: " "}); res == nil || !res.IsError {
+		t.Error("expected IsError for an empty query")
+	}
+}
+
+func TestCategoryFeedResources(t *testing.T) {
+	ctx := context.Background()
+	source := &pagedCategorySource{fakeSource: newFakeSource(), pagesCount: 2}
+	srv := startTestServer(source, Config{}, &ServerOptions{})
+	defer srv.Close()
+
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("list templates: %v", err)
+	}
+	var uris []string
+	for _, tmpl := range templates.ResourceTemplates {
+		uris = append(uris, tmpl.URITemplate)
+	}
+	for _, want := range []string{CategoryFeedTemplate, CategoryFeedPageTemplate} {
+		if !slices.Contains(uris, want) {
+			t.Errorf("templates %v missing %s", uris, want)
+		}
+	}
+
+	var listed []string
+	for res, err := range session.Resources(ctx, nil) {
+		if err != nil {
+			t.Fatalf("list resources: %v", err)
+		}
+		listed = append(listed, res.URI)
+	}
+	if len(listed) < len(types.AllCategories) || !slices.Contains(listed, "phtui://category/ai-agents") {
+		t.Errorf("listed %d resources, want one per category including ai-agents", len(listed))
+	}
+
+	read := func(uri string) categoryFeedOutput {
+		t.Helper()
+		result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+		if err != nil {
+			t.Fatalf("read %s: %v", uri, err)
+		}
+		var out categoryFeedOutput
+		if len(result.Contents) != 1 || json.Unmarshal([]byte(result.Contents[0].Text), &out) != nil {
+			t.Fatalf("read %s: unexpected contents %+v", uri, result.Contents)
+		}
+		return out
+	}
+	first := read("phtui://category/ai-agents")
+	if first.Slug != "ai-agents" || first.Name != "AI Agents" || first.Page != 1 || first.Pages != 2 || first.ItemsCount != 1 || first.Items[0].Slug != "page-1" {
+		t.Errorf("first page = %+v", first)
+	}
+	if first.NextURI != "phtui://category/ai-agents/page/2" {
+		t.Fatalf("next uri = %q", first.NextURI)
+	}
+	// A slug outside the built-in list resolves through the template.
+	// Feed pages are Product Hunt's pages.
+	second := read("phtui://category/newly-added/page/2")
+	if second.Page != 2 || len(second.Items) != 1 || second.Items[0].Slug != "page-2" || second.NextURI != "" {
+		t.Errorf("second page = %+v", second)
+	}
+
+	for _, uri := range []string{"phtui://category/ai-agents/page/3", "phtui://category/ai-agents/page/0"} {
+		if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri}); err == nil {
+			t.Errorf("read %s: expected an error", uri)
+		}
+	}
+
+	// A source without category pages serves the first page only.
+	unpaged := startTestServer(newFakeSource(), Config{}, &ServerOptions{})
+	defer unpaged.Close()
+	unpagedSession := connectTestClient(t, ctx, unpaged.URL+"/mcp")
+	defer unpagedSession.Close()
+	if _, err := unpagedSession.ReadResource(ctx, &mcp.ReadResourceParams{URI: "phtui://category/ai-agents"}); err != nil {
+		t.Errorf("unpaged first page: %v", err)
+	}
+	if _, err := unpagedSession.ReadResource(ctx, &mcp.ReadResourceParams{URI: "phtui://category/ai-agents/page/2"}); err == nil {
+		t.Error("unpaged page 2: expected an error")
+	}
+}
+
+type treeFakeSource struct {
+	*fakeSource
+	tree types.CategoryTree
+}
+
+func (f *treeFakeSource) GetCategoryTree(slug string) (types.CategoryTree, error) {
+	if f.failCat {
+		return types.CategoryTree{}, errors.New("upstream category error")
+	}
+	return f.tree, nil
+}
+
+// countsFakeSource lists n products for each category in counts and fails
+// for any other slug.
+type countsFakeSource struct {
+	*fakeSource
+	counts map[string]int
+}
+
+func (f *countsFakeSource) GetCategoryProducts(slug string) ([]types.Product, []types.CategoryLink, error) {
+	n, ok := f.counts[slug]
+	if !ok {
+		return nil, nil, errors.New("upstream category error")
+	}
+	products := make([]types.Product, n)
+	for i := range products {
+		products[i] = types.NewProduct(fmt.Sprintf("P%d", i), "", nil, 0, 0, fmt.Sprintf("p%d", i), "", i+1, 0, false)
+	}
+	return products, nil, nil
+}
+
+func TestToolCategoryCounts(t *testing.T) {
+	src := &countsFakeSource{fakeSource: newFakeSource(), counts: map[string]int{"ai-agents": 3, "llms": 0}}
+	res, out, err := categoryCountsHandler(context.Background(), nil, categoryCountsArgs{Slugs: []string{"ai-agents", " llms ", "broken", "ai-agents", ""}}, src)
+	if err != nil || res != nil {
+		t.Fatalf("category_counts: res=%v err=%v", res, err)
+	}
+	want := []categoryCount{
+		{Slug: "ai-agents", Count: 3},
+		{Slug: "llms", Count: 0},
+		{Slug: "broken", Error: "fetch category products failed"},
+	}
+	if !reflect.DeepEqual(out.Items, want) {
+		t.Fatalf("items = %+v, want %+v", out.Items, want)
+	}
+	if out.Total != 3 || out.Failed != 1 {
+		t.Fatalf("total=%d failed=%d, want 3 and 1", out.Total, out.Failed)
+	}
+
+	if res, _, _ := categoryCountsHandler(context.Background(), nil, categoryCountsArgs{}, src); res == nil || !res.IsError {
+		t.Fatal("no slugs must return IsError")
+	}
+	var many []string
+	for i := 0; i <= maxCategoryCountSlugs; i++ {
+		many = append(many, fmt.Sprintf("c%d", i))
+	}
+	if res, _, _ := categoryCountsHandler(context.Background(), nil, categoryCountsArgs{Slugs: many}, src); res == nil || !res.IsError {
+		t.Fatalf("%d slugs must return IsError", len(many))
+	}
+}
+
+func TestToolCategoryCountsAllPages(t *testing.T) {
+	src := &pagedCategorySource{fakeSource: newFakeSource(), pagesCount: 4}
+	_, out, err := categoryCountsHandler(context.Background(), nil, categoryCountsArgs{Slugs: []string{"ai-agents"}}, src)
+	if err != nil {
+		t.Fatalf("category_counts: %v", err)
+	}
+	if want := []categoryCount{{Slug: "ai-agents", Count: 4, Pages: 4}}; !reflect.DeepEqual(out.Items, want) {
+		t.Fatalf("items = %+v, want %+v", out.Items, want)
+	}
+	if !reflect.DeepEqual(src.pages, []int{1, 4}) {
+		t.Errorf("fetched pages %v, want the first and last", src.pages)
+	}
+}
+
+func TestToolCategoryTree(t *testing.T) {
+	parent := types.NewCategoryLink("Engineering & Development", "engineering-development")
+	source := &treeFakeSource{
+		fakeSource: newFakeSource(),
+		tree: types.NewCategoryTree(
+			types.NewCategoryLink("AI Coding Agents", "ai-coding-agents"),
+			&parent,
+			[]types.CategoryLink{types.NewCategoryLink("AI Code Review", "ai-code-review")},
+			true,
+		),
+	}
+
+	result, out, err := categoryTreeHandler(context.Background(), nil, categoryTreeArgs{Slug: "ai-coding-agents"}, source)
+	if err != nil || result != nil {
+		t.Fatalf("unexpected result: %v, %v", result, err)
+	}
+	if !out.Hierarchical || out.Parent == nil || out.Parent.Slug != "engineering-development" {
+		t.Fatalf("unexpected parent: %+v", out)
+	}
+	if len(out.Children) != 1 || out.Children[0].Slug != "ai-code-review" {
+		t.Fatalf("unexpected children: %+v", out.Children)
+	}
+
+	// Sources without GetCategoryTree fall back to a flat list.
+	_, flat, err := categoryTreeHandler(context.Background(), nil, categoryTreeArgs{Slug: "ai-agents"}, newFakeSource())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flat.Hierarchical || flat.Parent != nil || len(flat.Children) != 2 {
+		t.Fatalf("unexpected flat tree: %+v", flat)
+	}
+	if flat.Name != "AI Agents" {
+		t.Fatalf("unexpected name: %q", flat.Name)
+	}
+
+	source.failCat = true
+	r, _, _ := categoryTreeHandler(context.Background(), nil, categoryTreeArgs{Slug: "ai-coding-agents"}, source)
+	if r == nil || !r.IsError {
+		t.Fatalf("category tree failure must return IsError")
+	}
+	r, _, _ = categoryTreeHandler(context.Background(), nil, categoryTreeArgs{Slug: " "}, source)
+	if r == nil || !r.IsError {
+		t.Fatalf("empty slug must return IsError")
+	}
+}
+
 func TestSearchToolEmptyQuery(t *testing.T) {
-	result, _, err := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "  "}, newFakeSource())
+	result, _, err := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "  "}, newFakeSource(), false)
 	if err != nil {
 		t.Fatalf("unexpected handler error: %v", err)
 	}
@@ -149,6 +1049,21 @@ func TestToolUpstreamFailuresIsError(t *testing.T) {
 		t.Fatalf("detail failure must return IsError")
 	}
 
+	if text := r2.Content[0].(*mcp.TextContent).Text; text != "fetch product detail failed" {
+		t.Fatalf("detail failure message = %q", text)
+	}
+
+	// Hidden or offline products are reported as such, not as a failure.
+	f2.failDetail = false
+	f2.detailErr = fmt.Errorf("parse product detail: %w", types.ErrProductUnavailable)
+	r2, _, _ = productGetDetailHandler(context.Background(), nil, productGetDetailArgs{Slug: "demo-product"}, f2)
+	if r2 == nil || !r2.IsError {
+		t.Fatalf("unavailable product must return IsError")
+	}
+	if text := r2.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "no longer available") {
+		t.Fatalf("unavailable product message = %q", text)
+	}
+
 	f3 := newFakeSource()
 	f3.failCat = true
 	r3, _, _ := categoryGetProductsHandler(context.Background(), nil, categoryGetProductsArgs{Slug: "ai-agents"}, f3)
@@ -158,7 +1073,7 @@ func TestToolUpstreamFailuresIsError(t *testing.T) {
 
 	f4 := newFakeSource()
 	f4.failSearch = true
-	r4, _, _ := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", Page: 1}, f4)
+	r4, _, _ := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", Page: 1}, f4, false)
 	if r4 == nil || !r4.IsError {
 		t.Fatalf("search failure must return IsError")
 	}
@@ -175,8 +1090,10 @@ func TestSearchToolGating(t *testing.T) {
 		t.Fatalf("list tools (without search): %v", err)
 	}
 	sessionWithout.Close()
-	if containsTool(toolsWithout.Tools, "search_products") {
-		t.Fatalf("search_products should be absent when disabled")
+	for _, name := range []string{"search_products", "find_alternatives"} {
+		if containsTool(toolsWithout.Tools, name) {
+			t.Fatalf("%s should be absent when disabled", name)
+		}
 	}
 
 	srvWith := startTestServer(newFakeSource(), Config{}, &ServerOptions{EnableSearch: true})
@@ -187,8 +1104,10 @@ func TestSearchToolGating(t *testing.T) {
 		t.Fatalf("list tools (with search): %v", err)
 	}
 	sessionWith.Close()
-	if !containsTool(toolsWith.Tools, "search_products") {
-		t.Fatalf("search_products should be present when enabled")
+	for _, name := range []string{"search_products", "find_alternatives"} {
+		if !containsTool(toolsWith.Tools, name) {
+			t.Fatalf("%s should be present when enabled", name)
+		}
 	}
 }
 
@@ -221,6 +1140,76 @@ func TestAdminCacheClearGating(t *testing.T) {
 	}
 }
 
+type healthFakeSource struct {
+	*fakeSource
+	report types.HealthReport
+	checks int
+}
+
+func (f *healthFakeSource) HealthCheck(context.Context) types.HealthReport {
+	f.checks++
+	return f.report
+}
+
+func TestHealthCheckToolAndReadyz(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		report   types.HealthReport
+		wantCode int
+	}{
+		{"ok", types.NewHealthReport(types.HealthOK, 120*time.Millisecond, ""), http.StatusOK},
+		{"blocked", types.NewHealthReport(types.HealthBlocked, 80*time.Millisecond, "served a Cloudflare challenge page"), http.StatusServiceUnavailable},
+		{"error", types.NewHealthReport(types.HealthError, 3*time.Second, "unexpected status code: 502"), http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &healthFakeSource{fakeSource: newFakeSource(), report: tt.report}
+			want := healthCheckOutput{Status: string(tt.report.Status()), LatencyMS: tt.report.Latency().Milliseconds(), Detail: tt.report.Detail()}
+
+			result, out, err := healthCheckHandler(ctx, nil, src)
+			if err != nil || result != nil || out != want {
+				t.Fatalf("health_check = %+v, %v, %v; want %+v", out, result, err, want)
+			}
+
+			rec := httptest.NewRecorder()
+			ReadyHandler(src).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			var got healthCheckOutput
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("readyz body %q: %v", rec.Body.String(), err)
+			}
+			if rec.Code != tt.wantCode || got != want {
+				t.Fatalf("readyz = %d %+v, want %d %+v", rec.Code, got, tt.wantCode, want)
+			}
+		})
+	}
+
+	// Sources without a health check are ready, but the tool says it can't check.
+	rec := httptest.NewRecorder()
+	ReadyHandler(newFakeSource()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("readyz without a health check = %d", rec.Code)
+	}
+	if result, _, _ := healthCheckHandler(ctx, nil, newFakeSource()); result == nil || !result.IsError {
+		t.Fatal("expected IsError when the source has no health check")
+	}
+}
+
+func TestReadyzReusesRecentCheck(t *testing.T) {
+	src := &healthFakeSource{fakeSource: newFakeSource(), report: types.NewHealthReport(types.HealthOK, 0, "")}
+	handler := ReadyHandler(src)
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("readyz = %d", rec.Code)
+		}
+	}
+	if src.checks != 1 {
+		t.Fatalf("health checks = %d, want 1", src.checks)
+	}
+}
+
 func TestAdminCacheClearCallsSource(t *testing.T) {
 	ctx := context.Background()
 	src := newFakeSource()
@@ -230,15 +1219,75 @@ func TestAdminCacheClearCallsSource(t *testing.T) {
 	session := connectTestClient(t, ctx, srv.URL+"/mcp")
 	defer session.Close()
 
-	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "cache_clear", Arguments: map[string]any{}})
-	if err != nil {
-		t.Fatalf("call cache_clear: %v", err)
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "cache_clear", Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("call cache_clear: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("cache_clear returned tool error")
+	}
+	if !src.cleared {
+		t.Fatalf("expected source.ClearCache to be called")
+	}
+}
+
+func TestToolUsageStats(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	src.failDetail = true
+	srv := startTestServer(src, Config{}, &ServerOptions{EnableAdmin: true})
+	defer srv.Close()
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	call := func(name string, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil {
+			t.Fatalf("call %s: %v", name, err)
+		}
+		return result
+	}
+	stats := func(reset bool) map[string]any {
+		t.Helper()
+		out, ok := call("usage_stats", map[string]any{"reset": reset}).StructuredContent.(map[string]any)
+		if !ok {
+			t.Fatal("usage_stats returned no structured content")
+		}
+		return out
+	}
+	counts := func(out map[string]any) map[string][2]float64 {
+		got := make(map[string][2]float64)
+		for _, item := range out["items"].([]any) {
+			m := item.(map[string]any)
+			got[m["name"].(string)] = [2]float64{m["calls"].(float64), m["errors"].(float64)}
+		}
+		return got
+	}
+
+	call("leaderboard_get", map[string]any{"period": "daily"})
+	call("leaderboard_get", map[string]any{"period": "weekly"})
+	call("product_get_detail", map[string]any{"slug": "alpha"})
+
+	out := stats(false)
+	want := map[string][2]float64{"leaderboard_get": {2, 0}, "product_get_detail": {1, 1}}
+	if got := counts(out); !reflect.DeepEqual(got, want) {
+		t.Fatalf("counts = %v, want %v", got, want)
 	}
-	if result.IsError {
-		t.Fatalf("cache_clear returned tool error")
+	if out["calls"] != float64(3) || out["errors"] != float64(1) {
+		t.Fatalf("totals = %v calls, %v errors; want 3, 1", out["calls"], out["errors"])
 	}
-	if !src.cleared {
-		t.Fatalf("expected source.ClearCache to be called")
+
+	// The first read is counted; resetting returns the counts before zeroing.
+	call("leaderboard_get", map[string]any{"period": "daily"})
+	out = stats(true)
+	want = map[string][2]float64{"leaderboard_get": {3, 0}, "product_get_detail": {1, 1}, "usage_stats": {1, 0}}
+	if got := counts(out); !reflect.DeepEqual(got, want) || out["reset"] != true {
+		t.Fatalf("counts before reset = %v (reset=%v), want %v", got, out["reset"], want)
+	}
+	want = map[string][2]float64{"usage_stats": {1, 0}}
+	if got := counts(stats(false)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("counts after reset = %v, want %v", got, want)
 	}
 }
 
@@ -365,6 +1414,46 @@ func TestStatelessGetMethod(t *testing.T) {
 	}
 }
 
+func TestRepeatedInitializeReusesSession(t *testing.T) {
+	server := NewServer(newFakeSource(), "dev", &ServerOptions{})
+	srv := httptest.NewServer(NewHandler(server, StreamableOptions(Config{})))
+	defer srv.Close()
+
+	resp, err := postInitialize(srv.URL, nil)
+	if err != nil {
+		t.Fatalf("initialize request failed: %v", err)
+	}
+	resp.Body.Close()
+	sessionID := resp.Header.Get("Mcp-Session-Id")
+	if resp.StatusCode != http.StatusOK || sessionID == "" {
+		t.Fatalf("initialize: status %d, session %q", resp.StatusCode, sessionID)
+	}
+
+	// A client retrying initialize on its session gets the same session back.
+	for i := 0; i < 2; i++ {
+		resp, err = postInitialize(srv.URL, map[string]string{"Mcp-Session-Id": sessionID})
+		if err != nil {
+			t.Fatalf("repeated initialize failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"serverInfo"`) {
+			t.Fatalf("repeated initialize: status %d, body %s", resp.StatusCode, body)
+		}
+		if got := resp.Header.Get("Mcp-Session-Id"); got != sessionID {
+			t.Fatalf("repeated initialize answered on session %q, want %q", got, sessionID)
+		}
+	}
+
+	sessions := 0
+	for range server.Sessions() {
+		sessions++
+	}
+	if sessions != 1 {
+		t.Fatalf("server has %d sessions after repeated initialize, want 1", sessions)
+	}
+}
+
 func TestMCPListTools(t *testing.T) {
 	ctx := context.Background()
 	srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{})
@@ -377,13 +1466,116 @@ func TestMCPListTools(t *testing.T) {
 	if err != nil {
 		t.Fatalf("list tools: %v", err)
 	}
-	for _, name := range []string{"leaderboard_get", "product_get_detail", "category_list", "category_get_products"} {
+	for _, name := range []string{"leaderboard_get", "leaderboard_digest", "product_get_detail", "category_list", "category_get_products", "category_tree", "leaderboard_since", "upcoming_get", "product_get_rank_history", "product_get_reviews_summary", "product_related_launches", "product_get_topics", "category_counts", "leaderboard_range", "category_resolve"} {
 		if !containsTool(tools.Tools, name) {
 			t.Fatalf("missing tool %q", name)
 		}
 	}
 }
 
+func TestExportToolSchemas(t *testing.T) {
+	server := NewServer(newFakeSource(), "test", &ServerOptions{EnableSearch: true})
+	data, err := ExportToolSchemas(context.Background(), server)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var doc struct {
+		Tools []struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			InputSchema struct {
+				Properties map[string]any `json:"properties"`
+				Required   []string       `json:"required"`
+			} `json:"inputSchema"`
+			OutputSchema map[string]any `json:"outputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode export: %v\n%s", err, data)
+	}
+	fields := make(map[string][]string)
+	for _, tool := range doc.Tools {
+		if tool.Description == "" || tool.OutputSchema == nil {
+			t.Errorf("tool %q exported without a description or output schema", tool.Name)
+		}
+		for name := range tool.InputSchema.Properties {
+			fields[tool.Name] = append(fields[tool.Name], name)
+		}
+	}
+
+	want := map[string][]string{
+		"leaderboard_get":       {"period", "date", "limit", "sort"},
+		"product_get_detail":    {"slug"},
+		"category_list":         {"offset", "limit"},
+		"category_get_products": {"slug", "limit", "pricing", "min_reviews", "page"},
+		"search_products":       {"query", "page", "sort", "featured_only"},
+	}
+	for tool, args := range want {
+		got, ok := fields[tool]
+		if !ok {
+			t.Errorf("export is missing tool %q", tool)
+			continue
+		}
+		for _, arg := range args {
+			if !slices.Contains(got, arg) {
+				t.Errorf("%s is missing argument %q (has %v)", tool, arg, got)
+			}
+		}
+	}
+	if _, ok := fields["cache_clear"]; ok {
+		t.Error("admin tools exported while disabled")
+	}
+}
+
+func TestMCPMinimalProfile(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{Profile: ProfileMinimal, EnableSearch: true, EnableAdmin: true})
+	defer srv.Close()
+
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	if want := []string{"leaderboard_get", "product_get_detail"}; !slices.Equal(names, want) {
+		t.Fatalf("minimal profile tools = %v, want %v", names, want)
+	}
+	if templates, err := session.ListResourceTemplates(ctx, nil); err == nil && len(templates.ResourceTemplates) > 0 {
+		t.Fatalf("minimal profile exposes %d resource templates", len(templates.ResourceTemplates))
+	}
+
+	t.Setenv("PHTUI_MCP_PROFILE", " Minimal ")
+	if got := LoadConfig().Profile; got != ProfileMinimal {
+		t.Fatalf("profile = %q, want %q", got, ProfileMinimal)
+	}
+	t.Setenv("PHTUI_MCP_PROFILE", "tiny")
+	if got := LoadConfig().Profile; got != ProfileFull {
+		t.Fatalf("unknown profile = %q, want %q", got, ProfileFull)
+	}
+}
+
+func TestLoadConfigScraperTimeout(t *testing.T) {
+	t.Setenv("PHTUI_SCRAPER_TIMEOUT", "")
+	if got := LoadConfig().ScraperTimeout; got != 0 {
+		t.Fatalf("unset timeout = %v, want 0 (scraper default)", got)
+	}
+	t.Setenv("PHTUI_SCRAPER_TIMEOUT", "25s")
+	if got := LoadConfig().ScraperTimeout; got != 25*time.Second {
+		t.Fatalf("timeout = %v, want 25s", got)
+	}
+	t.Setenv("PHTUI_SCRAPER_TIMEOUT", "soon")
+	if got := LoadConfig().ScraperTimeout; got != 0 {
+		t.Fatalf("invalid timeout = %v, want 0", got)
+	}
+}
+
 func TestMCPCoreTools(t *testing.T) {
 	ctx := context.Background()
 	srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{})
@@ -397,6 +1589,8 @@ func TestMCPCoreTools(t *testing.T) {
 		{Name: "product_get_detail", Arguments: map[string]any{"slug": "demo-product"}},
 		{Name: "category_list", Arguments: map[string]any{"offset": 0, "limit": 5}},
 		{Name: "category_get_products", Arguments: map[string]any{"slug": "ai-agents"}},
+		{Name: "category_tree", Arguments: map[string]any{"slug": "ai-agents"}},
+		{Name: "leaderboard_since", Arguments: map[string]any{"since": "2026-02-18T00:00:00Z"}},
 	}
 
 	for _, tc := range cases {
@@ -410,6 +1604,96 @@ func TestMCPCoreTools(t *testing.T) {
 	}
 }
 
+func TestSearchToolSort(t *testing.T) {
+	source := newFakeSource()
+	source.search = []types.Product{
+		types.NewProduct("A", "", nil, 10, 50, "a", "", 1, 4.2, false),
+		types.NewProduct("B", "", nil, 30, 5, "b", "", 2, 4.9, false),
+		types.NewProduct("C", "", nil, 20, 90, "c", "", 3, 3.5, false),
+	}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"a", "b", "c"}},
+		{"relevance", []string{"a", "b", "c"}},
+		{"votes", []string{"b", "c", "a"}},
+		{"reviews", []string{"c", "a", "b"}},
+		{"RATING", []string{"b", "a", "c"}},
+	}
+	for _, tt := range tests {
+		result, out, err := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", Sort: tt.sort}, source, false)
+		if err != nil || result != nil {
+			t.Fatalf("sort %q: unexpected result: %v, %v", tt.sort, result, err)
+		}
+		if got := productSlugs(out.Items); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("sort %q: got %v want %v", tt.sort, got, tt.want)
+		}
+	}
+
+	result, _, _ := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", Sort: "newest"}, source, false)
+	if result == nil || !result.IsError {
+		t.Fatalf("expected IsError for invalid sort")
+	}
+}
+
+func TestSearchToolHideOffline(t *testing.T) {
+	source := newFakeSource()
+	source.search = []types.Product{
+		types.NewProduct("Live", "", nil, 0, 0, "live", "", 1, 0, false),
+		types.NewProduct("Gone", "", nil, 0, 0, "gone", "", 2, 0, true),
+	}
+
+	_, out, err := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo"}, source, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Items) != 2 || !out.Items[1].Offline {
+		t.Fatalf("expected offline flag in items, got %+v", out.Items)
+	}
+
+	_, out, err = searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", HideOffline: true}, source, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"live"}) {
+		t.Fatalf("got %v want [live]", got)
+	}
+}
+
+func TestSearchToolFeaturedOnly(t *testing.T) {
+	source := newFakeSource()
+	source.search = []types.Product{
+		types.NewProduct("Reviewed", "", nil, 50, 12, "reviewed", "", 1, 0, false),
+		types.NewProduct("Never Launched", "", nil, 0, 0, "never-launched", "", 2, 0, false),
+		types.NewProduct("Rated", "", nil, 20, 0, "rated", "", 3, 4.5, false),
+		types.NewProduct("Gone", "", nil, 300, 40, "gone", "", 4, 4.8, true),
+	}
+
+	_, out, err := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo"}, source, false)
+	if err != nil || len(out.Items) != 4 || out.FilteredOut != 0 {
+		t.Fatalf("unfiltered: %d items, %d filtered out, %v", len(out.Items), out.FilteredOut, err)
+	}
+
+	_, out, err = searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", FeaturedOnly: true}, source, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"reviewed", "rated"}) {
+		t.Fatalf("featured only: got %v want [reviewed rated]", got)
+	}
+	if out.FilteredOut != 2 || out.ItemsCount != 2 {
+		t.Fatalf("featured only: filtered out %d, items count %d", out.FilteredOut, out.ItemsCount)
+	}
+
+	// Sorting applies to what the filter kept.
+	_, out, _ = searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo", FeaturedOnly: true, Sort: "rating"}, source, false)
+	if got := productSlugs(out.Items); !reflect.DeepEqual(got, []string{"rated", "reviewed"}) {
+		t.Fatalf("featured only by rating: got %v", got)
+	}
+}
+
 func TestSearchToolSuccess(t *testing.T) {
 	ctx := context.Background()
 	srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{EnableSearch: true})
@@ -427,6 +1711,49 @@ func TestSearchToolSuccess(t *testing.T) {
 	}
 }
 
+// notedSearchSource searches like an API source that matches topics.
+type notedSearchSource struct{ *fakeSource }
+
+func (notedSearchSource) SearchNote() string { return "topic posts, not name matches" }
+
+func TestSearchNote(t *testing.T) {
+	_, out, _ := searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo"}, newFakeSource(), false)
+	if out.Note != "" {
+		t.Fatalf("scraper-like source note = %q, want none", out.Note)
+	}
+	_, out, _ = searchProductsHandler(context.Background(), nil, searchProductsArgs{Query: "demo"}, notedSearchSource{newFakeSource()}, false)
+	if out.Note != "topic posts, not name matches" {
+		t.Fatalf("note = %q, want the source's search note", out.Note)
+	}
+}
+
+func TestSearchToolEmptyQueryTrending(t *testing.T) {
+	for _, trending := range []bool{false, true} {
+		ctx := context.Background()
+		srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{EnableSearch: true, TrendingSearch: trending})
+		session := connectTestClient(t, ctx, srv.URL+"/mcp")
+
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "search_products", Arguments: map[string]any{"query": "  "}})
+		session.Close()
+		srv.Close()
+		if err != nil {
+			t.Fatalf("trending=%v: search tool call failed: %v", trending, err)
+		}
+		if !trending {
+			if !result.IsError || !strings.Contains(toolResultText(result), "query is required") {
+				t.Fatalf("strict: expected query is required error, got %+v", result)
+			}
+			continue
+		}
+		if result.IsError {
+			t.Fatalf("trending: empty query returned error %q", toolResultText(result))
+		}
+		if !strings.Contains(toolResultText(result), "demo-product") {
+			t.Fatalf("trending: result missing products: %s", toolResultText(result))
+		}
+	}
+}
+
 func startTestServer(source types.ProductSource, cfg Config, opts *ServerOptions) *httptest.Server {
 	if cfg.RPS <= 0 {
 		cfg.RPS = 100
@@ -492,3 +1819,381 @@ func postInitialize(url string, headers map[string]string) (*http.Response, erro
 	}
 	return http.DefaultClient.Do(req)
 }
+
+func TestStructuredOnlyMode(t *testing.T) {
+	ctx := context.Background()
+	for _, structuredOnly := range []bool{false, true} {
+		srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{StructuredOnly: structuredOnly})
+		session := connectTestClient(t, ctx, srv.URL+"/mcp")
+
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "leaderboard_get", Arguments: map[string]any{"period": "daily"}})
+		if err != nil {
+			t.Fatalf("call leaderboard_get: %v", err)
+		}
+		if result.IsError || result.StructuredContent == nil {
+			t.Fatalf("structuredOnly=%v: missing structured output: %+v", structuredOnly, result)
+		}
+		if structuredOnly && len(result.Content) != 0 {
+			t.Fatalf("structured-only result still has %d content blocks", len(result.Content))
+		}
+		if !structuredOnly && len(result.Content) == 0 {
+			t.Fatalf("default result has no text content")
+		}
+
+		errResult, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "product_get_detail", Arguments: map[string]any{"slug": " "}})
+		if err != nil {
+			t.Fatalf("call product_get_detail: %v", err)
+		}
+		if !errResult.IsError || len(errResult.Content) == 0 {
+			t.Fatalf("expected text error result: %+v", errResult)
+		}
+		if structuredOnly {
+			fields, ok := errResult.StructuredContent.(map[string]any)
+			if !ok || fields["error"] != "slug is required" {
+				t.Fatalf("unexpected structured error: %#v", errResult.StructuredContent)
+			}
+		}
+
+		session.Close()
+		srv.Close()
+	}
+}
+
+type slowFakeSource struct {
+	*fakeSource
+	release chan struct{}
+}
+
+func (s *slowFakeSource) GetLeaderboard(period types.Period, date time.Time) ([]types.Product, error) {
+	<-s.release
+	return s.fakeSource.GetLeaderboard(period, date)
+}
+
+// contextFakeSource blocks leaderboard fetches until their context is done
+// and reports the context's error on canceled.
+type contextFakeSource struct {
+	*fakeSource
+	canceled chan error
+}
+
+func (s *contextFakeSource) GetLeaderboardContext(ctx context.Context, _ types.Period, _ time.Time) ([]types.Product, error) {
+	<-ctx.Done()
+	s.canceled <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func (s *contextFakeSource) GetProductDetailContext(_ context.Context, slug string) (types.ProductDetail, error) {
+	return s.GetProductDetail(slug)
+}
+
+func (s *contextFakeSource) GetCategoryProductsContext(_ context.Context, slug string) ([]types.Product, []types.CategoryLink, error) {
+	return s.GetCategoryProducts(slug)
+}
+
+func TestToolTimeoutCancelsFetch(t *testing.T) {
+	ctx := context.Background()
+	source := &contextFakeSource{fakeSource: newFakeSource(), canceled: make(chan error, 1)}
+	srv := startTestServer(source, Config{}, &ServerOptions{ToolTimeout: 50 * time.Millisecond})
+	defer srv.Close()
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "leaderboard_get", Arguments: map[string]any{"period": "daily"}})
+	if err != nil || !result.IsError {
+		t.Fatalf("call leaderboard_get = %+v, %v; want a timeout error", result, err)
+	}
+	select {
+	case err := <-source.canceled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("fetch context ended with %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the timed-out call's fetch was never canceled")
+	}
+}
+
+// contextUpcomingSource blocks upcoming fetches until their context is done.
+type contextUpcomingSource struct {
+	*fakeSource
+	canceled chan error
+}
+
+func (s *contextUpcomingSource) GetUpcomingProducts() ([]types.Product, error) {
+	return s.GetUpcomingProductsContext(context.Background())
+}
+
+func (s *contextUpcomingSource) GetUpcomingProductsContext(ctx context.Context) ([]types.Product, error) {
+	<-ctx.Done()
+	s.canceled <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestToolTimeoutCancelsUpcomingFetch(t *testing.T) {
+	ctx := context.Background()
+	source := &contextUpcomingSource{fakeSource: newFakeSource(), canceled: make(chan error, 1)}
+	srv := startTestServer(source, Config{}, &ServerOptions{ToolTimeout: 50 * time.Millisecond})
+	defer srv.Close()
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "upcoming_get", Arguments: map[string]any{}})
+	if err != nil || !result.IsError {
+		t.Fatalf("call upcoming_get = %+v, %v; want a timeout error", result, err)
+	}
+	select {
+	case err := <-source.canceled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("fetch context ended with %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the timed-out call's fetch was never canceled")
+	}
+}
+
+func TestToolTimeout(t *testing.T) {
+	ctx := context.Background()
+	source := &slowFakeSource{fakeSource: newFakeSource(), release: make(chan struct{})}
+	defer close(source.release)
+	srv := startTestServer(source, Config{}, &ServerOptions{ToolTimeout: 50 * time.Millisecond})
+	defer srv.Close()
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	start := time.Now()
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "leaderboard_get", Arguments: map[string]any{"period": "daily"}})
+	if err != nil {
+		t.Fatalf("call leaderboard_get: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("call took %s, deadline not enforced", elapsed)
+	}
+	if !result.IsError {
+		t.Fatalf("expected timeout error result, got %+v", result)
+	}
+	if text := toolResultText(result); !strings.Contains(text, ErrToolTimeout.Error()) {
+		t.Fatalf("unexpected error text %q", text)
+	}
+
+	// Tools that finish in time are unaffected.
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "category_list"})
+	if err != nil || result.IsError {
+		t.Fatalf("category_list under deadline: %+v, %v", result, err)
+	}
+}
+
+type panicFakeSource struct {
+	*fakeSource
+}
+
+func (s *panicFakeSource) GetLeaderboard(types.Period, time.Time) ([]types.Product, error) {
+	panic("parser bug")
+}
+
+func TestToolPanicRecovered(t *testing.T) {
+	ctx := context.Background()
+	source := &panicFakeSource{fakeSource: newFakeSource()}
+	srv := startTestServer(source, Config{}, &ServerOptions{ToolTimeout: time.Second})
+	defer srv.Close()
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "leaderboard_get", Arguments: map[string]any{"period": "daily"}})
+	if err != nil {
+		t.Fatalf("call leaderboard_get: %v", err)
+	}
+	if !result.IsError || !strings.Contains(toolResultText(result), "internal error") {
+		t.Fatalf("expected internal error result, got %+v", result)
+	}
+
+	// The server stays up, and ordinary errors still come through as before.
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "category_list"})
+	if err != nil || result.IsError {
+		t.Fatalf("category_list after panic: %+v, %v", result, err)
+	}
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "leaderboard_get", Arguments: map[string]any{"period": "hourly"}})
+	if err != nil || !result.IsError || strings.Contains(toolResultText(result), "internal error") {
+		t.Fatalf("invalid period: %+v, %v", result, err)
+	}
+}
+
+func TestRecoverHTTPHandler(t *testing.T) {
+	h := recoverHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Fatalf("recovered %v, want http.ErrAbortHandler re-panicked", r)
+		}
+	}()
+	recoverHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp", nil))
+}
+
+func TestToolProgressNotifications(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		ctx := context.Background()
+		srv := startTestServer(newPricingFakeSource(), Config{}, &ServerOptions{Progress: enabled})
+
+		var mu sync.Mutex
+		var got []*mcp.ProgressNotificationParams
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+			ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, req.Params)
+			},
+		})
+		session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: srv.URL + "/mcp"}, nil)
+		if err != nil {
+			t.Fatalf("connect client: %v", err)
+		}
+
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Meta:      mcp.Meta{"progressToken": "tok"},
+			Name:      "category_get_products",
+			Arguments: map[string]any{"slug": "ai-agents", "pricing": "free"},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("progress=%v: call category_get_products: %+v, %v", enabled, result, err)
+		}
+		session.Close()
+		srv.Close()
+
+		mu.Lock()
+		if !enabled {
+			if len(got) != 0 {
+				t.Errorf("progress disabled: got %d notifications", len(got))
+			}
+			mu.Unlock()
+			continue
+		}
+		if len(got) != 3 {
+			t.Fatalf("got %d progress notifications, want 3 (one per lookup)", len(got))
+		}
+		for i, n := range got {
+			if n.ProgressToken != "tok" || n.Total != 3 || n.Progress != float64(i+1) {
+				t.Errorf("notification %d = %+v", i, n)
+			}
+		}
+		var messages []string
+		for _, n := range got {
+			messages = append(messages, n.Message)
+		}
+		if joined := strings.Join(messages, ","); !strings.Contains(joined, "free-app: free") || !strings.Contains(joined, "mystery-app: unknown pricing") {
+			t.Errorf("progress messages = %v", messages)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestRelativeDate(t *testing.T) {
+	loc := time.FixedZone("PT", -8*60*60)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, loc) }
+	today := day(2026, 3, 31)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"today", today},
+		{"Yesterday", day(2026, 3, 30)},
+		{"last-week", day(2026, 3, 24)},
+		{"last week", day(2026, 3, 24)},
+		{"last_month", day(2026, 2, 28)},
+		{"this-week", today},
+		{"this-month", today},
+	}
+	for _, tt := range tests {
+		got, ok := relativeDate(tt.expr, today)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("relativeDate(%q) = %v, %v; want %v", tt.expr, got, ok, tt.want)
+		}
+	}
+	if got, _ := relativeDate("last-month", day(2026, 1, 15)); !got.Equal(day(2025, 12, 15)) {
+		t.Errorf("last-month across year = %v", got)
+	}
+	if _, ok := relativeDate("2026-02-18", today); ok {
+		t.Error("absolute date treated as relative")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	today := types.Today()
+	tests := []struct {
+		raw  string
+		want time.Time
+	}{
+		{"", today},
+		{"today", today},
+		{"yesterday", today.AddDate(0, 0, -1)},
+		{"last-week", today.AddDate(0, 0, -7)},
+		{"2026-02-18", time.Date(2026, 2, 18, 0, 0, 0, 0, types.Timezone())},
+		{"2026-02-18T20:00:00Z", time.Date(2026, 2, 18, 0, 0, 0, 0, types.Timezone())},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.raw)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %v, %v; want %v", tt.raw, got, err, tt.want)
+		}
+	}
+	if got, err := parseDate("last-month"); err != nil || got.Month() == today.Month() {
+		t.Errorf("parseDate(last-month) = %v, %v", got, err)
+	}
+	if _, err := parseDate("next-week"); err == nil {
+		t.Error("expected error for unknown expression")
+	}
+}
+
+func TestMaxItemsCap(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeSource()
+	source.leaderboard = nil
+	for i := 1; i <= 5; i++ {
+		source.leaderboard = append(source.leaderboard, types.NewProduct(fmt.Sprintf("P%d", i), "", nil, 0, 0, fmt.Sprintf("p%d", i), "", i, 0, false))
+	}
+	srv := startTestServer(source, Config{}, &ServerOptions{MaxItems: 2})
+	defer srv.Close()
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	call := func(name string, args map[string]any) map[string]any {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil || result.IsError {
+			t.Fatalf("call %s: %+v, %v", name, result, err)
+		}
+		out, ok := result.StructuredContent.(map[string]any)
+		if !ok {
+			t.Fatalf("%s: unexpected structured content %#v", name, result.StructuredContent)
+		}
+		return out
+	}
+
+	// The tool's own limit (10) is higher than the cap.
+	out := call("leaderboard_get", map[string]any{"period": "daily", "limit": 10})
+	if items := out["items"].([]any); len(items) != 2 || out["truncated"] != true {
+		t.Fatalf("leaderboard_get: %d items, truncated=%v", len(items), out["truncated"])
+	}
+
+	out = call("category_list", map[string]any{"limit": 50})
+	if items := out["items"].([]any); len(items) != 2 || out["truncated"] != true {
+		t.Fatalf("category_list: %d items, truncated=%v", len(items), out["truncated"])
+	}
+
+	out = call("leaderboard_digest", map[string]any{"period": "daily"})
+	if out["total"] != float64(2) || out["truncated"] != true {
+		t.Fatalf("leaderboard_digest: total=%v, truncated=%v", out["total"], out["truncated"])
+	}
+
+	// Within the cap nothing is reported as truncated.
+	out = call("leaderboard_get", map[string]any{"period": "daily", "limit": 1})
+	if items := out["items"].([]any); len(items) != 1 || out["truncated"] != nil {
+		t.Fatalf("leaderboard_get under cap: %d items, truncated=%v", len(items), out["truncated"])
+	}
+}
@@ -0,0 +1,118 @@
+package mcpsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrShutdownGraceExpired is returned by RunGraceful when tool calls are still
+// running once the shutdown grace period is up.
+var ErrShutdownGraceExpired = errors.New("shutdown grace period expired")
+
+// RunGraceful runs server over transport until the client disconnects or ctx
+// is cancelled. On cancellation it stops accepting tool calls and gives the
+// ones in flight up to grace to finish before closing the session, so a
+// signal doesn't cut off a call that is about to answer. It returns nil after
+// a clean shutdown and an error wrapping ErrShutdownGraceExpired if calls
+// were abandoned.
+func RunGraceful(ctx context.Context, server *mcp.Server, transport mcp.Transport, grace time.Duration) error {
+	calls := &inflightCalls{}
+	server.AddReceivingMiddleware(calls.middleware)
+
+	// The session outlives ctx so in-flight calls keep their contexts
+	// during the grace period.
+	runCtx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	defer stop()
+	done := make(chan error, 1)
+	go func() { done <- server.Run(runCtx, transport) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	if left := calls.drain(grace); left > 0 {
+		// A stuck call would also hold up closing the session, so don't
+		// wait for it.
+		return fmt.Errorf("%w: %d tool call(s) still running after %s", ErrShutdownGraceExpired, left, grace)
+	}
+	stop()
+	if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// inflightCalls counts running tools/call requests and, once draining,
+// refuses new ones.
+type inflightCalls struct {
+	mu       sync.Mutex
+	active   int
+	draining bool
+	// idle is closed when the last call finishes while draining.
+	idle chan struct{}
+}
+
+func (c *inflightCalls) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		if !c.begin() {
+			return errorToolResult("server is shutting down"), nil
+		}
+		defer c.end()
+		return next(ctx, method, req)
+	}
+}
+
+func (c *inflightCalls) begin() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		return false
+	}
+	c.active++
+	return true
+}
+
+func (c *inflightCalls) end() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	if c.draining && c.active == 0 && c.idle != nil {
+		close(c.idle)
+		c.idle = nil
+	}
+}
+
+// drain stops new calls and waits up to grace for running ones to finish,
+// returning how many are still running.
+func (c *inflightCalls) drain(grace time.Duration) int {
+	c.mu.Lock()
+	c.draining = true
+	if c.active == 0 {
+		c.mu.Unlock()
+		return 0
+	}
+	idle := make(chan struct{})
+	c.idle = idle
+	c.mu.Unlock()
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-idle:
+	case <-timer.C:
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active
+}
@@ -0,0 +1,105 @@
+package mcpsrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// blockingServer returns a server with a "wait" tool that signals started and
+// then blocks until release is closed.
+func blockingServer(started chan<- struct{}, release <-chan struct{}) *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "dev"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "wait"}, func(context.Context, *mcp.CallToolRequest, struct{}) (*mcp.CallToolResult, struct{}, error) {
+		started <- struct{}{}
+		<-release
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, struct{}{}, nil
+	})
+	return server
+}
+
+func TestRunGracefulDrainsInFlightCall(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		grace   time.Duration
+		release bool // finish the call during the grace period
+		wantErr error
+	}{
+		{"call finishes within the grace period", time.Minute, true, nil},
+		{"grace period expires", 10 * time.Millisecond, false, ErrShutdownGraceExpired},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan struct{}, 1)
+			release := make(chan struct{})
+			server := blockingServer(started, release)
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			runErr := make(chan error, 1)
+			go func() { runErr <- RunGraceful(ctx, server, serverTransport, tt.grace) }()
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+			session, err := client.Connect(context.Background(), clientTransport, nil)
+			if err != nil {
+				t.Fatalf("client connect: %v", err)
+			}
+			defer session.Close()
+
+			type callResult struct {
+				res *mcp.CallToolResult
+				err error
+			}
+			call := make(chan callResult, 1)
+			go func() {
+				res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "wait"})
+				call <- callResult{res, err}
+			}()
+			<-started
+
+			// Cancel while the call is in flight: the server must wait for it.
+			cancel()
+			if !tt.release {
+				err := <-runErr
+				close(release) // let the abandoned call end so the client can close
+				<-call
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RunGraceful = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			select {
+			case err := <-runErr:
+				t.Fatalf("RunGraceful returned %v before the in-flight call finished", err)
+			default:
+			}
+			close(release)
+
+			got := <-call
+			if got.err != nil || got.res == nil || got.res.IsError {
+				t.Fatalf("in-flight call = %+v, %v; want it to complete", got.res, got.err)
+			}
+			if err := <-runErr; err != nil {
+				t.Fatalf("RunGraceful = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestInflightCallsRefuseWhileDraining(t *testing.T) {
+	calls := &inflightCalls{}
+	if left := calls.drain(time.Second); left != 0 {
+		t.Fatalf("drain with no calls = %d, want 0", left)
+	}
+	handler := calls.middleware(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		t.Fatal("a call reached the tool while draining")
+		return nil, nil
+	})
+	res, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{})
+	if result, ok := res.(*mcp.CallToolResult); err != nil || !ok || !result.IsError {
+		t.Fatalf("call while draining = %v, %v; want an error result", res, err)
+	}
+}
@@ -0,0 +1,46 @@
+package mcpsrv
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolErrorOutput is the structured content of a failed tool call in
+// structured-only mode.
+type toolErrorOutput struct {
+	Error string `json:"error"`
+}
+
+// structuredOnlyMiddleware rewrites tools/call results for clients that only
+// read structured content: successful results lose their text blocks, and
+// error results carry their message as structured content too.
+func structuredOnlyMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		res, err := next(ctx, method, req)
+		if err != nil || method != "tools/call" {
+			return res, err
+		}
+		result, ok := res.(*mcp.CallToolResult)
+		if !ok || result == nil {
+			return res, err
+		}
+		if result.IsError {
+			result.StructuredContent = toolErrorOutput{Error: toolResultText(result)}
+			return result, nil
+		}
+		result.Content = []mcp.Content{}
+		return result, nil
+	}
+}
+
+func toolResultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,50 @@
+package mcpsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrToolTimeout is reported when a tool call exceeds its deadline.
+var ErrToolTimeout = errors.New("tool call timed out")
+
+// toolTimeoutMiddleware bounds each tools/call to timeout. Handlers run with
+// a context carrying the deadline, which context-aware sources use to cancel
+// their requests. Sources without context variants would run on, so the call
+// is also abandoned once the deadline passes and an error result wrapping
+// ErrToolTimeout is returned in its place.
+func toolTimeoutMiddleware(timeout time.Duration) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			type callResult struct {
+				res mcp.Result
+				err error
+			}
+			done := make(chan callResult, 1)
+			go func() {
+				res, err := next(ctx, method, req)
+				done <- callResult{res, err}
+			}()
+
+			select {
+			case r := <-done:
+				return r.res, r.err
+			case <-ctx.Done():
+				if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return nil, ctx.Err()
+				}
+				return errorToolResult(fmt.Errorf("%w after %s", ErrToolTimeout, timeout).Error()), nil
+			}
+		}
+	}
+}
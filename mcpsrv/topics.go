@@ -0,0 +1,51 @@
+package mcpsrv
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/types"
+)
+
+type productGetTopicsArgs struct {
+	Slug string `json:"slug" jsonschema:"Product slug"`
+}
+
+type productTopic struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	Path string `json:"path"`
+}
+
+type productGetTopicsOutput struct {
+	Slug      string         `json:"slug"`
+	Total     int            `json:"total"`
+	Items     []productTopic `json:"items"`
+	Truncated bool           `json:"truncated,omitempty"`
+}
+
+// productGetTopicsHandler returns the topics linked from a product's page with
+// their slugs, which category_get_products accepts, and /topics/{slug} paths.
+// Products whose page links no topics return no items rather than an error.
+func productGetTopicsHandler(ctx context.Context, _ *mcp.CallToolRequest, args productGetTopicsArgs, source types.ProductSource) (*mcp.CallToolResult, productGetTopicsOutput, error) {
+	slug := strings.TrimSpace(args.Slug)
+	if slug == "" {
+		return errorToolResult("slug is required"), productGetTopicsOutput{}, nil
+	}
+
+	detail, err := types.FetchProductDetail(ctx, source, slug)
+	if err != nil {
+		return errorToolResult(detailErrorMessage(err)), productGetTopicsOutput{}, nil
+	}
+
+	items := make([]productTopic, 0, len(detail.Topics()))
+	for _, topic := range detail.Topics() {
+		items = append(items, productTopic{Name: topic.Name(), Slug: topic.Slug(), Path: "/topics/" + topic.Slug()})
+	}
+	return nil, productGetTopicsOutput{
+		Slug:  slug,
+		Total: len(items),
+		Items: items,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package mcpsrv
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type usageStatsArgs struct {
+	Reset bool `json:"reset,omitempty" jsonschema:"zero the counters after reading them"`
+}
+
+type toolUsageItem struct {
+	Name   string `json:"name"`
+	Calls  int    `json:"calls"`
+	Errors int    `json:"errors"`
+}
+
+type usageStatsOutput struct {
+	// Since is when counting started: server start or the last reset.
+	Since  string          `json:"since"`
+	Calls  int             `json:"calls"`
+	Errors int             `json:"errors"`
+	Items  []toolUsageItem `json:"items"`
+	Reset  bool            `json:"reset"`
+}
+
+// toolUsage counts tools/call invocations and error results per tool, in
+// memory, for the usage_stats tool.
+type toolUsage struct {
+	mu     sync.Mutex
+	since  time.Time
+	counts map[string]*toolUsageItem
+}
+
+func newToolUsage() *toolUsage {
+	return &toolUsage{since: time.Now(), counts: make(map[string]*toolUsageItem)}
+}
+
+// middleware counts every tools/call that reaches a tool. Calls rejected
+// before producing a result, such as ones naming an unknown tool, aren't
+// tool usage and are left out.
+func (u *toolUsage) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		res, err := next(ctx, method, req)
+		call, ok := req.(*mcp.CallToolRequest)
+		if err != nil || !ok || call.Params == nil {
+			return res, err
+		}
+		result, _ := res.(*mcp.CallToolResult)
+		u.record(call.Params.Name, result != nil && result.IsError)
+		return res, err
+	}
+}
+
+func (u *toolUsage) record(name string, failed bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	item, ok := u.counts[name]
+	if !ok {
+		item = &toolUsageItem{Name: name}
+		u.counts[name] = item
+	}
+	item.Calls++
+	if failed {
+		item.Errors++
+	}
+}
+
+// snapshot returns the counters sorted by tool name and, when reset is set,
+// zeroes them.
+func (u *toolUsage) snapshot(reset bool) usageStatsOutput {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	out := usageStatsOutput{
+		Since: u.since.UTC().Format(time.RFC3339),
+		Items: make([]toolUsageItem, 0, len(u.counts)),
+		Reset: reset,
+	}
+	for _, item := range u.counts {
+		out.Items = append(out.Items, *item)
+		out.Calls += item.Calls
+		out.Errors += item.Errors
+	}
+	sort.Slice(out.Items, func(i, j int) bool { return out.Items[i].Name < out.Items[j].Name })
+	if reset {
+		u.since = time.Now()
+		u.counts = make(map[string]*toolUsageItem)
+	}
+	return out
+}
+
+func usageStatsHandler(_ context.Context, _ *mcp.CallToolRequest, args usageStatsArgs, usage *toolUsage) (*mcp.CallToolResult, usageStatsOutput, error) {
+	return nil, usage.snapshot(args.Reset), nil
+}
@@ -0,0 +1,123 @@
+package mcpsrv
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+// TopProductURI is the subscribable resource describing today's #1 daily product.
+// Subscribers receive notifications/resources/updated when rank 1 changes.
+const TopProductURI = "phtui://leaderboard/daily/top"
+
+type topProductOutput struct {
+	Date string       `json:"date"`
+	Item *dto.Product `json:"item"`
+}
+
+func addTopProductResource(server *mcp.Server, source types.ProductSource) {
+	server.AddResource(&mcp.Resource{
+		URI:         TopProductURI,
+		Name:        "daily_top_product",
+		Description: "Today's #1 product on the daily leaderboard. Subscribe to be notified when it changes.",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		date := types.Today()
+		products, err := types.FetchLeaderboard(ctx, source, types.Daily, date)
+		if err != nil {
+			return nil, err
+		}
+		out := topProductOutput{Date: date.Format(time.DateOnly)}
+		if top, ok := topProduct(products); ok {
+			item := dto.FromProduct(top)
+			out.Item = &item
+		}
+		b, err := json.Marshal(out)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+			{URI: req.Params.URI, MIMEType: "application/json", Text: string(b)},
+		}}, nil
+	})
+}
+
+func topProduct(products []types.Product) (types.Product, bool) {
+	for _, p := range products {
+		if p.Rank() == 1 {
+			return p, true
+		}
+	}
+	if len(products) > 0 {
+		return products[0], true
+	}
+	return types.Product{}, false
+}
+
+type topProductWatcher struct {
+	server *mcp.Server
+	source types.ProductSource
+
+	mu      sync.Mutex
+	lastTop string
+}
+
+func newTopProductWatcher(server *mcp.Server, source types.ProductSource) *topProductWatcher {
+	return &topProductWatcher{server: server, source: source}
+}
+
+// poll fetches today's daily leaderboard, skipping the source's cached copy,
+// and notifies subscribers when the rank-1 slug differs from the previous
+// poll. The first poll only records the current leader. It reports whether
+// a notification was sent.
+func (w *topProductWatcher) poll(ctx context.Context) (bool, error) {
+	products, err := types.RefreshLeaderboard(ctx, w.source, types.Daily, types.Today())
+	if err != nil {
+		return false, err
+	}
+	top, ok := topProduct(products)
+	if !ok {
+		return false, nil
+	}
+
+	w.mu.Lock()
+	prev := w.lastTop
+	w.lastTop = top.Slug()
+	w.mu.Unlock()
+
+	if prev == "" || prev == top.Slug() {
+		return false, nil
+	}
+	return true, w.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: TopProductURI})
+}
+
+// WatchTopProduct polls the daily leaderboard every interval until ctx is
+// done, notifying TopProductURI subscribers when the #1 product changes.
+// The server must be created with ServerOptions.WatchTopProduct enabled.
+func WatchTopProduct(ctx context.Context, server *mcp.Server, source types.ProductSource, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	w := newTopProductWatcher(server, source)
+	if _, err := w.poll(ctx); err != nil {
+		log.Printf("top product watch: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := w.poll(ctx); err != nil {
+				log.Printf("top product watch: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package mcpsrv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/qyinm/phtui/types"
+)
+
+func TestTopProductWatcherNotifiesOnRankOneChange(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource()
+	server := NewServer(src, "test", &ServerOptions{WatchTopProduct: true})
+
+	updated := make(chan string, 4)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: TopProductURI}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	w := newTopProductWatcher(server, src)
+	if notified, err := w.poll(ctx); err != nil || notified {
+		t.Fatalf("first poll: notified=%v err=%v, want priming only", notified, err)
+	}
+	if notified, err := w.poll(ctx); err != nil || notified {
+		t.Fatalf("unchanged poll: notified=%v err=%v, want no notification", notified, err)
+	}
+
+	src.leaderboard = []types.Product{
+		types.NewProduct("New Leader", "Overtook", nil, 500, 10, "new-leader", "", 1, 0, false),
+		src.leaderboard[0],
+	}
+	notified, err := w.poll(ctx)
+	if err != nil || !notified {
+		t.Fatalf("changed poll: notified=%v err=%v, want notification", notified, err)
+	}
+
+	select {
+	case uri := <-updated:
+		if uri != TopProductURI {
+			t.Fatalf("unexpected updated uri %q", uri)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for resource updated notification")
+	}
+}
+
+// cachingLeaderboardSource serves the leaderboard it last fetched until it
+// is invalidated, like a source with a long cache TTL.
+type cachingLeaderboardSource struct {
+	*fakeSource
+	cached []types.Product
+}
+
+func (s *cachingLeaderboardSource) GetLeaderboard(types.Period, time.Time) ([]types.Product, error) {
+	if s.cached == nil {
+		s.cached = s.leaderboard
+	}
+	return s.cached, nil
+}
+
+func (s *cachingLeaderboardSource) InvalidateLeaderboard(types.Period, time.Time) {
+	s.cached = nil
+}
+
+func TestTopProductWatcherBypassesCache(t *testing.T) {
+	ctx := context.Background()
+	src := &cachingLeaderboardSource{fakeSource: newFakeSource()}
+	server := NewServer(src, "test", &ServerOptions{WatchTopProduct: true})
+	w := newTopProductWatcher(server, src)
+	if _, err := w.poll(ctx); err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+
+	src.leaderboard = []types.Product{
+		types.NewProduct("New Leader", "Overtook", nil, 500, 10, "new-leader", "", 1, 0, false),
+		src.leaderboard[0],
+	}
+	notified, err := w.poll(ctx)
+	if err != nil || !notified {
+		t.Fatalf("changed poll: notified=%v err=%v, want a notification past the cached leaderboard", notified, err)
+	}
+}
+
+func TestTopProductResourceGating(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(newFakeSource(), Config{}, &ServerOptions{WatchTopProduct: true})
+	defer srv.Close()
+
+	session := connectTestClient(t, ctx, srv.URL+"/mcp")
+	defer session.Close()
+
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: TopProductURI})
+	if err != nil {
+		t.Fatalf("read resource: %v", err)
+	}
+	if len(result.Contents) != 1 || result.Contents[0].Text == "" {
+		t.Fatalf("unexpected resource contents: %+v", result.Contents)
+	}
+}
@@ -0,0 +1,196 @@
+package scraper
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting Product Hunt while the
+// circuit breaker is open after repeated block responses.
+var ErrCircuitOpen = errors.New("circuit open: Product Hunt is blocking requests; retry later")
+
+const (
+	defaultBreakerThreshold   = 5
+	defaultBreakerCooldown    = 30 * time.Second
+	defaultBreakerMaxCooldown = 10 * time.Minute
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects requests with ErrCircuitOpen until the cool-down ends.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe request through to test recovery.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker stops the scraper from hammering Product Hunt while it is
+// blocking requests (Cloudflare challenges, 403 and 429 responses). After
+// threshold consecutive blocks it opens for a cool-down, then half-opens to
+// let one probe through: a clean probe closes it, a blocked one reopens it
+// with the cool-down doubled, up to maxCooldown.
+//
+// A nil *CircuitBreaker allows every request.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	threshold   int
+	cooldown    time.Duration
+	maxCooldown time.Duration
+	now         func() time.Time
+
+	state     BreakerState
+	blocks    int
+	openFor   time.Duration
+	openUntil time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker creates a closed breaker. Non-positive values fall back
+// to 5 blocks, a 30s cool-down and a 10m maximum cool-down.
+func NewCircuitBreaker(threshold int, cooldown, maxCooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = defaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	if maxCooldown <= 0 {
+		maxCooldown = defaultBreakerMaxCooldown
+	}
+	if maxCooldown < cooldown {
+		maxCooldown = cooldown
+	}
+	return &CircuitBreaker{
+		threshold:   threshold,
+		cooldown:    cooldown,
+		maxCooldown: maxCooldown,
+		now:         time.Now,
+	}
+}
+
+// CircuitBreakerFromEnv builds the breaker configured by
+// PHTUI_BREAKER_THRESHOLD, PHTUI_BREAKER_COOLDOWN and
+// PHTUI_BREAKER_MAX_COOLDOWN. A threshold of 0 disables it (returns nil).
+func CircuitBreakerFromEnv() *CircuitBreaker {
+	threshold := 0
+	if raw := strings.TrimSpace(os.Getenv("PHTUI_BREAKER_THRESHOLD")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err == nil && n == 0 {
+			return nil
+		}
+		if err == nil {
+			threshold = n
+		}
+	}
+	cooldown, _ := time.ParseDuration(strings.TrimSpace(os.Getenv("PHTUI_BREAKER_COOLDOWN")))
+	maxCooldown, _ := time.ParseDuration(strings.TrimSpace(os.Getenv("PHTUI_BREAKER_MAX_COOLDOWN")))
+	return NewCircuitBreaker(threshold, cooldown, maxCooldown)
+}
+
+// State reports the breaker's current state.
+func (b *CircuitBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && !b.now().Before(b.openUntil) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether a request may proceed, returning ErrCircuitOpen
+// while open or while a half-open probe is already in flight.
+func (b *CircuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Before(b.openUntil) {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// recordSuccess notes a response that wasn't a block and closes the breaker.
+func (b *CircuitBreaker) recordSuccess() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = BreakerClosed
+	b.blocks = 0
+	b.openFor = 0
+	b.probing = false
+}
+
+// recordBlock notes a block response. It opens the breaker once threshold
+// consecutive blocks are seen, and reopens it with a doubled cool-down when
+// a half-open probe is blocked.
+func (b *CircuitBreaker) recordBlock() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.blocks++
+	b.probing = false
+	switch {
+	case b.state == BreakerHalfOpen:
+		b.openFor *= 2
+		if b.openFor > b.maxCooldown {
+			b.openFor = b.maxCooldown
+		}
+	case b.blocks >= b.threshold:
+		b.openFor = b.cooldown
+	default:
+		return
+	}
+	b.state = BreakerOpen
+	b.openUntil = b.now().Add(b.openFor)
+}
+
+// release frees a half-open probe whose request failed without telling us
+// whether Product Hunt is still blocking (e.g. a network error).
+func (b *CircuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,183 @@
+package scraper
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for breaker tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker(threshold int, cooldown, maxCooldown time.Duration) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 2, 18, 9, 0, 0, 0, time.UTC)}
+	b := NewCircuitBreaker(threshold, cooldown, maxCooldown)
+	b.now = clock.now
+	return b, clock
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	b, clock := newTestBreaker(3, time.Minute, 3*time.Minute)
+
+	// Blocks below the threshold keep it closed; a success resets the count.
+	for i := 0; i < 2; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("closed breaker rejected request: %v", err)
+		}
+		b.recordBlock()
+	}
+	b.recordSuccess()
+	for i := 0; i < 2; i++ {
+		b.allow()
+		b.recordBlock()
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("state = %v after reset and 2 blocks, want closed", b.State())
+	}
+
+	// The third consecutive block opens it.
+	b.allow()
+	b.recordBlock()
+	if b.State() != BreakerOpen {
+		t.Fatalf("state = %v, want open", b.State())
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("open breaker allow = %v, want ErrCircuitOpen", err)
+	}
+
+	// After the cool-down exactly one probe is let through.
+	clock.advance(time.Minute)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("state = %v after cool-down, want half-open", b.State())
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("half-open probe rejected: %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second half-open request = %v, want ErrCircuitOpen", err)
+	}
+
+	// A blocked probe reopens with the cool-down doubled.
+	b.recordBlock()
+	clock.advance(time.Minute)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("reopened breaker allowed request after 1m: %v", err)
+	}
+	clock.advance(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe after doubled cool-down rejected: %v", err)
+	}
+
+	// Doubling stops at the maximum cool-down.
+	b.recordBlock()
+	clock.advance(3 * time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe after capped cool-down rejected: %v", err)
+	}
+
+	// A clean probe closes it again.
+	b.recordSuccess()
+	if b.State() != BreakerClosed {
+		t.Fatalf("state = %v after clean probe, want closed", b.State())
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("closed breaker rejected request: %v", err)
+	}
+}
+
+func TestCircuitBreakerReleaseProbe(t *testing.T) {
+	b, clock := newTestBreaker(1, time.Minute, time.Minute)
+	b.allow()
+	b.recordBlock()
+	clock.advance(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	// A network error says nothing about the block; free the probe slot.
+	b.release()
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("state = %v after release, want half-open", b.State())
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("next probe rejected: %v", err)
+	}
+}
+
+func TestNilCircuitBreakerAllows(t *testing.T) {
+	var b *CircuitBreaker
+	b.recordBlock()
+	if err := b.allow(); err != nil {
+		t.Fatalf("nil breaker allow = %v", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("nil breaker state = %v", b.State())
+	}
+}
+
+func TestCircuitBreakerFromEnv(t *testing.T) {
+	t.Setenv("PHTUI_BREAKER_THRESHOLD", "0")
+	if b := CircuitBreakerFromEnv(); b != nil {
+		t.Fatal("threshold 0 should disable the breaker")
+	}
+
+	t.Setenv("PHTUI_BREAKER_THRESHOLD", "2")
+	t.Setenv("PHTUI_BREAKER_COOLDOWN", "5s")
+	t.Setenv("PHTUI_BREAKER_MAX_COOLDOWN", "")
+	b := CircuitBreakerFromEnv()
+	if b == nil || b.threshold != 2 || b.cooldown != 5*time.Second || b.maxCooldown != defaultBreakerMaxCooldown {
+		t.Fatalf("unexpected breaker: %+v", b)
+	}
+}
+
+func TestScraperCircuitBreaker(t *testing.T) {
+	hits := 0
+	status := http.StatusTooManyRequests
+	body := "<html><head><title>Just a moment...</title></head><body>_cf_chl_opt</body></html>"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	b, clock := newTestBreaker(2, time.Minute, time.Minute)
+	s := New()
+	s.SetCircuitBreaker(b)
+
+	get := func() error {
+		req, _ := http.NewRequest("GET", srv.URL, nil)
+		resp, err := s.do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// A 429 and a 200 challenge page are both blocks.
+	get()
+	status = http.StatusOK
+	get()
+	if b.State() != BreakerOpen {
+		t.Fatalf("state = %v after 2 blocks, want open", b.State())
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) || hits != 2 {
+		t.Fatalf("open breaker: err=%v hits=%d, want ErrCircuitOpen without a request", err, hits)
+	}
+
+	// Once Product Hunt recovers, the half-open probe closes the breaker.
+	body = "<html><body>ok</body></html>"
+	clock.advance(time.Minute)
+	if err := get(); err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("state = %v after clean probe, want closed", b.State())
+	}
+}
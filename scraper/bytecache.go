@@ -0,0 +1,71 @@
+package scraper
+
+import (
+	"bytes"
+	"time"
+)
+
+// ByteCache stores raw payloads with a TTL per entry. It is a byte-oriented
+// view for callers that cache serialized data rather than parsed results.
+type ByteCache interface {
+	// Get returns the payload stored under key and whether it was found and
+	// hasn't expired.
+	Get(key string) ([]byte, bool)
+	// Set stores val under key for ttl, replacing any existing entry. A
+	// non-positive ttl keeps the entry until it is replaced or the
+	// underlying cache is cleared.
+	Set(key string, val []byte, ttl time.Duration)
+}
+
+// ValueByteCache is a ByteCache kept in a value Cache, so the payloads share
+// its storage (and a Scraper's, if it is the same Cache) without replacing it.
+// Keys are prefixed so they can't collide with the scraper's URL keys.
+type ValueByteCache struct {
+	cache Cache
+	// now checks entries against their expiry; tests replace it.
+	now func() time.Time
+}
+
+type byteEntry struct {
+	data    []byte
+	expires time.Time // zero if the entry doesn't expire
+}
+
+// Compile-time interface check
+var _ ByteCache = (*ValueByteCache)(nil)
+
+// NewByteCache creates a ValueByteCache storing its entries in cache.
+func NewByteCache(cache Cache) *ValueByteCache {
+	return &ValueByteCache{cache: cache, now: time.Now}
+}
+
+// Get returns a copy of the payload stored under key, deleting it if it has
+// expired.
+func (c *ValueByteCache) Get(key string) ([]byte, bool) {
+	val, ok := c.cache.Get(byteCacheKey(key))
+	if !ok {
+		return nil, false
+	}
+	entry, ok := val.(byteEntry)
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.cache.Delete(byteCacheKey(key))
+		return nil, false
+	}
+	return bytes.Clone(entry.data), true
+}
+
+// Set stores a copy of val under key for ttl.
+func (c *ValueByteCache) Set(key string, val []byte, ttl time.Duration) {
+	entry := byteEntry{data: bytes.Clone(val)}
+	if ttl > 0 {
+		entry.expires = c.now().Add(ttl)
+	}
+	c.cache.Set(byteCacheKey(key), entry)
+}
+
+func byteCacheKey(key string) string {
+	return "bytes:" + key
+}
@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestByteCacheTTL(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	c := NewByteCache(NewMemoryCache())
+	c.now = func() time.Time { return now }
+
+	c.Set("short", []byte("a"), time.Minute)
+	c.Set("long", []byte("b"), time.Hour)
+	c.Set("forever", []byte("c"), 0)
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("short"); ok {
+		t.Fatal("entry returned after its TTL")
+	}
+	if got, ok := c.Get("long"); !ok || string(got) != "b" {
+		t.Fatalf("Get(long) = %q, %v", got, ok)
+	}
+	now = now.Add(24 * time.Hour)
+	if got, ok := c.Get("forever"); !ok || string(got) != "c" {
+		t.Fatalf("Get(forever) = %q, %v", got, ok)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("missing key found")
+	}
+}
+
+func TestByteCacheSharesScraperCache(t *testing.T) {
+	dir := t.TempDir()
+	disk := newTestDiskCache(t, dir)
+	disk.Set("https://www.producthunt.com/", []byte("scraper value"))
+	c := NewByteCache(disk)
+
+	val := []byte("payload")
+	c.Set("https://www.producthunt.com/", val, time.Hour)
+	val[0] = 'X'
+	got, ok := c.Get("https://www.producthunt.com/")
+	if !ok || string(got) != "payload" {
+		t.Fatalf("Get = %q, %v, want an unaliased copy", got, ok)
+	}
+	got[0] = 'X'
+	if again, _ := c.Get("https://www.producthunt.com/"); string(again) != "payload" {
+		t.Fatalf("Get returned the stored slice: %q", again)
+	}
+	if v, ok := disk.Get("https://www.producthunt.com/"); !ok || string(v.([]byte)) != "scraper value" {
+		t.Fatalf("byte entry overwrote the scraper's: %v, %v", v, ok)
+	}
+
+	disk.Clear()
+	if _, ok := c.Get("https://www.producthunt.com/"); ok {
+		t.Fatal("entry survived clearing the underlying cache")
+	}
+}
@@ -0,0 +1,88 @@
+package scraper
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores parsed scraper results keyed by request URL. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key and whether it was found.
+	Get(key string) (any, bool)
+	// Set stores value under key, replacing any existing entry.
+	Set(key string, value any)
+	// Delete removes the entry for key, if present.
+	Delete(key string)
+	// Clear removes all entries.
+	Clear()
+}
+
+// storedAtCache is implemented by caches that record when each entry was
+// stored, which lets a Scraper with a cache TTL expire entries.
+type storedAtCache interface {
+	// GetStored returns the value stored under key, when it was stored, and
+	// whether it was found.
+	GetStored(key string) (any, time.Time, bool)
+}
+
+// MemoryCache is the default in-process Cache backed by a map.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResult
+	// now stamps entries as they're stored; tests replace it.
+	now func() time.Time
+}
+
+type cachedResult struct {
+	value     any
+	timestamp time.Time
+}
+
+// Compile-time interface checks
+var (
+	_ Cache         = (*MemoryCache)(nil)
+	_ storedAtCache = (*MemoryCache)(nil)
+)
+
+// NewMemoryCache creates an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cachedResult), now: time.Now}
+}
+
+// Get retrieves a cached value by key, returning (value, true) if found.
+func (c *MemoryCache) Get(key string) (any, bool) {
+	value, _, ok := c.GetStored(key)
+	return value, ok
+}
+
+// GetStored retrieves a cached value by key with the time it was stored.
+func (c *MemoryCache) GetStored(key string) (any, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.entries[key]; ok {
+		return cached.value, cached.timestamp, true
+	}
+	return nil, time.Time{}, false
+}
+
+// Set stores a value in the cache under the given key.
+func (c *MemoryCache) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedResult{value: value, timestamp: c.now()}
+}
+
+// Delete removes the value stored under key.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Clear removes all cached values.
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cachedResult)
+}
@@ -0,0 +1,96 @@
+package scraper
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// testCacheContract exercises the behavior every Cache backend must provide.
+func testCacheContract(t *testing.T, newCache func(t *testing.T) Cache) {
+	t.Run("get missing", func(t *testing.T) {
+		c := newCache(t)
+		if v, ok := c.Get("missing"); ok || v != nil {
+			t.Fatalf("Get(missing) = %v, %v; want nil, false", v, ok)
+		}
+	})
+
+	t.Run("set then get", func(t *testing.T) {
+		c := newCache(t)
+		c.Set("k", "v1")
+		if v, ok := c.Get("k"); !ok || v != "v1" {
+			t.Fatalf("Get(k) = %v, %v; want v1, true", v, ok)
+		}
+		c.Set("k", "v2")
+		if v, _ := c.Get("k"); v != "v2" {
+			t.Fatalf("Get(k) after overwrite = %v, want v2", v)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		c := newCache(t)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Delete("a")
+		c.Delete("never-set")
+		if _, ok := c.Get("a"); ok {
+			t.Fatal("deleted key still present")
+		}
+		if v, ok := c.Get("b"); !ok || v != 2 {
+			t.Fatalf("Get(b) = %v, %v; want 2, true", v, ok)
+		}
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		c := newCache(t)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Clear()
+		for _, key := range []string{"a", "b"} {
+			if _, ok := c.Get(key); ok {
+				t.Fatalf("key %s survived Clear", key)
+			}
+		}
+		c.Set("a", 3)
+		if v, ok := c.Get("a"); !ok || v != 3 {
+			t.Fatalf("Get(a) after Clear = %v, %v; want 3, true", v, ok)
+		}
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		c := newCache(t)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				key := fmt.Sprintf("k%d", i)
+				c.Set(key, i)
+				c.Get(key)
+				c.Delete(key)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCacheContract(t, func(*testing.T) Cache { return NewMemoryCache() })
+}
+
+func TestScraperUsesProvidedCache(t *testing.T) {
+	cache := NewMemoryCache()
+	s := NewWithCache(cache)
+	s.setCache("k", "v")
+	if v, ok := cache.Get("k"); !ok || v != "v" {
+		t.Fatalf("backend Get(k) = %v, %v; want v, true", v, ok)
+	}
+	s.ClearCache()
+	if _, ok := cache.Get("k"); ok {
+		t.Fatal("ClearCache did not clear the backend")
+	}
+
+	if NewWithCache(nil).cache == nil {
+		t.Fatal("NewWithCache(nil) should fall back to an in-memory cache")
+	}
+}
@@ -1,8 +1,10 @@
 package scraper
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,22 +17,75 @@ import (
 // (e.g. /categories/ai-agents) and extracts the product list
 // plus related category links.
 func ParseCategoryProducts(reader io.Reader) ([]types.Product, []types.CategoryLink, error) {
+	products, relatedCategories, _, _, err := ParseCategoryProductsPage(reader, 1)
+	return products, relatedCategories, err
+}
+
+// ParseCategoryProductsPage parses one page of a category listing
+// (e.g. /categories/ai-agents?page=2). Besides the products and related
+// categories it reports whether a later page exists and how many pages the
+// category has, read from the pagination links. page is the page that was
+// requested; products are ranked across pages when the page says which
+// products it shows.
+func ParseCategoryProductsPage(reader io.Reader, page int) ([]types.Product, []types.CategoryLink, bool, int, error) {
 	doc, err := goquery.NewDocumentFromReader(reader)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse HTML: %w", err)
+		return nil, nil, false, 0, fmt.Errorf("parse HTML: %w", err)
+	}
+	if page < 1 {
+		page = 1
 	}
 
-	products := parseCategoryProductCards(doc)
+	products := parseCategoryProductCards(doc, parseCategoryFirstRank(doc))
 	relatedCategories := parseCategoryRelatedCategories(doc)
+	hasNext, pagesCount := parseCategoryPageInfo(doc, page)
+
+	return products, relatedCategories, hasNext, pagesCount, nil
+}
+
+// categoryShowingRe matches the "Showing 16-30 of 474 products" line under
+// a category listing.
+var categoryShowingRe = regexp.MustCompile(`Showing\s*(\d+)\s*-\s*\d+\s*of`)
+
+// parseCategoryFirstRank returns the position of the page's first product
+// in the whole category, or 1 when the page doesn't say.
+func parseCategoryFirstRank(doc *goquery.Document) int {
+	m := categoryShowingRe.FindStringSubmatch(doc.Find("span").FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return strings.HasPrefix(strings.TrimSpace(s.Text()), "Showing")
+	}).First().Text())
+	if len(m) < 2 {
+		return 1
+	}
+	if first, err := strconv.Atoi(m[1]); err == nil && first > 0 {
+		return first
+	}
+	return 1
+}
 
-	return products, relatedCategories, nil
+// parseCategoryPageInfo reads the pagination links (?page=N) of a category
+// listing. The highest page linked, usually from the "Last" link, is the
+// page count; a page without pagination is the only one.
+func parseCategoryPageInfo(doc *goquery.Document, page int) (bool, int) {
+	pagesCount := page
+	doc.Find(`a[href^="/categories/"]`).Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		u, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		if n, err := strconv.Atoi(u.Query().Get("page")); err == nil && n > pagesCount {
+			pagesCount = n
+		}
+	})
+	hasNext := page < pagesCount || doc.Find(`link[rel="next"]`).Length() > 0
+	return hasNext, pagesCount
 }
 
 // parseCategoryProductCards extracts products from the category page HTML.
 // Each product card is an <a data-grid-span="1" href="/products/{slug}">
 // containing a name span and tagline span. Rating and review count appear
-// nearby in the same row/grid container.
-func parseCategoryProductCards(doc *goquery.Document) []types.Product {
+// nearby in the same row/grid container. Ranks start at firstRank.
+func parseCategoryProductCards(doc *goquery.Document, firstRank int) []types.Product {
 	var products []types.Product
 	seen := make(map[string]struct{})
 
@@ -76,7 +131,9 @@ func parseCategoryProductCards(doc *goquery.Document) []types.Product {
 			reviewCount,
 			slug,
 			thumbnailURL,
-			len(products)+1,
+			firstRank+len(products),
+			0,
+			false,
 		))
 	})
 
@@ -121,7 +178,9 @@ func parseCategoryProductCards(doc *goquery.Document) []types.Product {
 				0,
 				slug,
 				"",
-				len(products)+1,
+				firstRank+len(products),
+				0,
+				false,
 			))
 		})
 	}
@@ -231,3 +290,70 @@ func parseCategoryRelatedCategories(doc *goquery.Document) []types.CategoryLink
 
 	return categories
 }
+
+// ParseCategoryIndex parses Product Hunt's category index page (/categories)
+// and returns every category it links to, in page order.
+func ParseCategoryIndex(reader io.Reader) ([]types.CategoryLink, error) {
+	doc, err := goquery.NewDocumentFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML: %w", err)
+	}
+	return parseCategoryRelatedCategories(doc), nil
+}
+
+var (
+	categoryHierarchyRe = regexp.MustCompile(`"parent":(null|\{[^{}]*\}),"subCategories":\{"__typename":"ProductCategoryConnection","edges":\[(.*?)\]\}`)
+	categoryNodeRe      = regexp.MustCompile(`\{"__typename":"ProductCategory"[^{}]*\}`)
+	categoryNameRe      = regexp.MustCompile(`"name":"((?:[^"\\]|\\.)*)"`)
+	categoryPathRe      = regexp.MustCompile(`"path":"/categories/([^"?/]+)"`)
+)
+
+// ParseCategoryTree parses a Product Hunt category page and extracts the
+// category's parent and subcategories from the Apollo SSR hydration data.
+// When the hierarchy isn't present, it falls back to the page's related
+// category links as a flat list.
+func ParseCategoryTree(reader io.Reader, slug string) (types.CategoryTree, error) {
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return types.CategoryTree{}, fmt.Errorf("read HTML: %w", err)
+	}
+
+	category := types.NewCategoryLink(slug, slug)
+	if idx := types.CategoryIndexBySlug(slug); idx >= 0 {
+		category = types.AllCategories[idx]
+	}
+
+	if m := categoryHierarchyRe.FindStringSubmatch(string(raw)); m != nil {
+		var parent *types.CategoryLink
+		if link, ok := parseCategoryNode(m[1]); ok {
+			parent = &link
+		}
+		var children []types.CategoryLink
+		for _, node := range categoryNodeRe.FindAllString(m[2], -1) {
+			if link, ok := parseCategoryNode(node); ok {
+				children = append(children, link)
+			}
+		}
+		return types.NewCategoryTree(category, parent, children, true), nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(raw))
+	if err != nil {
+		return types.CategoryTree{}, fmt.Errorf("parse HTML: %w", err)
+	}
+	return types.NewCategoryTree(category, nil, parseCategoryRelatedCategories(doc), false), nil
+}
+
+// parseCategoryNode extracts a CategoryLink from a hydration ProductCategory
+// object. It reports false for null or incomplete nodes.
+func parseCategoryNode(node string) (types.CategoryLink, bool) {
+	path := categoryPathRe.FindStringSubmatch(node)
+	if path == nil {
+		return types.CategoryLink{}, false
+	}
+	name := path[1]
+	if m := categoryNameRe.FindStringSubmatch(node); m != nil {
+		name = decodeJSONEscaped(m[1])
+	}
+	return types.NewCategoryLink(name, path[1]), true
+}
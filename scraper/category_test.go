@@ -98,6 +98,76 @@ func TestParseCategoryProducts(t *testing.T) {
 	}
 }
 
+func TestParseCategoryProductsPage(t *testing.T) {
+	for _, tt := range []struct {
+		file      string
+		page      int
+		firstSlug string
+		firstRank int
+	}{
+		{"../testdata/category_products.html", 1, "", 1},
+		{"../testdata/category_products_page2.html", 2, "lindy", 16},
+	} {
+		f, err := os.Open(tt.file)
+		if err != nil {
+			t.Fatalf("open fixture: %v", err)
+		}
+		products, categories, hasNext, pagesCount, err := ParseCategoryProductsPage(f, tt.page)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: ParseCategoryProductsPage: %v", tt.file, err)
+		}
+		if !hasNext || pagesCount != 32 {
+			t.Errorf("%s: hasNext = %v, pagesCount = %d; want true, 32", tt.file, hasNext, pagesCount)
+		}
+		if len(products) == 0 || len(categories) == 0 {
+			t.Fatalf("%s: %d products, %d categories", tt.file, len(products), len(categories))
+		}
+		if tt.firstSlug != "" && products[0].Slug() != tt.firstSlug {
+			t.Errorf("%s: first product = %q, want %q", tt.file, products[0].Slug(), tt.firstSlug)
+		}
+		for i, p := range products {
+			if p.Rank() != tt.firstRank+i {
+				t.Errorf("%s: product %q rank = %d, want %d", tt.file, p.Slug(), p.Rank(), tt.firstRank+i)
+			}
+		}
+	}
+
+	f, err := os.Open("../testdata/category_products_page2.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+	products, categories, _, _, _ := ParseCategoryProductsPage(f, 2)
+	if len(products) != 2 || products[1].Name() != "Relevance AI" || products[1].Tagline() != "Build your AI workforce" || products[0].CommentCount() != 98 {
+		t.Errorf("page 2 products = %+v", products)
+	}
+	if len(categories) != 2 || categories[0].Slug() != "ai-coding-agents" {
+		t.Errorf("page 2 categories = %+v", categories)
+	}
+}
+
+func TestParseCategoryProductsPageLastPage(t *testing.T) {
+	html := `<html><body>
+	<a data-grid-span="1" href="/products/last"><span class="font-semibold">Last One</span></a>
+	<a href="/categories/test-cat?page=1#content">1</a>
+	<a href="/categories/test-cat?page=2#content">2</a>
+	</body></html>`
+	_, _, hasNext, pagesCount, err := ParseCategoryProductsPage(strings.NewReader(html), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasNext || pagesCount != 2 {
+		t.Errorf("last page: hasNext = %v, pagesCount = %d; want false, 2", hasNext, pagesCount)
+	}
+
+	// Without pagination the requested page is the only one.
+	_, _, hasNext, pagesCount, _ = ParseCategoryProductsPage(strings.NewReader(`<html><body></body></html>`), 1)
+	if hasNext || pagesCount != 1 {
+		t.Errorf("no pagination: hasNext = %v, pagesCount = %d; want false, 1", hasNext, pagesCount)
+	}
+}
+
 func TestParseCategoryProductsTaglines(t *testing.T) {
 	f, err := os.Open("../testdata/category_products.html")
 	if err != nil {
@@ -215,3 +285,125 @@ func TestParseCategoryProductsSyntheticHTML(t *testing.T) {
 		t.Errorf("category[0] name = %q, want %q", categories[0].Name(), "Related Category")
 	}
 }
+
+func TestParseCategoryTree(t *testing.T) {
+	f, err := os.Open("../testdata/category_tree.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	tree, err := ParseCategoryTree(f, "ai-coding-agents")
+	if err != nil {
+		t.Fatalf("ParseCategoryTree: %v", err)
+	}
+
+	if !tree.Hierarchical() {
+		t.Fatal("expected hierarchical tree")
+	}
+	if tree.Category().Slug() != "ai-coding-agents" || tree.Category().Name() != "AI Coding Agents" {
+		t.Errorf("category = %q (%q), want AI Coding Agents (ai-coding-agents)", tree.Category().Name(), tree.Category().Slug())
+	}
+	parent := tree.Parent()
+	if parent == nil {
+		t.Fatal("expected a parent category")
+	}
+	if parent.Slug() != "engineering-development" || parent.Name() != "Engineering & Development" {
+		t.Errorf("parent = %q (%q), want Engineering & Development (engineering-development)", parent.Name(), parent.Slug())
+	}
+
+	children := tree.Children()
+	wantChildren := []string{"ai-code-review", "ai-terminal-agents"}
+	if len(children) != len(wantChildren) {
+		t.Fatalf("children count = %d, want %d", len(children), len(wantChildren))
+	}
+	for i, want := range wantChildren {
+		if children[i].Slug() != want {
+			t.Errorf("children[%d] slug = %q, want %q", i, children[i].Slug(), want)
+		}
+	}
+}
+
+func TestParseCategoryTreeTopLevel(t *testing.T) {
+	f, err := os.Open("../testdata/category_products.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	tree, err := ParseCategoryTree(f, "ai-agents")
+	if err != nil {
+		t.Fatalf("ParseCategoryTree: %v", err)
+	}
+
+	if !tree.Hierarchical() {
+		t.Fatal("expected hierarchical tree")
+	}
+	if tree.Parent() != nil {
+		t.Errorf("parent = %v, want nil for top-level category", tree.Parent())
+	}
+	found := false
+	for _, c := range tree.Children() {
+		if c.Slug() == "ai-agent-automation" {
+			found = true
+			if c.Name() != "AI Agent Automation" {
+				t.Errorf("child name = %q, want %q", c.Name(), "AI Agent Automation")
+			}
+		}
+	}
+	if !found {
+		t.Error("expected subcategory ai-agent-automation not found")
+	}
+}
+
+func TestParseCategoryTreeFlatFallback(t *testing.T) {
+	html := `<!DOCTYPE html><html><head>
+	<link rel="canonical" href="https://www.producthunt.com/categories/test-cat">
+	</head><body>
+	<a href="/categories/related-cat">Related Category</a>
+	<a href="/categories/test-cat">Test Cat</a>
+	</body></html>`
+
+	tree, err := ParseCategoryTree(strings.NewReader(html), "test-cat")
+	if err != nil {
+		t.Fatalf("ParseCategoryTree: %v", err)
+	}
+	if tree.Hierarchical() {
+		t.Error("expected flat fallback without hydration data")
+	}
+	if tree.Parent() != nil {
+		t.Errorf("parent = %v, want nil", tree.Parent())
+	}
+	if tree.Category().Slug() != "test-cat" {
+		t.Errorf("category slug = %q, want %q", tree.Category().Slug(), "test-cat")
+	}
+	children := tree.Children()
+	if len(children) != 1 || children[0].Slug() != "related-cat" {
+		t.Errorf("children = %v, want [related-cat]", children)
+	}
+}
+
+func TestParseCategoryIndex(t *testing.T) {
+	html := `<html><head><link rel="canonical" href="https://www.producthunt.com/categories"></head><body>
+	<a href="/categories/ai-agents">AI Agents</a>
+	<a href="/categories/llms">LLMs</a>
+	<a href="/categories/ai-agents">AI Agents</a>
+	<a href="/categories/vibe-coding">Vibe coding</a>
+	<a href="/categories/llms?page=2">2</a>
+	<a href="/products/demo">Demo</a>
+	</body></html>`
+
+	categories, err := ParseCategoryIndex(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseCategoryIndex: %v", err)
+	}
+	want := []string{"ai-agents:AI Agents", "llms:LLMs", "vibe-coding:Vibe coding"}
+	if len(categories) != len(want) {
+		t.Fatalf("got %d categories, want %d: %v", len(categories), len(want), categories)
+	}
+	for i, c := range categories {
+		if got := c.Slug() + ":" + c.Name(); got != want[i] {
+			t.Errorf("category[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
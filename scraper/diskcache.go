@@ -0,0 +1,694 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	defaultDiskCacheTTL     = time.Hour
+	defaultDiskCacheMaxSize = 64 << 20 // 64 MiB
+	diskCacheExt            = ".json"
+)
+
+// DiskCache is a Cache that persists scraper results as JSON files under a
+// directory so they survive restarts. Files are named by a hash of the cache
+// key, expire after a TTL, and the oldest are evicted once the directory
+// exceeds its size cap. The directory's total size is tracked in memory, so
+// it is only scanned when the cap is exceeded, and evicted entries are dropped
+// from memory too. Values of types the scraper doesn't cache are kept in
+// memory only. Corrupt or unreadable files are removed and treated as misses.
+type DiskCache struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+	now     func() time.Time
+
+	mu    sync.Mutex
+	mem   map[string]cachedResult
+	size  int64            // total bytes of cache files on disk
+	sizes map[string]int64 // cache file path -> size in bytes
+}
+
+// Compile-time interface checks
+var (
+	_ Cache         = (*DiskCache)(nil)
+	_ storedAtCache = (*DiskCache)(nil)
+)
+
+// NewDiskCache creates a DiskCache rooted at dir, creating the directory if
+// needed. A non-positive ttl or maxSize selects the default.
+func NewDiskCache(dir string, ttl time.Duration, maxSize int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = defaultDiskCacheTTL
+	}
+	if maxSize <= 0 {
+		maxSize = defaultDiskCacheMaxSize
+	}
+	c := &DiskCache{
+		dir:     dir,
+		ttl:     ttl,
+		maxSize: maxSize,
+		now:     time.Now,
+		mem:     make(map[string]cachedResult),
+		sizes:   make(map[string]int64),
+	}
+	c.scanLocked()
+	return c, nil
+}
+
+// Get returns the value stored under key if it hasn't expired, reading it back
+// from disk when it isn't already in memory.
+func (c *DiskCache) Get(key string) (any, bool) {
+	value, _, ok := c.GetStored(key)
+	return value, ok
+}
+
+// GetStored is Get that also returns when the value was stored, which for
+// a value read back from disk is when it was first written.
+func (c *DiskCache) GetStored(key string) (any, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.mem[key]; ok {
+		if c.expired(cached.timestamp) {
+			c.deleteLocked(key)
+			return nil, time.Time{}, false
+		}
+		return cached.value, cached.timestamp, true
+	}
+
+	entry, ok := c.readEntry(key)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	if c.expired(entry.StoredAt) {
+		c.deleteLocked(key)
+		return nil, time.Time{}, false
+	}
+	value, ok := entry.decode()
+	if !ok {
+		c.deleteLocked(key)
+		return nil, time.Time{}, false
+	}
+	c.mem[key] = cachedResult{value: value, timestamp: entry.StoredAt}
+	return value, entry.StoredAt, true
+}
+
+// Set stores value under key in memory and, for scraper result types, on disk.
+func (c *DiskCache) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.mem[key] = cachedResult{value: value, timestamp: now}
+
+	entry, ok := encodeDiskEntry(value)
+	if !ok {
+		// Not persistable; drop any stale file so it can't shadow this value.
+		c.removeFileLocked(c.path(key))
+		return
+	}
+	entry.Key = key
+	entry.StoredAt = now
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	path := c.path(key)
+	if err := writeFileAtomic(path, data); err != nil {
+		return
+	}
+	size := int64(len(data))
+	c.size += size - c.sizes[path]
+	c.sizes[path] = size
+	if c.size > c.maxSize {
+		c.enforceSizeLocked()
+	}
+}
+
+// Delete removes key from memory and disk.
+func (c *DiskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deleteLocked(key)
+}
+
+// Clear removes every cached entry from memory and disk.
+func (c *DiskCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mem = make(map[string]cachedResult)
+	files, _ := filepath.Glob(filepath.Join(c.dir, "*"+diskCacheExt))
+	for _, f := range files {
+		os.Remove(f)
+	}
+	c.size = 0
+	c.sizes = make(map[string]int64)
+}
+
+func (c *DiskCache) expired(storedAt time.Time) bool {
+	return c.now().Sub(storedAt) > c.ttl
+}
+
+func (c *DiskCache) deleteLocked(key string) {
+	delete(c.mem, key)
+	c.removeFileLocked(c.path(key))
+}
+
+// removeFileLocked removes a cache file and stops counting its size.
+func (c *DiskCache) removeFileLocked(path string) {
+	if os.Remove(path) == nil {
+		c.size -= c.sizes[path]
+		delete(c.sizes, path)
+	}
+}
+
+// scanLocked recomputes the tracked sizes from the files in the directory.
+func (c *DiskCache) scanLocked() {
+	c.size = 0
+	c.sizes = make(map[string]int64)
+	for _, f := range listCacheFiles(c.dir, diskCacheExt) {
+		c.size += f.size
+		c.sizes[f.path] = f.size
+	}
+}
+
+// path returns the file holding key, named by the SHA-256 of the key.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskCacheExt)
+}
+
+// readEntry loads the entry for key from disk. Corrupt files, and files whose
+// stored key doesn't match, are removed.
+func (c *DiskCache) readEntry(key string) (diskEntry, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diskEntry{}, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		c.removeFileLocked(path)
+		return diskEntry{}, false
+	}
+	return entry, true
+}
+
+// enforceSizeLocked evicts the least recently written files until the cache
+// directory fits within maxSize, dropping the evicted entries from memory.
+func (c *DiskCache) enforceSizeLocked() {
+	evicted := evictOldest(c.dir, diskCacheExt, c.maxSize)
+	if len(evicted) == 0 {
+		return
+	}
+	removed := make(map[string]bool, len(evicted))
+	for _, path := range evicted {
+		removed[path] = true
+	}
+	for key := range c.mem {
+		if removed[c.path(key)] {
+			delete(c.mem, key)
+		}
+	}
+	c.scanLocked()
+}
+
+// cacheFile is a file in a cache directory.
+type cacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// listCacheFiles returns the files with the given extension in dir.
+func listCacheFiles(dir, ext string) []cacheFile {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []cacheFile
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ext) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{filepath.Join(dir, e.Name()), info.Size(), info.ModTime()})
+	}
+	return files
+}
+
+// evictOldest removes the least recently written files with the given
+// extension in dir until their total size fits within maxSize, returning the
+// paths it removed.
+func evictOldest(dir, ext string, maxSize int64) []string {
+	files := listCacheFiles(dir, ext)
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	if total <= maxSize {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	var removed []string
+	for _, f := range files {
+		if total <= maxSize {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+			removed = append(removed, f.path)
+		}
+	}
+	return removed
+}
+
+// writeFileAtomic writes data to a temp file and renames it into place so
+// readers never observe a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// DefaultDataDir returns the directory phtui stores persistent data in:
+// PHTUI_DATA_DIR when set, otherwise "phtui" under the user cache directory.
+func DefaultDataDir() (string, error) {
+	if dir := strings.TrimSpace(os.Getenv("PHTUI_DATA_DIR")); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "phtui"), nil
+}
+
+// CacheTTLFromEnv returns PHTUI_CACHE_TTL, or 0 when it is unset or invalid.
+func CacheTTLFromEnv() time.Duration {
+	ttl, err := time.ParseDuration(strings.TrimSpace(os.Getenv("PHTUI_CACHE_TTL")))
+	if err != nil || ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// CacheFromEnv returns the cache backend selected by PHTUI_CACHE. "disk"
+// selects a DiskCache under DefaultDataDir()/cache, tuned by PHTUI_CACHE_TTL
+// and PHTUI_CACHE_MAX_MB; anything else, or a disk cache that can't be
+// created, selects the in-memory cache.
+func CacheFromEnv() Cache {
+	if !strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_CACHE")), "disk") {
+		return NewMemoryCache()
+	}
+	dir, err := DefaultDataDir()
+	if err != nil {
+		return NewMemoryCache()
+	}
+	ttl := CacheTTLFromEnv()
+	maxMB, _ := strconv.ParseInt(strings.TrimSpace(os.Getenv("PHTUI_CACHE_MAX_MB")), 10, 64)
+	cache, err := NewDiskCache(filepath.Join(dir, "cache"), ttl, maxMB<<20)
+	if err != nil {
+		return NewMemoryCache()
+	}
+	return cache
+}
+
+// diskEntry is the on-disk form of a cached value. Exactly one payload field
+// is set, selected by Kind.
+type diskEntry struct {
+	Key      string               `json:"key"`
+	StoredAt time.Time            `json:"stored_at"`
+	Kind     string               `json:"kind"`
+	Products []diskProduct        `json:"products,omitempty"`
+	Featured map[string]time.Time `json:"featured,omitempty"`
+	Detail   *diskDetail          `json:"detail,omitempty"`
+	Search   *diskSearch          `json:"search,omitempty"`
+	Category *diskCategory        `json:"category,omitempty"`
+	Tree     *diskTree            `json:"tree,omitempty"`
+	History  []diskRankPoint      `json:"history,omitempty"`
+	// HydrationOnly is a leaderboard's hydration-only slug set, sorted.
+	HydrationOnly []string `json:"hydration_only,omitempty"`
+}
+
+const (
+	diskKindProducts      = "products"
+	diskKindFeatured      = "featured"
+	diskKindDetail        = "detail"
+	diskKindSearch        = "search"
+	diskKindCategory      = "category"
+	diskKindTree          = "tree"
+	diskKindHistory       = "history"
+	diskKindHydrationOnly = "hydration-only"
+)
+
+type diskProduct struct {
+	Name         string   `json:"name"`
+	Tagline      string   `json:"tagline"`
+	Categories   []string `json:"categories,omitempty"`
+	VoteCount    int      `json:"vote_count"`
+	CommentCount int      `json:"comment_count"`
+	Slug         string   `json:"slug"`
+	ThumbnailURL string   `json:"thumbnail_url,omitempty"`
+	Rank         int      `json:"rank"`
+	Rating       float64  `json:"rating,omitempty"`
+	Offline      bool     `json:"offline,omitempty"`
+}
+
+type diskProConTag struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+type diskDetail struct {
+	Product         diskProduct         `json:"product"`
+	Description     string              `json:"description"`
+	Rating          float64             `json:"rating"`
+	ReviewCount     int                 `json:"review_count"`
+	FollowerCount   int                 `json:"follower_count"`
+	MakerComment    string              `json:"maker_comment"`
+	WebsiteURL      string              `json:"website_url"`
+	Categories      []string            `json:"categories,omitempty"`
+	SocialLinks     []string            `json:"social_links,omitempty"`
+	FirstLaunch     time.Time           `json:"first_launch"`
+	LatestLaunch    time.Time           `json:"latest_launch"`
+	MakerName       string              `json:"maker_name"`
+	MakerProfileURL string              `json:"maker_profile_url"`
+	ProConTags      []diskProConTag     `json:"pro_con_tags,omitempty"`
+	PricingInfo     string              `json:"pricing_info"`
+	PricingRaw      []string            `json:"pricing_raw,omitempty"`
+	RatingDist      [5]int              `json:"rating_distribution"`
+	Related         []diskProduct       `json:"related,omitempty"`
+	Links           map[string][]string `json:"links,omitempty"`
+	Topics          []diskCategoryLink  `json:"topics,omitempty"`
+	GalleryImages   []string            `json:"gallery_images,omitempty"`
+}
+
+type diskSearch struct {
+	Products   []diskProduct `json:"products"`
+	Page       int           `json:"page"`
+	HasPrev    bool          `json:"has_prev"`
+	HasNext    bool          `json:"has_next"`
+	PagesCount int           `json:"pages_count"`
+}
+
+type diskCategoryLink struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type diskCategory struct {
+	Products   []diskProduct      `json:"products"`
+	Categories []diskCategoryLink `json:"categories,omitempty"`
+	HasNext    bool               `json:"has_next,omitempty"`
+	PagesCount int                `json:"pages_count,omitempty"`
+}
+
+type diskTree struct {
+	Category     diskCategoryLink   `json:"category"`
+	Parent       *diskCategoryLink  `json:"parent,omitempty"`
+	Children     []diskCategoryLink `json:"children,omitempty"`
+	Hierarchical bool               `json:"hierarchical"`
+}
+
+type diskRankPoint struct {
+	Date time.Time `json:"date"`
+	Rank int       `json:"rank"`
+}
+
+// encodeDiskEntry converts a scraper cache value into its on-disk form. It
+// reports false for types that aren't persisted.
+func encodeDiskEntry(value any) (diskEntry, bool) {
+	switch v := value.(type) {
+	case []types.Product:
+		return diskEntry{Kind: diskKindProducts, Products: toDiskProducts(v)}, true
+	case map[string]time.Time:
+		return diskEntry{Kind: diskKindFeatured, Featured: v}, true
+	case map[string]bool:
+		slugs := make([]string, 0, len(v))
+		for slug, ok := range v {
+			if ok {
+				slugs = append(slugs, slug)
+			}
+		}
+		sort.Strings(slugs)
+		return diskEntry{Kind: diskKindHydrationOnly, HydrationOnly: slugs}, true
+	case types.ProductDetail:
+		tags := make([]diskProConTag, 0, len(v.ProConTags()))
+		for _, t := range v.ProConTags() {
+			tags = append(tags, diskProConTag{Name: t.Name(), Type: t.TagType(), Count: t.Count()})
+		}
+		return diskEntry{Kind: diskKindDetail, Detail: &diskDetail{
+			Product:         toDiskProduct(v.Product()),
+			Description:     v.Description(),
+			Rating:          v.Rating(),
+			ReviewCount:     v.ReviewCount(),
+			FollowerCount:   v.FollowerCount(),
+			MakerComment:    v.MakerComment(),
+			WebsiteURL:      v.WebsiteURL(),
+			Categories:      v.Categories(),
+			SocialLinks:     v.SocialLinks(),
+			FirstLaunch:     v.FirstLaunchDate(),
+			LatestLaunch:    v.LatestLaunchDate(),
+			MakerName:       v.MakerName(),
+			MakerProfileURL: v.MakerProfileURL(),
+			ProConTags:      tags,
+			PricingInfo:     v.PricingInfo(),
+			PricingRaw:      v.PricingRaw(),
+			RatingDist:      v.RatingDistribution(),
+			Related:         toDiskProducts(v.RelatedLaunches()),
+			Links:           v.Links(),
+			Topics:          toDiskCategoryLinks(v.Topics()),
+			GalleryImages:   v.GalleryImages(),
+		}}, true
+	case searchPageCache:
+		return diskEntry{Kind: diskKindSearch, Search: &diskSearch{
+			Products:   toDiskProducts(v.products),
+			Page:       v.page,
+			HasPrev:    v.hasPrev,
+			HasNext:    v.hasNext,
+			PagesCount: v.pagesCount,
+		}}, true
+	case categoryCache:
+		return diskEntry{Kind: diskKindCategory, Category: &diskCategory{
+			Products:   toDiskProducts(v.products),
+			Categories: toDiskCategoryLinks(v.categories),
+			HasNext:    v.hasNext,
+			PagesCount: v.pagesCount,
+		}}, true
+	case types.CategoryTree:
+		tree := &diskTree{
+			Category:     toDiskCategoryLink(v.Category()),
+			Children:     toDiskCategoryLinks(v.Children()),
+			Hierarchical: v.Hierarchical(),
+		}
+		if p := v.Parent(); p != nil {
+			parent := toDiskCategoryLink(*p)
+			tree.Parent = &parent
+		}
+		return diskEntry{Kind: diskKindTree, Tree: tree}, true
+	case []types.RankPoint:
+		history := make([]diskRankPoint, 0, len(v))
+		for _, p := range v {
+			history = append(history, diskRankPoint{Date: p.Date(), Rank: p.Rank()})
+		}
+		return diskEntry{Kind: diskKindHistory, History: history}, true
+	default:
+		return diskEntry{}, false
+	}
+}
+
+// decode converts the entry back into the value the scraper cached. It
+// reports false when the entry's payload doesn't match its kind.
+func (e diskEntry) decode() (any, bool) {
+	switch e.Kind {
+	case diskKindProducts:
+		return fromDiskProducts(e.Products), true
+	case diskKindFeatured:
+		if e.Featured == nil {
+			return map[string]time.Time{}, true
+		}
+		return e.Featured, true
+	case diskKindHydrationOnly:
+		slugs := make(map[string]bool, len(e.HydrationOnly))
+		for _, slug := range e.HydrationOnly {
+			slugs[slug] = true
+		}
+		return slugs, true
+	case diskKindDetail:
+		if e.Detail == nil {
+			return nil, false
+		}
+		d := e.Detail
+		tags := make([]types.ProConTag, 0, len(d.ProConTags))
+		for _, t := range d.ProConTags {
+			tags = append(tags, types.NewProConTag(t.Name, t.Type, t.Count))
+		}
+		return types.NewProductDetail(types.ProductDetailOptions{
+			Product:            fromDiskProduct(d.Product),
+			Description:        d.Description,
+			Rating:             d.Rating,
+			ReviewCount:        d.ReviewCount,
+			FollowerCount:      d.FollowerCount,
+			MakerComment:       d.MakerComment,
+			WebsiteURL:         d.WebsiteURL,
+			Categories:         d.Categories,
+			SocialLinks:        d.SocialLinks,
+			FirstLaunch:        d.FirstLaunch,
+			LatestLaunch:       d.LatestLaunch,
+			MakerName:          d.MakerName,
+			MakerProfileURL:    d.MakerProfileURL,
+			ProConTags:         tags,
+			PricingInfo:        d.PricingInfo,
+			Links:              d.Links,
+			PricingRaw:         d.PricingRaw,
+			RatingDistribution: d.RatingDist,
+			Related:            fromDiskProducts(d.Related),
+			Topics:             fromDiskCategoryLinks(d.Topics),
+			GalleryImages:      d.GalleryImages,
+		}), true
+	case diskKindSearch:
+		if e.Search == nil {
+			return nil, false
+		}
+		return searchPageCache{
+			products:   fromDiskProducts(e.Search.Products),
+			page:       e.Search.Page,
+			hasPrev:    e.Search.HasPrev,
+			hasNext:    e.Search.HasNext,
+			pagesCount: e.Search.PagesCount,
+		}, true
+	case diskKindCategory:
+		if e.Category == nil {
+			return nil, false
+		}
+		return categoryCache{
+			products:   fromDiskProducts(e.Category.Products),
+			categories: fromDiskCategoryLinks(e.Category.Categories),
+			hasNext:    e.Category.HasNext,
+			pagesCount: e.Category.PagesCount,
+		}, true
+	case diskKindTree:
+		if e.Tree == nil {
+			return nil, false
+		}
+		var parent *types.CategoryLink
+		if e.Tree.Parent != nil {
+			link := fromDiskCategoryLink(*e.Tree.Parent)
+			parent = &link
+		}
+		return types.NewCategoryTree(
+			fromDiskCategoryLink(e.Tree.Category),
+			parent,
+			fromDiskCategoryLinks(e.Tree.Children),
+			e.Tree.Hierarchical,
+		), true
+	case diskKindHistory:
+		history := make([]types.RankPoint, 0, len(e.History))
+		for _, p := range e.History {
+			history = append(history, types.NewRankPoint(p.Date, p.Rank))
+		}
+		return history, true
+	default:
+		return nil, false
+	}
+}
+
+func toDiskProduct(p types.Product) diskProduct {
+	return diskProduct{
+		Name:         p.Name(),
+		Tagline:      p.Tagline(),
+		Categories:   p.Categories(),
+		VoteCount:    p.VoteCount(),
+		CommentCount: p.CommentCount(),
+		Slug:         p.Slug(),
+		ThumbnailURL: p.ThumbnailURL(),
+		Rank:         p.Rank(),
+		Rating:       p.Rating(),
+		Offline:      p.Offline(),
+	}
+}
+
+func fromDiskProduct(p diskProduct) types.Product {
+	return types.NewProduct(p.Name, p.Tagline, p.Categories, p.VoteCount, p.CommentCount, p.Slug, p.ThumbnailURL, p.Rank, p.Rating, p.Offline)
+}
+
+func toDiskProducts(products []types.Product) []diskProduct {
+	out := make([]diskProduct, 0, len(products))
+	for _, p := range products {
+		out = append(out, toDiskProduct(p))
+	}
+	return out
+}
+
+func fromDiskProducts(products []diskProduct) []types.Product {
+	out := make([]types.Product, 0, len(products))
+	for _, p := range products {
+		out = append(out, fromDiskProduct(p))
+	}
+	return out
+}
+
+func toDiskCategoryLink(c types.CategoryLink) diskCategoryLink {
+	return diskCategoryLink{Name: c.Name(), Slug: c.Slug()}
+}
+
+func fromDiskCategoryLink(c diskCategoryLink) types.CategoryLink {
+	return types.NewCategoryLink(c.Name, c.Slug)
+}
+
+func toDiskCategoryLinks(links []types.CategoryLink) []diskCategoryLink {
+	if links == nil {
+		return nil
+	}
+	out := make([]diskCategoryLink, 0, len(links))
+	for _, c := range links {
+		out = append(out, toDiskCategoryLink(c))
+	}
+	return out
+}
+
+func fromDiskCategoryLinks(links []diskCategoryLink) []types.CategoryLink {
+	if links == nil {
+		return nil
+	}
+	out := make([]types.CategoryLink, 0, len(links))
+	for _, c := range links {
+		out = append(out, fromDiskCategoryLink(c))
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func newTestDiskCache(t *testing.T, dir string) *DiskCache {
+	t.Helper()
+	c, err := NewDiskCache(dir, time.Hour, 0)
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+	return c
+}
+
+func TestDiskCacheContract(t *testing.T) {
+	testCacheContract(t, func(t *testing.T) Cache { return newTestDiskCache(t, t.TempDir()) })
+}
+
+func TestPersistentCache(t *testing.T) {
+	if New().PersistentCache() {
+		t.Error("the memory cache reported persistent")
+	}
+	if !NewWithCache(newTestDiskCache(t, t.TempDir())).PersistentCache() {
+		t.Error("the disk cache reported not persistent")
+	}
+}
+
+func TestDiskCacheSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	launch := time.Date(2026, 2, 18, 8, 1, 0, 0, time.UTC)
+	product := types.NewProduct("Demo", "A demo", []string{"AI"}, 120, 8, "demo", "https://img/demo.png", 1, 4.5, true)
+	parent := types.NewCategoryLink("AI", "ai")
+	values := map[string]any{
+		"leaderboard": []types.Product{product},
+		"featured":    map[string]time.Time{"demo": launch},
+		"detail": types.NewProductDetail(types.ProductDetailOptions{
+			Product:            product,
+			Description:        "desc",
+			Rating:             4.5,
+			ReviewCount:        10,
+			FollowerCount:      300,
+			MakerComment:       "hi",
+			WebsiteURL:         "https://demo.dev",
+			Categories:         []string{"AI"},
+			SocialLinks:        []string{"https://x.com/demo"},
+			FirstLaunch:        launch,
+			LatestLaunch:       launch.AddDate(0, 1, 0),
+			MakerName:          "Maker",
+			MakerProfileURL:    "https://ph/@maker",
+			ProConTags:         []types.ProConTag{types.NewProConTag("Fast", "Positive", 3)},
+			PricingInfo:        "Free",
+			Links:              map[string][]string{types.LinkWebsite: {"https://demo.dev"}},
+			PricingRaw:         []string{"From $9/mo"},
+			RatingDistribution: [5]int{0, 1, 0, 3, 6},
+			Related:            []types.Product{types.NewProduct("Demo Lite", "Smaller", nil, 0, 0, "demo-lite", "https://img/lite.png", 0, 0, false)},
+			Topics:             []types.CategoryLink{types.NewCategoryLink("AI", "artificial-intelligence")},
+			GalleryImages:      []string{"https://ph-files.imgix.net/shot.png"},
+		}),
+		"search":            searchPageCache{products: []types.Product{product}, page: 2, hasPrev: true, pagesCount: 3},
+		"category":          categoryCache{products: []types.Product{product}, categories: []types.CategoryLink{parent}, hasNext: true, pagesCount: 32},
+		"history":           []types.RankPoint{types.NewRankPoint(time.Date(2026, 2, 18, 0, 0, 0, 0, time.UTC), 3)},
+		"tree":              types.NewCategoryTree(types.NewCategoryLink("AI Agents", "ai-agents"), &parent, []types.CategoryLink{types.NewCategoryLink("Coding", "coding")}, true),
+		"hydration-only":    map[string]bool{"demo": true, "demo-lite": true},
+		"no-hydration-only": map[string]bool{},
+	}
+
+	first := newTestDiskCache(t, dir)
+	for key, value := range values {
+		first.Set(key, value)
+	}
+
+	second := newTestDiskCache(t, dir)
+	for key, want := range values {
+		got, ok := second.Get(key)
+		if !ok {
+			t.Errorf("Get(%s) missed after restart", key)
+			continue
+		}
+		if key == "featured" {
+			if !got.(map[string]time.Time)["demo"].Equal(launch) {
+				t.Errorf("featured = %v", got)
+			}
+			continue
+		}
+		if key == "detail" {
+			gd, wd := got.(types.ProductDetail), want.(types.ProductDetail)
+			if !gd.FirstLaunchDate().Equal(wd.FirstLaunchDate()) || !gd.LatestLaunchDate().Equal(wd.LatestLaunchDate()) {
+				t.Errorf("detail launch dates = %v, %v", gd.FirstLaunchDate(), gd.LatestLaunchDate())
+			}
+			// Compare the rest with launch dates normalized.
+			got = types.NewProductDetail(types.ProductDetailOptions{
+				Product:            gd.Product(),
+				Description:        gd.Description(),
+				Rating:             gd.Rating(),
+				ReviewCount:        gd.ReviewCount(),
+				FollowerCount:      gd.FollowerCount(),
+				MakerComment:       gd.MakerComment(),
+				WebsiteURL:         gd.WebsiteURL(),
+				Categories:         gd.Categories(),
+				SocialLinks:        gd.SocialLinks(),
+				FirstLaunch:        wd.FirstLaunchDate(),
+				LatestLaunch:       wd.LatestLaunchDate(),
+				MakerName:          gd.MakerName(),
+				MakerProfileURL:    gd.MakerProfileURL(),
+				ProConTags:         gd.ProConTags(),
+				PricingInfo:        gd.PricingInfo(),
+				Links:              gd.Links(),
+				PricingRaw:         gd.PricingRaw(),
+				RatingDistribution: gd.RatingDistribution(),
+				Related:            gd.RelatedLaunches(),
+				Topics:             gd.Topics(),
+				GalleryImages:      gd.GalleryImages(),
+			})
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Get(%s) = %#v, want %#v", key, got, want)
+		}
+	}
+}
+
+func TestDiskCacheExpiry(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestDiskCache(t, dir)
+	now := time.Date(2026, 2, 18, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	c.Set("k", []types.Product{testCacheProduct("a")})
+
+	now = now.Add(59 * time.Minute)
+	if _, ok := c.Get("k"); !ok {
+		t.Fatal("entry expired before its TTL")
+	}
+
+	// A fresh instance reading from disk must also honor the TTL.
+	reopened := newTestDiskCache(t, dir)
+	reopened.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if _, ok := reopened.Get("k"); ok {
+		t.Fatal("expired entry returned from disk")
+	}
+	if _, err := os.Stat(c.path("k")); !os.IsNotExist(err) {
+		t.Fatalf("expired file not removed: %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("expired entry returned from memory")
+	}
+}
+
+func TestDiskCacheIgnoresCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestDiskCache(t, dir)
+	if err := os.WriteFile(c.path("k"), []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("write corrupt file: %v", err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("corrupt file returned a value")
+	}
+	if _, err := os.Stat(c.path("k")); !os.IsNotExist(err) {
+		t.Fatalf("corrupt file not removed: %v", err)
+	}
+
+	c.Set("k", []types.Product{testCacheProduct("a")})
+	if v, ok := newTestDiskCache(t, dir).Get("k"); !ok || len(v.([]types.Product)) != 1 {
+		t.Fatalf("re-fetched value not persisted: %v, %v", v, ok)
+	}
+}
+
+func TestDiskCacheSizeCap(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, time.Hour, 600)
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+	base := time.Now()
+	for i, key := range []string{"a", "b", "c", "d"} {
+		c.Set(key, []types.Product{testCacheProduct(key)})
+		// Space out modification times so eviction order is deterministic.
+		mod := base.Add(time.Duration(i) * time.Second)
+		os.Chtimes(c.path(key), mod, mod)
+	}
+	c.Set("e", []types.Product{testCacheProduct("e")})
+
+	var total int64
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+diskCacheExt))
+	for _, f := range files {
+		info, _ := os.Stat(f)
+		total += info.Size()
+	}
+	if total > c.maxSize {
+		t.Fatalf("cache dir is %d bytes, cap %d", total, c.maxSize)
+	}
+	if _, err := os.Stat(c.path("a")); !os.IsNotExist(err) {
+		t.Fatal("oldest entry not evicted")
+	}
+	if _, ok := c.mem["a"]; ok {
+		t.Fatal("evicted entry still held in memory")
+	}
+	if c.size != total {
+		t.Fatalf("tracked size %d, dir holds %d bytes", c.size, total)
+	}
+	if reopened := newTestDiskCache(t, dir); reopened.size != total {
+		t.Fatalf("reopened cache tracks %d bytes, dir holds %d", reopened.size, total)
+	}
+}
+
+func testCacheProduct(slug string) types.Product {
+	return types.NewProduct(slug, slug+" tagline", nil, 1, 1, slug, "", 1, 0, false)
+}
@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// HealthCheck fetches today's daily leaderboard once, bypassing the cache
+// and retries, and reports whether Product Hunt served a usable page. A
+// challenge page, a 403 or 429, or an open circuit breaker report
+// HealthBlocked; network failures and other statuses report HealthError.
+// The latency covers the request and reading its body.
+func (s *Scraper) HealthCheck(ctx context.Context) types.HealthReport {
+	req, err := http.NewRequestWithContext(ctx, "GET", LeaderboardURL(types.Daily, types.Today()), nil)
+	if err != nil {
+		return types.NewHealthReport(types.HealthError, 0, fmt.Sprintf("create request: %v", err))
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	start := s.now()
+	resp, err := s.attempt(req)
+	latency := s.now().Sub(start)
+	if err != nil {
+		if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrCloudflareChallenge) {
+			return types.NewHealthReport(types.HealthBlocked, latency, err.Error())
+		}
+		return types.NewHealthReport(types.HealthError, latency, err.Error())
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		body, _ := io.ReadAll(resp.Body)
+		if looksLikeCloudflareChallenge(string(body)) {
+			return types.NewHealthReport(types.HealthBlocked, latency, "served a Cloudflare challenge page")
+		}
+		return types.NewHealthReport(types.HealthOK, latency, "")
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return types.NewHealthReport(types.HealthBlocked, latency, fmt.Sprintf("status %d", resp.StatusCode))
+	default:
+		return types.NewHealthReport(types.HealthError, latency, fmt.Sprintf("unexpected status code: %d", resp.StatusCode))
+	}
+}
@@ -0,0 +1,89 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+const challengePage = `<html><head><title>Just a moment...</title></head><body><div id="cf-challenge"></div></body></html>`
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus types.HealthStatus
+	}{
+		{"ok", func(w http.ResponseWriter, _ *http.Request) { io.WriteString(w, "<html>leaderboard</html>") }, types.HealthOK},
+		{"challenge page", func(w http.ResponseWriter, _ *http.Request) { io.WriteString(w, challengePage) }, types.HealthBlocked},
+		{"rate limited", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusTooManyRequests) }, types.HealthBlocked},
+		{"server error", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusInternalServerError) }, types.HealthError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				tt.handler(w, r)
+			}))
+			defer srv.Close()
+
+			s := New()
+			redirectTo(t, s, srv.URL)
+			now := time.Date(2025, 2, 18, 12, 0, 0, 0, time.UTC)
+			s.now = func() time.Time {
+				now = now.Add(40 * time.Millisecond)
+				return now
+			}
+			// A cached leaderboard must not satisfy the check.
+			s.cache.Set(LeaderboardURL(types.Daily, types.Today()), []types.Product{})
+
+			report := s.HealthCheck(context.Background())
+			if report.Status() != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", report.Status(), report.Detail(), tt.wantStatus)
+			}
+			if report.Latency() != 40*time.Millisecond {
+				t.Errorf("latency = %v, want 40ms", report.Latency())
+			}
+			if (report.Detail() == "") != (tt.wantStatus == types.HealthOK) {
+				t.Errorf("detail = %q for status %s", report.Detail(), report.Status())
+			}
+			if requests != 1 {
+				t.Errorf("requests = %d, want exactly 1 (no cache, no retries)", requests)
+			}
+		})
+	}
+
+	t.Run("circuit open", func(t *testing.T) {
+		s := New()
+		s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatalf("request sent while the circuit is open: %s", r.URL)
+			return nil, nil
+		})
+		breaker := NewCircuitBreaker(1, time.Hour, time.Hour)
+		breaker.recordBlock()
+		s.SetCircuitBreaker(breaker)
+		if report := s.HealthCheck(context.Background()); report.Status() != types.HealthBlocked {
+			t.Fatalf("status = %s, want blocked", report.Status())
+		}
+	})
+}
+
+// redirectTo sends every request s makes to the test server at target.
+func redirectTo(t *testing.T, s *Scraper, target string) {
+	t.Helper()
+	u, err := url.Parse(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r.URL.Scheme, r.URL.Host = u.Scheme, u.Host
+		return http.DefaultTransport.RoundTrip(r)
+	})
+}
@@ -0,0 +1,50 @@
+package scraper
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// rankHistoryRe matches a launch post in the detail page's Apollo cache:
+// its id, creation time, daily rank and the slug of the product it belongs to.
+var rankHistoryRe = regexp.MustCompile(`"__typename":"Post","id":"(\d+)","createdAt":"([^"]+)"[^{}]*?"dailyRank":"(\d+)"[^{}]*?"product":\{"__typename":"Product","id":"\d+","slug":"([^"]+)"`)
+
+// ParseRankHistory extracts the daily rank of each of slug's launches from a
+// product detail page, oldest first. Posts of other products on the page are
+// ignored. It returns an empty slice when the page carries no ranks.
+func ParseRankHistory(reader io.Reader, slug string) ([]types.RankPoint, error) {
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []types.RankPoint{}
+	seen := make(map[string]struct{})
+	for _, m := range rankHistoryRe.FindAllStringSubmatch(string(raw), -1) {
+		postID, createdAt, rankStr, postSlug := m[1], m[2], m[3], m[4]
+		if postSlug != slug {
+			continue
+		}
+		// The Apollo cache repeats posts; keep one point per launch.
+		if _, ok := seen[postID]; ok {
+			continue
+		}
+		launched, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			continue
+		}
+		rank, err := strconv.Atoi(rankStr)
+		if err != nil || rank <= 0 {
+			continue
+		}
+		seen[postID] = struct{}{}
+		points = append(points, types.NewRankPoint(types.DayIn(launched), rank))
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Date().Before(points[j].Date()) })
+	return points, nil
+}
@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRankHistory(t *testing.T) {
+	f, err := os.Open("../testdata/product_detail.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	history, err := ParseRankHistory(f, "tanka")
+	if err != nil {
+		t.Fatalf("ParseRankHistory: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("got %d points, want 1 (duplicate cache entries collapsed)", len(history))
+	}
+	if got := history[0].Date().Format(time.DateOnly); got != "2025-02-18" || history[0].Rank() != 1 {
+		t.Fatalf("point = %s #%d, want 2025-02-18 #1", got, history[0].Rank())
+	}
+}
+
+func TestParseRankHistoryRelaunches(t *testing.T) {
+	post := func(id, createdAt, rank, slug string) string {
+		return `{"__typename":"Post","id":"` + id + `","createdAt":"` + createdAt + `","slug":"p` + id + `","dailyRank":"` + rank +
+			`","weeklyRank":"9","product":{"__typename":"Product","id":"1","slug":"` + slug + `"}}`
+	}
+	html := `<script>` + strings.Join([]string{
+		post("3", "2026-01-10T00:01:00-08:00", "4", "demo"),
+		post("1", "2025-06-02T00:01:00-07:00", "12", "demo"),
+		post("2", "2025-09-01T00:01:00-07:00", "7", "other"),
+	}, ",") + `</script>`
+
+	history, err := ParseRankHistory(strings.NewReader(html), "demo")
+	if err != nil {
+		t.Fatalf("ParseRankHistory: %v", err)
+	}
+	var got []string
+	for _, p := range history {
+		got = append(got, fmt.Sprintf("%s#%d", p.Date().Format(time.DateOnly), p.Rank()))
+	}
+	if strings.Join(got, ",") != "2025-06-02#12,2026-01-10#4" {
+		t.Fatalf("history = %v", got)
+	}
+
+	if history, _ := ParseRankHistory(strings.NewReader("<html></html>"), "demo"); history == nil || len(history) != 0 {
+		t.Fatalf("page without ranks = %v, want empty slice", history)
+	}
+}
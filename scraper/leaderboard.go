@@ -2,27 +2,45 @@ package scraper
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/qyinm/phtui/types"
 )
 
+// ErrParseEmpty is returned when a page is recognizably a leaderboard but no
+// products could be parsed from it, which usually means Product Hunt changed
+// its markup rather than that the leaderboard is empty.
+var ErrParseEmpty = errors.New("leaderboard page parsed to zero products; the page layout may have changed")
+
 // ParseLeaderboard parses Product Hunt leaderboard HTML and returns a slice of Products.
-// It expects SSR HTML from Product Hunt's Next.js pages.
-func ParseLeaderboard(reader io.Reader) ([]types.Product, error) {
+// It expects SSR HTML from Product Hunt's Next.js pages. The period is the
+// leaderboard being parsed; it decides which hydration rank is authoritative.
+// Pages that aren't leaderboards yield no products and no error; a leaderboard
+// page with no parseable products yields ErrParseEmpty.
+func ParseLeaderboard(reader io.Reader, period types.Period) ([]types.Product, error) {
 	raw, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
+	products, _, err := parseLeaderboard(raw, period)
+	return products, err
+}
 
+// parseLeaderboard parses a leaderboard page as ParseLeaderboard does and
+// also returns the slugs of the products only its hydration data listed.
+func parseLeaderboard(raw []byte, period types.Period) ([]types.Product, map[string]bool, error) {
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(raw))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var products []types.Product
@@ -111,19 +129,23 @@ func ParseLeaderboard(reader io.Reader) ([]types.Product, error) {
 			products[i].Slug(),
 			products[i].ThumbnailURL(),
 			i+1,
+			products[i].Rating(),
+			products[i].Offline(),
 		)
 	}
 
 	// Hydration JSON often includes more leaderboard posts than SSR HTML.
 	// Merge any missing posts by slug.
-	hydrationProducts := parseHydrationLeaderboardProducts(string(raw))
+	hydrationEntries := leaderboardHydration(raw, period)
+	fromHydration := make(map[string]bool)
 	indexBySlug := make(map[string]int, len(products))
 	for i, p := range products {
 		if p.Slug() != "" {
 			indexBySlug[p.Slug()] = i
 		}
 	}
-	for _, hp := range hydrationProducts {
+	for _, entry := range hydrationEntries {
+		hp := entry.product
 		if hp.Slug() == "" {
 			continue
 		}
@@ -149,8 +171,11 @@ func ParseLeaderboard(reader io.Reader) ([]types.Product, error) {
 			if hp.CommentCount() > 0 {
 				commentCount = hp.CommentCount()
 			}
+			// SSR order and hydration ranks can disagree. Only a hydration rank
+			// taken from the requested period's field overrides the SSR position;
+			// ranks from other periods would reorder the list incorrectly.
 			rank := existing.Rank()
-			if hp.Rank() > 0 {
+			if hp.Rank() > 0 && entry.rankPeriod == period {
 				rank = hp.Rank()
 			}
 			products[idx] = types.NewProduct(
@@ -162,12 +187,15 @@ func ParseLeaderboard(reader io.Reader) ([]types.Product, error) {
 				existing.Slug(),
 				existing.ThumbnailURL(),
 				rank,
+				existing.Rating(),
+				existing.Offline(),
 			)
 			continue
 		}
 		seenBySlug[hp.Slug()] = struct{}{}
 		products = append(products, hp)
 		indexBySlug[hp.Slug()] = len(products) - 1
+		fromHydration[hp.Slug()] = true
 	}
 
 	sort.SliceStable(products, func(i, j int) bool {
@@ -197,8 +225,13 @@ func ParseLeaderboard(reader io.Reader) ([]types.Product, error) {
 		deduped = append(deduped, p)
 	}
 
+	// Only products that survived the dedup keep their attribution.
+	hydrationOnly := make(map[string]bool)
 	for i := range deduped {
 		p := deduped[i]
+		if fromHydration[p.Slug()] {
+			hydrationOnly[p.Slug()] = true
+		}
 		deduped[i] = types.NewProduct(
 			p.Name(),
 			p.Tagline(),
@@ -208,10 +241,18 @@ func ParseLeaderboard(reader io.Reader) ([]types.Product, error) {
 			p.Slug(),
 			p.ThumbnailURL(),
 			i+1,
+			p.Rating(),
+			p.Offline(),
 		)
 	}
 
-	return deduped, nil
+	// Both the HTML and hydration paths came up empty on what is clearly a
+	// leaderboard page: report it instead of passing for an empty day.
+	if len(deduped) == 0 && doc.Find("[data-test='leaderboard-title']").Length() > 0 {
+		return nil, nil, ErrParseEmpty
+	}
+
+	return deduped, hydrationOnly, nil
 }
 
 var topicNameRe = regexp.MustCompile(`"name":"([^"]+)"`)
@@ -225,23 +266,65 @@ var topicNameRe = regexp.MustCompile(`"name":"([^"]+)"`)
 //	 ...,"topics":{"__typename":"TopicConnection","edges":[...]},
 //	 ...,"latestScore":N,...,"commentsCount":N}
 var hydrationPostRe = regexp.MustCompile(
-	`"__typename":"Post","id":"[^"]+","name":"([^"]+)","slug":"[^"]+","tagline":"([^"]*)"`)
+	`"__typename":"Post","id":"[^"]+","name":"([^"]+)","slug":"([^"]+)","tagline":"([^"]*)"`)
 var productSlugRe = regexp.MustCompile(
 	`"product":\{"__typename":"Product","id":"[^"]+","slug":"([^"]+)"`)
 var dailyRankRe = regexp.MustCompile(`"dailyRank":"(\d+)"`)
 var weeklyRankRe = regexp.MustCompile(`"weeklyRank":"(\d+)"`)
 var monthlyRankRe = regexp.MustCompile(`"monthlyRank":"(\d+)"`)
+
+// rankRes maps each period to the regexp for its rank field.
+var rankRes = map[types.Period]*regexp.Regexp{
+	types.Daily:   dailyRankRe,
+	types.Weekly:  weeklyRankRe,
+	types.Monthly: monthlyRankRe,
+}
 var latestScoreRe = regexp.MustCompile(`"latestScore":(\d+)`)
 var commentsCountRe = regexp.MustCompile(`"commentsCount":(\d+)`)
+var featuredAtRe = regexp.MustCompile(`"featuredAt":"([^"]+)"`)
 var topicsEdgesRe = regexp.MustCompile(`"topics":\{"__typename":"TopicConnection","edges":\[(.*?)\]\}`)
 
-func parseHydrationLeaderboardProducts(raw string) []types.Product {
+// hydrationEntry is a leaderboard post parsed from hydration JSON along with
+// the period whose rank field supplied its rank.
+type hydrationEntry struct {
+	product    types.Product
+	rankPeriod types.Period
+	featuredAt time.Time
+}
+
+// ParseLeaderboardFeaturedTimes returns each leaderboard post's featuredAt
+// time from the hydration data, keyed by product slug. Posts without a
+// parseable time are omitted.
+func ParseLeaderboardFeaturedTimes(reader io.Reader, period types.Period) (map[string]time.Time, error) {
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[string]time.Time)
+	for _, entry := range leaderboardHydration(raw, period) {
+		if !entry.featuredAt.IsZero() {
+			times[entry.product.Slug()] = entry.featuredAt
+		}
+	}
+	return times, nil
+}
+
+// leaderboardHydration returns the posts in the page's hydration data: the
+// Apollo homefeed entries, or failing those, the __NEXT_DATA__ payload.
+func leaderboardHydration(raw []byte, period types.Period) []hydrationEntry {
+	if entries := parseHydrationLeaderboardProducts(string(raw), period); len(entries) > 0 {
+		return entries
+	}
+	return parseNextDataLeaderboardProducts(raw, period)
+}
+
+func parseHydrationLeaderboardProducts(raw string, period types.Period) []hydrationEntry {
 	// Product Hunt SSR embeds Apollo cache data in a script element.
 	// Leaderboard posts live inside "homefeedItems" connection edges.
 	// There can be multiple occurrences (duplicate cache entries); we dedup by product slug.
 	const edgesStartMarker = `"homefeedItems":{"__typename":"HomefeedItemConnection","edges":[`
 
-	var products []types.Product
+	var entries []hydrationEntry
 	seen := make(map[string]struct{})
 
 	searchFrom := 0
@@ -272,14 +355,17 @@ func parseHydrationLeaderboardProducts(raw string) []types.Product {
 			chunk := edgesBlob[loc[0]:chunkEnd]
 
 			name := decodeJSONEscaped(edgesBlob[loc[2]:loc[3]])
-			tagline := decodeJSONEscaped(edgesBlob[loc[4]:loc[5]])
-
-			// Extract product slug (the one used for /products/ URLs)
-			pSlugMatch := productSlugRe.FindStringSubmatch(chunk)
-			if len(pSlugMatch) < 2 || pSlugMatch[1] == "" {
-				continue
+			postSlug := decodeJSONEscaped(edgesBlob[loc[4]:loc[5]])
+			tagline := decodeJSONEscaped(edgesBlob[loc[6]:loc[7]])
+
+			// Prefer the product slug (used for /products/ URLs); posts that
+			// aren't attached to a product fall back to their /posts/ slug.
+			var slug string
+			if pSlugMatch := productSlugRe.FindStringSubmatch(chunk); len(pSlugMatch) >= 2 {
+				slug = decodeJSONEscaped(pSlugMatch[1])
+			} else if postSlug != "" {
+				slug = PostSlug(postSlug)
 			}
-			slug := decodeJSONEscaped(pSlugMatch[1])
 
 			if name == "" || slug == "" {
 				continue
@@ -288,18 +374,7 @@ func parseHydrationLeaderboardProducts(raw string) []types.Product {
 				continue
 			}
 
-			// Extract ranks
-			dailyRank := extractInt(dailyRankRe, chunk)
-			weeklyRank := extractInt(weeklyRankRe, chunk)
-			monthlyRank := extractInt(monthlyRankRe, chunk)
-
-			rank := dailyRank
-			if rank == 0 {
-				rank = weeklyRank
-			}
-			if rank == 0 {
-				rank = monthlyRank
-			}
+			rank, rankPeriod := selectHydrationRank(chunk, period)
 			if rank <= 0 {
 				continue
 			}
@@ -319,21 +394,49 @@ func parseHydrationLeaderboardProducts(raw string) []types.Product {
 				}
 			}
 
+			var featuredAt time.Time
+			if fm := featuredAtRe.FindStringSubmatch(chunk); len(fm) >= 2 {
+				featuredAt, _ = time.Parse(time.RFC3339, fm[1])
+			}
+
 			seen[slug] = struct{}{}
-			products = append(products, types.NewProduct(
-				name,
-				tagline,
-				categories,
-				voteCount,
-				commentCount,
-				slug,
-				"",
-				rank,
-			))
+			entries = append(entries, hydrationEntry{
+				product: types.NewProduct(
+					name,
+					tagline,
+					categories,
+					voteCount,
+					commentCount,
+					slug,
+					"",
+					rank,
+					0,
+					false,
+				),
+				rankPeriod: rankPeriod,
+				featuredAt: featuredAt,
+			})
 		}
 	}
 
-	return products
+	return entries
+}
+
+// selectHydrationRank picks the rank field matching period, falling back to
+// daily → weekly → monthly when that field is absent. It also reports which
+// period's field the rank came from.
+func selectHydrationRank(chunk string, period types.Period) (int, types.Period) {
+	if re, ok := rankRes[period]; ok {
+		if rank := extractInt(re, chunk); rank > 0 {
+			return rank, period
+		}
+	}
+	for _, p := range []types.Period{types.Daily, types.Weekly, types.Monthly} {
+		if rank := extractInt(rankRes[p], chunk); rank > 0 {
+			return rank, p
+		}
+	}
+	return 0, period
 }
 
 func extractInt(re *regexp.Regexp, s string) int {
@@ -344,12 +447,31 @@ func extractInt(re *regexp.Regexp, s string) int {
 	return parseCount(m[1])
 }
 
+// decodeJSONEscaped decodes a JSON string body (without surrounding quotes).
+// JSON decoding handles surrogate pairs and "\/" escapes that strconv.Unquote
+// rejects; Go unquoting and finally the raw text are used as fallbacks.
+// The result is always sanitized to valid UTF-8.
 func decodeJSONEscaped(s string) string {
-	unquoted, err := strconv.Unquote(`"` + s + `"`)
-	if err != nil {
+	var decoded string
+	if err := json.Unmarshal([]byte(`"`+s+`"`), &decoded); err != nil {
+		unquoted, err := strconv.Unquote(`"` + s + `"`)
+		if err != nil {
+			unquoted = s
+		}
+		decoded = unquoted
+	}
+	return sanitizeUTF8(decoded)
+}
+
+// sanitizeUTF8 drops invalid byte sequences and U+FFFD replacement runes
+// (produced for broken surrogates) so rendered text never shows replacement
+// glyphs and display-width math stays accurate.
+func sanitizeUTF8(s string) string {
+	if utf8.ValidString(s) && !strings.ContainsRune(s, utf8.RuneError) {
 		return s
 	}
-	return unquoted
+	s = strings.ToValidUTF8(s, "")
+	return strings.ReplaceAll(s, string(utf8.RuneError), "")
 }
 
 func parseProductCard(s *goquery.Selection) (types.Product, bool) {
@@ -412,7 +534,8 @@ func parseProductCard(s *goquery.Selection) (types.Product, bool) {
 	return types.NewProduct(
 		name, tagline, categories,
 		voteCount, commentCount,
-		slug, thumbnailURL, 0,
+		slug, thumbnailURL, 0, 0,
+		false,
 	), true
 }
 
@@ -1,9 +1,16 @@
 package scraper
 
 import (
+	"bytes"
+	"errors"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/qyinm/phtui/types"
 )
 
 func TestParseLeaderboard_Daily(t *testing.T) {
@@ -13,7 +20,7 @@ func TestParseLeaderboard_Daily(t *testing.T) {
 	}
 	defer f.Close()
 
-	products, err := ParseLeaderboard(f)
+	products, err := ParseLeaderboard(f, types.Daily)
 	if err != nil {
 		t.Fatalf("ParseLeaderboard returned error: %v", err)
 	}
@@ -64,7 +71,7 @@ func TestParseLeaderboard_Weekly(t *testing.T) {
 	}
 	defer f.Close()
 
-	products, err := ParseLeaderboard(f)
+	products, err := ParseLeaderboard(f, types.Weekly)
 	if err != nil {
 		t.Fatalf("ParseLeaderboard returned error: %v", err)
 	}
@@ -107,7 +114,7 @@ func TestParseLeaderboard_Empty(t *testing.T) {
 	}
 	defer f.Close()
 
-	products, err := ParseLeaderboard(f)
+	products, err := ParseLeaderboard(f, types.Daily)
 	if err != nil {
 		t.Fatalf("ParseLeaderboard should not error on empty HTML, got: %v", err)
 	}
@@ -117,10 +124,26 @@ func TestParseLeaderboard_Empty(t *testing.T) {
 	}
 }
 
+func TestParseLeaderboard_UnparsedLayout(t *testing.T) {
+	f, err := os.Open("../testdata/leaderboard_unparsed.html")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer f.Close()
+
+	products, err := ParseLeaderboard(f, types.Daily)
+	if !errors.Is(err, ErrParseEmpty) {
+		t.Fatalf("leaderboard page with unknown markup: err = %v, want ErrParseEmpty", err)
+	}
+	if len(products) != 0 {
+		t.Errorf("expected no products, got %d", len(products))
+	}
+}
+
 func TestParseLeaderboard_Malformed(t *testing.T) {
 	r := strings.NewReader("<html><body><div>not a leaderboard</div></body></html>")
 
-	products, err := ParseLeaderboard(r)
+	products, err := ParseLeaderboard(r, types.Daily)
 	if err != nil {
 		t.Fatalf("ParseLeaderboard should not error on malformed HTML, got: %v", err)
 	}
@@ -129,3 +152,278 @@ func TestParseLeaderboard_Malformed(t *testing.T) {
 		t.Errorf("expected 0 products for malformed HTML, got %d", len(products))
 	}
 }
+
+func TestDecodeJSONEscaped(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Hello", "Hello"},
+		{"escaped unicode", `Caf\u00e9`, "Café"},
+		{"surrogate pair", `\ud83d\ude80 Launch`, "🚀 Launch"},
+		{"escaped slash", `a\/b`, "a/b"},
+		{"escaped quote", `say \"hi\"`, `say "hi"`},
+		{"lone surrogate", `broken \ud83d tail`, "broken  tail"},
+		{"invalid bytes", "bad\xffbyte", "badbyte"},
+		{"invalid escape", `path\qx`, `path\qx`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decodeJSONEscaped(tc.in)
+			if got != tc.want {
+				t.Errorf("decodeJSONEscaped(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("decodeJSONEscaped(%q) returned invalid UTF-8", tc.in)
+			}
+		})
+	}
+}
+
+// hydrationPost builds a single Apollo SSR post node for leaderboard tests.
+// ranks is spliced in verbatim, e.g. `"dailyRank":"1","weeklyRank":"4"`.
+func hydrationPost(name, productSlug, ranks string) string {
+	return `{"__typename":"Post","id":"` + productSlug + `-post","name":"` + name + `","slug":"` + productSlug + `-post","tagline":"` + name + ` tagline",` +
+		`"product":{"__typename":"Product","id":"` + productSlug + `-id","slug":"` + productSlug + `"},` +
+		ranks + `,"latestScore":10,"commentsCount":1}`
+}
+
+func leaderboardHTML(cards []string, posts ...string) string {
+	var b strings.Builder
+	b.WriteString("<html><body><main>")
+	for _, slug := range cards {
+		b.WriteString(`<section data-test="post-item-` + slug + `"><div data-test="post-name-` + slug + `"><a href="/products/` + slug + `">` + strings.ToUpper(slug) + `</a></div><span class="text-secondary">tagline</span></section>`)
+	}
+	b.WriteString("</main><script>")
+	b.WriteString(`"homefeedItems":{"__typename":"HomefeedItemConnection","edges":[`)
+	b.WriteString(strings.Join(posts, ","))
+	b.WriteString(`],"pageInfo":{"__typename":"PageInfo","hasNextPage":false}}`)
+	b.WriteString("</script></body></html>")
+	return b.String()
+}
+
+func TestParseLeaderboard_RankReconciliation(t *testing.T) {
+	tests := []struct {
+		name   string
+		period types.Period
+		posts  []string
+		want   []string
+	}{
+		{
+			name:   "hydration daily rank overrides SSR order for daily",
+			period: types.Daily,
+			posts: []string{
+				hydrationPost("A", "a", `"dailyRank":"2"`),
+				hydrationPost("B", "b", `"dailyRank":"1"`),
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name:   "other-period hydration rank keeps SSR order",
+			period: types.Daily,
+			posts: []string{
+				hydrationPost("A", "a", `"weeklyRank":"2"`),
+				hydrationPost("B", "b", `"weeklyRank":"1"`),
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name:   "weekly rank overrides SSR order for weekly",
+			period: types.Weekly,
+			posts: []string{
+				hydrationPost("A", "a", `"weeklyRank":"2"`),
+				hydrationPost("B", "b", `"weeklyRank":"1"`),
+			},
+			want: []string{"b", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := leaderboardHTML([]string{"a", "b"}, tt.posts...)
+			products, err := ParseLeaderboard(strings.NewReader(html), tt.period)
+			if err != nil {
+				t.Fatalf("ParseLeaderboard: %v", err)
+			}
+			if len(products) != len(tt.want) {
+				t.Fatalf("got %d products, want %d", len(products), len(tt.want))
+			}
+			for i, slug := range tt.want {
+				if products[i].Slug() != slug || products[i].Rank() != i+1 {
+					t.Errorf("products[%d] = %s #%d, want %s #%d", i, products[i].Slug(), products[i].Rank(), slug, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLeaderboard_PeriodSelectsHydrationRank(t *testing.T) {
+	// No SSR cards: every product comes from hydration with all three ranks.
+	html := leaderboardHTML(nil,
+		hydrationPost("A", "a", `"dailyRank":"1","weeklyRank":"3","monthlyRank":"2"`),
+		hydrationPost("B", "b", `"dailyRank":"2","weeklyRank":"1","monthlyRank":"3"`),
+		hydrationPost("C", "c", `"dailyRank":"3","weeklyRank":"2","monthlyRank":"1"`),
+	)
+	tests := []struct {
+		period types.Period
+		want   []string
+	}{
+		{types.Daily, []string{"a", "b", "c"}},
+		{types.Weekly, []string{"b", "c", "a"}},
+		{types.Monthly, []string{"c", "a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period.String(), func(t *testing.T) {
+			products, err := ParseLeaderboard(strings.NewReader(html), tt.period)
+			if err != nil {
+				t.Fatalf("ParseLeaderboard: %v", err)
+			}
+			got := make([]string, 0, len(products))
+			for _, p := range products {
+				got = append(got, p.Slug())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectHydrationRankFallback(t *testing.T) {
+	chunk := `"weeklyRank":"4","monthlyRank":"9"`
+	if rank, p := selectHydrationRank(chunk, types.Daily); rank != 4 || p != types.Weekly {
+		t.Errorf("daily fallback = %d (%s), want 4 (weekly)", rank, p)
+	}
+	if rank, p := selectHydrationRank(chunk, types.Monthly); rank != 9 || p != types.Monthly {
+		t.Errorf("monthly = %d (%s), want 9 (monthly)", rank, p)
+	}
+	if rank, _ := selectHydrationRank(`"latestScore":3`, types.Daily); rank != 0 {
+		t.Errorf("no rank fields = %d, want 0", rank)
+	}
+}
+
+func TestParseLeaderboard_PostOnlyEntry(t *testing.T) {
+	postOnly := `{"__typename":"Post","id":"42","name":"Lonely Launch","slug":"lonely-launch","tagline":"No product page yet",` +
+		`"product":null,"dailyRank":"2","latestScore":7,"commentsCount":0}`
+	html := leaderboardHTML([]string{"alpha"},
+		hydrationPost("Alpha", "alpha", `"dailyRank":"1"`),
+		postOnly,
+	)
+
+	products, err := ParseLeaderboard(strings.NewReader(html), types.Daily)
+	if err != nil {
+		t.Fatalf("ParseLeaderboard: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("products = %d, want 2", len(products))
+	}
+	got := products[1]
+	if got.Slug() != "posts/lonely-launch" {
+		t.Errorf("slug = %q, want %q", got.Slug(), "posts/lonely-launch")
+	}
+	if got.Name() != "Lonely Launch" || got.Rank() != 2 || got.VoteCount() != 7 {
+		t.Errorf("post-only product = %q rank %d votes %d", got.Name(), got.Rank(), got.VoteCount())
+	}
+	if url := ProductURL(got.Slug()); url != "https://www.producthunt.com/posts/lonely-launch" {
+		t.Errorf("ProductURL = %q", url)
+	}
+}
+
+func TestParseLeaderboardHydrationOnly(t *testing.T) {
+	// B has no card and ranks between the SSR products; A's duplicate under
+	// another slug is dropped by the name dedup.
+	html := leaderboardHTML([]string{"a", "c"},
+		hydrationPost("A", "a", `"dailyRank":"1"`),
+		hydrationPost("B", "b", `"dailyRank":"2"`),
+		hydrationPost("C", "c", `"dailyRank":"3"`),
+		hydrationPost("A", "a-launch", `"dailyRank":"4"`),
+	)
+	products, hydrationOnly, err := parseLeaderboard([]byte(html), types.Daily)
+	if err != nil {
+		t.Fatalf("parseLeaderboard: %v", err)
+	}
+	var order []string
+	for _, p := range products {
+		order = append(order, p.Slug())
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	if want := map[string]bool{"b": true}; !reflect.DeepEqual(hydrationOnly, want) {
+		t.Errorf("hydrationOnly = %v, want %v", hydrationOnly, want)
+	}
+
+	// A page whose products all have cards has none.
+	raw, err := os.ReadFile("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if _, got, err := parseLeaderboard(raw, types.Daily); err != nil || len(got) != 0 {
+		t.Errorf("daily fixture hydration-only = %v, %v; want none", got, err)
+	}
+}
+
+func TestParseLeaderboardFeaturedTimes(t *testing.T) {
+	f, err := os.Open("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	times, err := ParseLeaderboardFeaturedTimes(f, types.Daily)
+	if err != nil {
+		t.Fatalf("ParseLeaderboardFeaturedTimes: %v", err)
+	}
+	if len(times) == 0 {
+		t.Fatal("no featured times found")
+	}
+	want, _ := time.Parse(time.RFC3339, "2025-02-18T00:01:00-08:00")
+	for slug, at := range times {
+		if !at.Equal(want) {
+			t.Errorf("featuredAt[%s] = %v, want %v", slug, at, want)
+		}
+	}
+}
+
+func TestParseLeaderboard_NextDataOnly(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/leaderboard_nextdata.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	products, err := ParseLeaderboard(bytes.NewReader(raw), types.Daily)
+	if err != nil {
+		t.Fatalf("ParseLeaderboard: %v", err)
+	}
+	// The sidebar's promoted post and the navigation links aren't leaderboard
+	// entries; Gamma has no product page and keeps its /posts/ slug.
+	want := []struct {
+		name, slug string
+		votes      int
+		categories []string
+	}{
+		{"Alpha éditeur", "alpha", 455, []string{"AI", "Developer Tools"}},
+		{"Beta Notes", "beta-notes", 310, []string{"Productivity"}},
+		{"Gamma", PostSlug("gamma-post"), 120, nil},
+	}
+	if len(products) != len(want) {
+		t.Fatalf("got %d products, want %d: %v", len(products), len(want), products)
+	}
+	for i, w := range want {
+		p := products[i]
+		if p.Name() != w.name || p.Slug() != w.slug || p.VoteCount() != w.votes || p.Rank() != i+1 || !reflect.DeepEqual(p.Categories(), w.categories) {
+			t.Errorf("product %d = %q %q votes=%d rank=%d categories=%v, want %+v", i, p.Name(), p.Slug(), p.VoteCount(), p.Rank(), p.Categories(), w)
+		}
+	}
+	if products[1].Tagline() != "Beta Notes tagline" || products[1].CommentCount() != 12 {
+		t.Errorf("beta tagline %q comments %d", products[1].Tagline(), products[1].CommentCount())
+	}
+
+	featured, err := ParseLeaderboardFeaturedTimes(bytes.NewReader(raw), types.Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := featured["beta-notes"]; !got.Equal(time.Date(2025, 2, 18, 16, 1, 0, 0, time.UTC)) {
+		t.Errorf("beta featured at %v", got)
+	}
+}
@@ -0,0 +1,188 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/qyinm/phtui/types"
+)
+
+// parseNextDataLeaderboardProducts reads leaderboard posts from the page's
+// __NEXT_DATA__ script, for variants whose SSR HTML holds almost nothing and
+// whose data doesn't sit under the "homefeedItems" marker the Apollo parser
+// looks for. Rather than a fixed path, it finds the leaderboard by shape: the
+// largest JSON array of posts (or of edges whose node is a post) anywhere in
+// the payload.
+func parseNextDataLeaderboardProducts(raw []byte, period types.Period) []hydrationEntry {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil
+	}
+	script := strings.TrimSpace(doc.Find("script#__NEXT_DATA__").First().Text())
+	if script == "" {
+		return nil
+	}
+	var data any
+	if err := json.Unmarshal([]byte(script), &data); err != nil {
+		return nil
+	}
+
+	var entries []hydrationEntry
+	seen := make(map[string]struct{})
+	for _, post := range largestPostCollection(data) {
+		entry, ok := nextDataEntry(post, period)
+		if !ok {
+			continue
+		}
+		if _, dup := seen[entry.product.Slug()]; dup {
+			continue
+		}
+		seen[entry.product.Slug()] = struct{}{}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// largestPostCollection walks v and returns the posts of the array holding
+// the most of them. Smaller arrays of posts, such as a sidebar of featured
+// launches, lose to the leaderboard itself.
+func largestPostCollection(v any) []map[string]any {
+	var best []map[string]any
+	var walk func(any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			var posts []map[string]any
+			for _, item := range v {
+				if post, ok := asPost(item); ok {
+					posts = append(posts, post)
+				}
+			}
+			if len(posts) > len(best) {
+				best = posts
+			}
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(v)
+	return best
+}
+
+// asPost returns item, or the node of an edge, when it looks like a
+// leaderboard post: a name and slug plus a Post type or a rank field.
+func asPost(item any) (map[string]any, bool) {
+	obj, ok := item.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if node, ok := obj["node"].(map[string]any); ok {
+		obj = node
+	}
+	if jsonString(obj, "name") == "" || jsonString(obj, "slug") == "" {
+		return nil, false
+	}
+	if jsonString(obj, "__typename") == "Post" {
+		return obj, true
+	}
+	for _, field := range []string{"dailyRank", "weeklyRank", "monthlyRank"} {
+		if _, ok := obj[field]; ok {
+			return obj, true
+		}
+	}
+	return nil, false
+}
+
+// rankFields maps each period to the post's rank field.
+var rankFields = map[types.Period]string{types.Daily: "dailyRank", types.Weekly: "weeklyRank", types.Monthly: "monthlyRank"}
+
+func nextDataEntry(post map[string]any, period types.Period) (hydrationEntry, bool) {
+	// Prefer the product slug (used for /products/ URLs); posts that aren't
+	// attached to a product fall back to their /posts/ slug.
+	slug := PostSlug(jsonString(post, "slug"))
+	if product, ok := post["product"].(map[string]any); ok && jsonString(product, "slug") != "" {
+		slug = jsonString(product, "slug")
+	}
+
+	rank, rankPeriod := jsonInt(post, rankFields[period]), period
+	for _, p := range []types.Period{types.Daily, types.Weekly, types.Monthly} {
+		if rank > 0 {
+			break
+		}
+		rank, rankPeriod = jsonInt(post, rankFields[p]), p
+	}
+	if rank <= 0 {
+		return hydrationEntry{}, false
+	}
+
+	votes := jsonInt(post, "latestScore")
+	if votes == 0 {
+		votes = jsonInt(post, "votesCount")
+	}
+
+	var categories []string
+	var topics []any
+	switch t := post["topics"].(type) {
+	case map[string]any:
+		topics, _ = t["edges"].([]any)
+	case []any:
+		topics = t
+	}
+	for _, topic := range topics {
+		obj, ok := topic.(map[string]any)
+		if !ok {
+			continue
+		}
+		if node, ok := obj["node"].(map[string]any); ok {
+			obj = node
+		}
+		if name := strings.TrimSpace(jsonString(obj, "name")); name != "" {
+			categories = append(categories, name)
+		}
+	}
+
+	featuredAt, _ := time.Parse(time.RFC3339, jsonString(post, "featuredAt"))
+	return hydrationEntry{
+		product: types.NewProduct(
+			jsonString(post, "name"),
+			jsonString(post, "tagline"),
+			categories,
+			votes,
+			jsonInt(post, "commentsCount"),
+			slug,
+			"",
+			rank,
+			0,
+			false,
+		),
+		rankPeriod: rankPeriod,
+		featuredAt: featuredAt,
+	}, true
+}
+
+func jsonString(obj map[string]any, key string) string {
+	s, _ := obj[key].(string)
+	return sanitizeUTF8(s)
+}
+
+// jsonInt reads a count that may be a JSON number or a numeric string, as
+// Apollo serializes ranks.
+func jsonInt(obj map[string]any, key string) int {
+	switch v := obj[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
@@ -1,10 +1,12 @@
 package scraper
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,15 +16,47 @@ import (
 	"github.com/qyinm/phtui/types"
 )
 
+// noLongerOnlineRe matches the hydration flag Product Hunt sets on hidden or
+// offline products.
+var noLongerOnlineRe = regexp.MustCompile(`"isNoLongerOnline":\s*true`)
+
+// unavailableHeadingRe matches the visible heading of an unavailable
+// product's stub page.
+var unavailableHeadingRe = regexp.MustCompile(`(?i)^(this product is )?no longer available\.?$|^page not found\.?$`)
+
+// isUnavailableStub reports whether a page without the product header is
+// the stub Product Hunt serves for a hidden or offline product: it carries
+// the offline flag or the stub's heading. Other page text, scripts
+// included, isn't consulted.
+func isUnavailableStub(doc *goquery.Document, raw []byte) bool {
+	if noLongerOnlineRe.Match(raw) {
+		return true
+	}
+	found := false
+	doc.Find("h1, h2").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		found = unavailableHeadingRe.MatchString(strings.TrimSpace(s.Text()))
+		return !found
+	})
+	return found
+}
+
 // ParseProductDetail parses a Product Hunt product detail page and extracts
-// product information from the rendered HTML.
+// product information from the rendered HTML. A stub page for a hidden or
+// offline product yields types.ErrProductUnavailable.
 func ParseProductDetail(reader io.Reader) (types.ProductDetail, error) {
-	doc, err := goquery.NewDocumentFromReader(reader)
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return types.ProductDetail{}, fmt.Errorf("read HTML: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(raw))
 	if err != nil {
 		return types.ProductDetail{}, fmt.Errorf("parse HTML: %w", err)
 	}
 
 	header := doc.Find("[data-test='header']")
+	if header.Length() == 0 && isUnavailableStub(doc, raw) {
+		return types.ProductDetail{}, types.ErrProductUnavailable
+	}
 
 	// Product name from h1
 	name := strings.TrimSpace(header.Find("h1").First().Text())
@@ -45,7 +79,9 @@ func ParseProductDetail(reader io.Reader) (types.ProductDetail, error) {
 	// Website URL from visit-website button
 	websiteURL, _ := doc.Find("a[data-test='visit-website-button']").Attr("href")
 	categories := parseDetailCategories(doc)
+	topics := parseDetailTopics(doc)
 	socialLinks := parseSocialLinks(doc)
+	links := parseLinks(doc, websiteURL, socialLinks)
 
 	// Description: short product blurb
 	description := strings.TrimSpace(
@@ -59,28 +95,102 @@ func ParseProductDetail(reader io.Reader) (types.ProductDetail, error) {
 	makerComment := parseMakerComment(doc)
 
 	// New fields
-	launchDate := parseLaunchDate(doc)
+	firstLaunch, latestLaunch := parseLaunchDates(doc)
 	makerName, makerProfileURL := parseMakerInfo(doc)
 	proConTags := parseProConTags(doc)
 	pricingInfo := parsePricing(doc)
-
-	product := types.NewProduct(name, tagline, nil, 0, 0, slug, thumbnailURL, 0)
-	detail := types.NewProductDetail(product, description, rating, reviewCount, followerCount, makerComment, websiteURL, categories, socialLinks, launchDate, makerName, makerProfileURL, proConTags, pricingInfo)
+	pricingRaw := parsePricingRaw(doc)
+	ratingDist := parseRatingDistribution(doc)
+	related := parseRelatedLaunches(doc, slug)
+	voteCount, commentCount := parseDetailCounts(string(raw), slug)
+	gallery := parseGallery(doc)
+
+	product := types.NewProduct(name, tagline, nil, voteCount, commentCount, slug, thumbnailURL, 0, 0, false)
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:            product,
+		Description:        description,
+		Rating:             rating,
+		ReviewCount:        reviewCount,
+		FollowerCount:      followerCount,
+		MakerComment:       makerComment,
+		WebsiteURL:         websiteURL,
+		Categories:         categories,
+		SocialLinks:        socialLinks,
+		FirstLaunch:        firstLaunch,
+		LatestLaunch:       latestLaunch,
+		MakerName:          makerName,
+		MakerProfileURL:    makerProfileURL,
+		ProConTags:         proConTags,
+		PricingInfo:        pricingInfo,
+		Links:              links,
+		PricingRaw:         pricingRaw,
+		RatingDistribution: ratingDist,
+		Related:            related,
+		Topics:             topics,
+		GalleryImages:      gallery,
+	})
 
 	return detail, nil
 }
 
 // parseSlugFromDoc extracts the product slug from the canonical URL.
+// Launch post pages (/posts/{slug}) yield a PostSlug.
 func parseSlugFromDoc(doc *goquery.Document) string {
 	href, exists := doc.Find("link[rel='canonical']").Attr("href")
 	if !exists {
 		return ""
 	}
-	parts := strings.Split(href, "/products/")
-	if len(parts) < 2 {
-		return ""
+	if parts := strings.Split(href, "/products/"); len(parts) >= 2 {
+		return strings.SplitN(parts[1], "/", 2)[0]
+	}
+	if parts := strings.Split(href, "/posts/"); len(parts) >= 2 {
+		if slug := strings.SplitN(parts[1], "/", 2)[0]; slug != "" {
+			return PostSlug(slug)
+		}
+	}
+	return ""
+}
+
+// detailPostRe marks the start of a launch post in the detail page's Apollo
+// cache, capturing the first slug that follows: the post's own, or the
+// product's for posts listed with their product first.
+var detailPostRe = regexp.MustCompile(`"__typename":"Post","id":"\d+"[^{}]*?"slug":"([^"]+)"`)
+
+// parseDetailCounts extracts the current upvote and comment counts of the
+// page's launch from the SSR payload, where each post carries
+// "latestScore" and "commentsCount" like on the leaderboard. Only posts of
+// slug count: the post slug for a posts/ page, otherwise any post whose
+// product is slug. Pages without the payload yield zeros.
+func parseDetailCounts(raw, slug string) (int, int) {
+	postSlug, isPost := strings.CutPrefix(slug, PostSlug(""))
+	posts := detailPostRe.FindAllStringSubmatchIndex(raw, -1)
+	votes, comments := 0, 0
+	for i, loc := range posts {
+		end := len(raw)
+		if i+1 < len(posts) {
+			end = posts[i+1][0]
+		}
+		chunk := raw[loc[0]:end]
+		belongs := raw[loc[2]:loc[3]] == postSlug
+		if !isPost {
+			if m := productSlugRe.FindStringSubmatch(chunk); len(m) >= 2 {
+				belongs = belongs || m[1] == slug
+			}
+		}
+		if !belongs {
+			continue
+		}
+		if votes == 0 {
+			votes = extractInt(latestScoreRe, chunk)
+		}
+		if comments == 0 {
+			comments = extractInt(commentsCountRe, chunk)
+		}
+		if votes > 0 && comments > 0 {
+			break
+		}
 	}
-	return strings.SplitN(parts[1], "/", 2)[0]
+	return votes, comments
 }
 
 // parseRating extracts the numeric rating (e.g. 4.4) from the star rating area.
@@ -160,6 +270,57 @@ func parseThumbnail(header *goquery.Selection, name string) string {
 	return url
 }
 
+// galleryMinSize is the smallest width or height, in pixels, a gallery image
+// is requested at; smaller ones are icons and logos.
+const galleryMinSize = 100
+
+// parseGallery extracts the image URLs of the media gallery, whose items are
+// labeled "{name} gallery media": each item's first image, or its video's
+// poster, that isn't an icon. URLs are stripped of their imgix parameters,
+// which only size the image, and deduplicated. Pages without a gallery
+// yield nil.
+func parseGallery(doc *goquery.Document) []string {
+	var images []string
+	seen := make(map[string]bool)
+	doc.Find("[aria-label$='gallery media']").Each(func(i int, item *goquery.Selection) {
+		var image string
+		item.Find("img[src], video[poster]").EachWithBreak(func(j int, s *goquery.Selection) bool {
+			src, ok := s.Attr("src")
+			if !ok {
+				src, _ = s.Attr("poster")
+			}
+			alt, _ := s.Attr("alt")
+			u, err := url.Parse(strings.TrimSpace(src))
+			if err != nil || u.Host == "" || isIconImage(u, alt) {
+				return true
+			}
+			u.RawQuery, u.Fragment = "", ""
+			image = u.String()
+			return false
+		})
+		if image != "" && !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	})
+	return images
+}
+
+// isIconImage reports whether an image is a logo, by its alt text, or is
+// requested smaller than galleryMinSize.
+func isIconImage(u *url.URL, alt string) bool {
+	if strings.Contains(strings.ToLower(alt), "logo") {
+		return true
+	}
+	q := u.Query()
+	for _, key := range []string{"w", "h"} {
+		if n, err := strconv.Atoi(q.Get(key)); err == nil && n < galleryMinSize {
+			return true
+		}
+	}
+	return false
+}
+
 // parseMakerComment extracts the maker's comment from the "Maker Comment" section.
 func parseMakerComment(doc *goquery.Document) string {
 	var comment string
@@ -187,6 +348,78 @@ func parseMakerComment(doc *goquery.Document) string {
 	return comment
 }
 
+// isRelatedLaunchesHeading reports whether a heading introduces the other
+// launches by the same makers, e.g. "Makers also launched" or "More from
+// the makers".
+func isRelatedLaunchesHeading(text string) bool {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	return strings.Contains(text, "also launched") || strings.HasPrefix(text, "more from")
+}
+
+// parseRelatedLaunches extracts the products listed under the "Makers also
+// launched" section, in page order. Each is a /products/{slug} link holding
+// the name (span.font-semibold, or the link text) and tagline
+// (span.text-secondary); an image-only link to the same product supplies the
+// thumbnail. Links back to the product itself are skipped. Pages without the
+// section yield nil.
+func parseRelatedLaunches(doc *goquery.Document, self string) []types.Product {
+	var heading *goquery.Selection
+	doc.Find("h2, h3").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if isRelatedLaunchesHeading(s.Text()) {
+			heading = s
+			return false
+		}
+		return true
+	})
+	if heading == nil {
+		return nil
+	}
+	section := heading.Closest("section")
+	if section.Length() == 0 {
+		section = heading.Parent()
+	}
+
+	type launch struct{ name, tagline, thumbnail string }
+	launches := make(map[string]*launch)
+	var order []string
+	section.Find(`a[href^="/products/"]`).Each(func(_ int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		slug := normalizeProductSlug(href)
+		// Skip review sub-page links, whose text is a count, not a name
+		if slug == "" || slug == self || strings.Contains(href, "/reviews") {
+			return
+		}
+		l, ok := launches[slug]
+		if !ok {
+			l = &launch{}
+			launches[slug] = l
+			order = append(order, slug)
+		}
+		if l.name == "" {
+			l.name = strings.TrimSpace(link.Find("span.font-semibold").First().Text())
+			if l.name == "" && link.Find("span").Length() == 0 {
+				l.name = strings.TrimSpace(link.Text())
+			}
+		}
+		if l.tagline == "" {
+			l.tagline = strings.TrimSpace(link.Find("span.text-secondary").First().Text())
+		}
+		if l.thumbnail == "" {
+			l.thumbnail, _ = link.Find("img").First().Attr("src")
+		}
+	})
+
+	var related []types.Product
+	for _, slug := range order {
+		l := launches[slug]
+		if l.name == "" {
+			continue
+		}
+		related = append(related, types.NewProduct(l.name, l.tagline, nil, 0, 0, slug, l.thumbnail, 0, 0, false))
+	}
+	return related
+}
+
 func parseDetailCategories(doc *goquery.Document) []string {
 	seen := make(map[string]struct{})
 	categories := make([]string, 0)
@@ -204,6 +437,31 @@ func parseDetailCategories(doc *goquery.Document) []string {
 	return categories
 }
 
+// parseDetailTopics returns the page's topic links with the slugs from their
+// /topics/{slug} hrefs, deduplicated by slug. Links without a name or slug
+// are skipped.
+func parseDetailTopics(doc *goquery.Document) []types.CategoryLink {
+	seen := make(map[string]struct{})
+	topics := make([]types.CategoryLink, 0)
+	doc.Find("a[href^='/topics/']").Each(func(_ int, s *goquery.Selection) {
+		name := strings.TrimSpace(s.Text())
+		href, _ := s.Attr("href")
+		slug := strings.TrimPrefix(href, "/topics/")
+		if i := strings.IndexAny(slug, "/?#"); i >= 0 {
+			slug = slug[:i]
+		}
+		if name == "" || slug == "" {
+			return
+		}
+		if _, ok := seen[slug]; ok {
+			return
+		}
+		seen[slug] = struct{}{}
+		topics = append(topics, types.NewCategoryLink(name, slug))
+	})
+	return topics
+}
+
 func parseSocialLinks(doc *goquery.Document) []string {
 	seen := make(map[string]struct{})
 	links := make([]string, 0)
@@ -230,19 +488,67 @@ func parseSocialLinks(doc *goquery.Document) []string {
 	return links
 }
 
-// parseLaunchDate extracts the launch date from "featuredAt" in SSR JSON.
-func parseLaunchDate(doc *goquery.Document) time.Time {
+// parseLinks groups outbound links by category: the product website (the
+// visit-website target plus same-host links), GitHub, Discord, and the social
+// links from parseSocialLinks.
+func parseLinks(doc *goquery.Document, websiteURL string, socialLinks []string) map[string][]string {
+	links := make(map[string][]string)
+	seen := make(map[string]struct{})
+	add := func(category, href string) {
+		if _, exists := seen[href]; exists {
+			return
+		}
+		seen[href] = struct{}{}
+		links[category] = append(links[category], href)
+	}
+
+	websiteHost := ""
+	if websiteURL != "" {
+		add(types.LinkWebsite, websiteURL)
+		if u, err := url.Parse(websiteURL); err == nil && !strings.Contains(u.Host, "producthunt.com") {
+			websiteHost = strings.TrimPrefix(u.Host, "www.")
+		}
+	}
+
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		h := strings.TrimSpace(href)
+		u, err := url.Parse(h)
+		if err != nil || u.Host == "" {
+			return
+		}
+		host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+		switch {
+		case strings.Contains(host, "producthunt.com"):
+		case host == "github.com":
+			add(types.LinkGitHub, h)
+		case host == "discord.gg" || host == "discord.com" && strings.HasPrefix(u.Path, "/invite/"):
+			add(types.LinkDiscord, h)
+		case websiteHost != "" && host == websiteHost:
+			add(types.LinkWebsite, h)
+		}
+	})
+
+	for _, h := range socialLinks {
+		add(types.LinkSocial, h)
+	}
+	return links
+}
+
+// parseLaunchDates extracts the first and latest launch dates from the
+// "featuredAt" values in SSR JSON.
+func parseLaunchDates(doc *goquery.Document) (time.Time, time.Time) {
 	html, err := doc.Html()
 	if err != nil {
-		return time.Time{}
+		return time.Time{}, time.Time{}
 	}
 	re := regexp.MustCompile(`"featuredAt":"([^"]+)"`)
 	matches := re.FindAllStringSubmatch(html, -1)
 	if len(matches) == 0 {
-		return time.Time{}
+		return time.Time{}, time.Time{}
 	}
 
-	var launch time.Time
+	var first, latest time.Time
 	for _, m := range matches {
 		if len(m) < 2 {
 			continue
@@ -251,12 +557,16 @@ func parseLaunchDate(doc *goquery.Document) time.Time {
 		if parseErr != nil {
 			continue
 		}
-		// "featuredAt" can appear multiple times in SSR payloads; choose the earliest.
-		if launch.IsZero() || t.Before(launch) {
-			launch = t
+		// "featuredAt" appears once per launch (plus duplicate cache entries);
+		// the earliest is the first launch and the latest the most recent relaunch.
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if latest.IsZero() || t.After(latest) {
+			latest = t
 		}
 	}
-	return launch
+	return first, latest
 }
 
 // parseMakerInfo extracts maker name and profile URL from meta/link tags.
@@ -308,6 +618,36 @@ func parseProConTags(doc *goquery.Document) []types.ProConTag {
 	return tags
 }
 
+var (
+	ratingHistogramRe = regexp.MustCompile(`"detailedReviewsRatingSpecificCount":\[([^\]]*)\]`)
+	ratingBucketRe    = regexp.MustCompile(`"rating":(\d+),"count":(\d+)`)
+)
+
+// parseRatingDistribution extracts the per-star review counts from the
+// "detailedReviewsRatingSpecificCount" histogram in SSR JSON. The histogram
+// is repeated across cache entries; the first non-empty one wins. Pages
+// without one yield all zeros.
+func parseRatingDistribution(doc *goquery.Document) [5]int {
+	var dist [5]int
+	html, err := doc.Html()
+	if err != nil {
+		return dist
+	}
+	for _, m := range ratingHistogramRe.FindAllStringSubmatch(html, -1) {
+		for _, bucket := range ratingBucketRe.FindAllStringSubmatch(m[1], -1) {
+			stars, _ := strconv.Atoi(bucket[1])
+			count, _ := strconv.Atoi(bucket[2])
+			if stars >= 1 && stars <= 5 {
+				dist[stars-1] = count
+			}
+		}
+		if dist != [5]int{} {
+			break
+		}
+	}
+	return dist
+}
+
 // parsePricing extracts pricing info from SSR JSON "price" field.
 func parsePricing(doc *goquery.Document) string {
 	if price, ok := parsePricingFromJSONLD(doc); ok {
@@ -431,6 +771,77 @@ func parsePriceValue(v any) (int, bool) {
 	return 0, false
 }
 
+// parsePricingRaw returns the pricing text of each JSON-LD Product offer as
+// published, before it is reduced to a single price. An offer's description or
+// name is used when present, otherwise its price and currency (e.g. "9 USD").
+// Duplicates are dropped, keeping page order.
+func parsePricingRaw(doc *goquery.Document) []string {
+	var raw []string
+	seen := make(map[string]struct{})
+	doc.Find(`script[type='application/ld+json']`).Each(func(_ int, s *goquery.Selection) {
+		var payload any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &payload); err != nil {
+			return
+		}
+		collectOfferTexts(payload, func(text string) {
+			if _, ok := seen[text]; ok {
+				return
+			}
+			seen[text] = struct{}{}
+			raw = append(raw, text)
+		})
+	})
+	return raw
+}
+
+func collectOfferTexts(node any, emit func(string)) {
+	switch v := node.(type) {
+	case map[string]any:
+		if isProductType(v["@type"]) {
+			switch offers := v["offers"].(type) {
+			case map[string]any:
+				emitOfferText(offers, emit)
+			case []any:
+				for _, item := range offers {
+					if offer, ok := item.(map[string]any); ok {
+						emitOfferText(offer, emit)
+					}
+				}
+			}
+		}
+		for _, child := range v {
+			collectOfferTexts(child, emit)
+		}
+	case []any:
+		for _, item := range v {
+			collectOfferTexts(item, emit)
+		}
+	}
+}
+
+func emitOfferText(offer map[string]any, emit func(string)) {
+	for _, field := range []string{"description", "name"} {
+		if text, ok := offer[field].(string); ok && strings.TrimSpace(text) != "" {
+			emit(strings.TrimSpace(text))
+			return
+		}
+	}
+	var price string
+	switch p := offer["price"].(type) {
+	case float64:
+		price = strconv.FormatFloat(p, 'f', -1, 64)
+	case string:
+		price = strings.TrimSpace(p)
+	}
+	if price == "" {
+		return
+	}
+	if currency, ok := offer["priceCurrency"].(string); ok && currency != "" {
+		price += " " + currency
+	}
+	emit(price)
+}
+
 func formatPrice(price int) string {
 	if price == 0 {
 		return "Free"
@@ -1,10 +1,14 @@
 package scraper
 
 import (
+	"errors"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/qyinm/phtui/types"
 )
 
 func TestParseProductDetail(t *testing.T) {
@@ -95,6 +99,9 @@ func TestParseProductDetail(t *testing.T) {
 	if got := detail.PricingInfo(); got != "Free" {
 		t.Errorf("PricingInfo = %q, want %q", got, "Free")
 	}
+	if got := detail.PricingRaw(); !reflect.DeepEqual(got, []string{"0 USD"}) {
+		t.Errorf("PricingRaw = %q, want [0 USD]", got)
+	}
 
 	// Pro/Con tags
 	if len(detail.ProConTags()) == 0 {
@@ -119,6 +126,39 @@ func TestParseProductDetail(t *testing.T) {
 	if !hasNegative {
 		t.Error("No Negative ProConTags found")
 	}
+
+	// Current counts from the SSR payload
+	if detail.VoteCount() != 1422 || detail.CommentCount() != 272 {
+		t.Errorf("counts = %d votes, %d comments; want 1422, 272", detail.VoteCount(), detail.CommentCount())
+	}
+
+	// Gallery screenshots, without their size parameters
+	wantGallery := []string{
+		"https://ph-files.imgix.net/b15535c8-2ed8-4125-beed-b4537b6f7d73.png",
+		"https://ph-files.imgix.net/f5199b81-676f-4aa7-94f5-28ca17ba3f74.png",
+		"https://ph-files.imgix.net/2b1d34dc-2e8e-4c29-a933-48b8a36703d4.jpeg",
+	}
+	if got := detail.GalleryImages(); !reflect.DeepEqual(got, wantGallery) {
+		t.Errorf("GalleryImages = %v, want %v", got, wantGallery)
+	}
+}
+
+func TestParseProductDetailGallery(t *testing.T) {
+	html := `<html><body><div data-test="header"><h1>Demo</h1></div>
+	<div aria-label="Demo gallery media"><img src="https://ph-files.imgix.net/shot.png?auto=format&amp;w=407&amp;h=220"></div>
+	<div aria-label="Demo gallery media"><img src="https://ph-files.imgix.net/play.svg?w=24&amp;h=24"><video poster="https://ph-files.imgix.net/poster.jpeg?w=800"></video></div>
+	<div aria-label="Demo gallery media"><img src="https://ph-files.imgix.net/shot.png?auto=format&amp;w=814&amp;h=440"></div>
+	<div aria-label="Demo gallery media"><img alt="Demo logo" src="https://ph-files.imgix.net/logo.png"></div>
+	<img src="https://ph-files.imgix.net/elsewhere.png">
+	</body></html>`
+	detail, err := ParseProductDetail(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+	want := []string{"https://ph-files.imgix.net/shot.png", "https://ph-files.imgix.net/poster.jpeg"}
+	if got := detail.GalleryImages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GalleryImages = %v, want %v", got, want)
+	}
 }
 
 func TestParseProductDetailMetadataExtraction(t *testing.T) {
@@ -129,7 +169,8 @@ func TestParseProductDetailMetadataExtraction(t *testing.T) {
 	  <a data-test="visit-website-button" href="https://demo.example.com">Visit</a>
 	</div>
 	<a href="/topics/productivity">Productivity</a>
-	<a href="/topics/ai">AI</a>
+	<a href="/topics/artificial-intelligence?ref=header">AI</a>
+	<a href="/topics/productivity">Productivity</a>
 	<a href="https://x.com/demo">X</a>
 	<a href="https://linkedin.com/company/demo">LinkedIn</a>
 	</body></html>`
@@ -142,11 +183,56 @@ func TestParseProductDetailMetadataExtraction(t *testing.T) {
 	if len(detail.Categories()) != 2 {
 		t.Errorf("Categories length = %d, want 2", len(detail.Categories()))
 	}
+	wantTopics := []types.CategoryLink{
+		types.NewCategoryLink("Productivity", "productivity"),
+		types.NewCategoryLink("AI", "artificial-intelligence"),
+	}
+	if got := detail.Topics(); !reflect.DeepEqual(got, wantTopics) {
+		t.Errorf("Topics = %v, want %v", got, wantTopics)
+	}
 	if len(detail.SocialLinks()) != 2 {
 		t.Errorf("SocialLinks length = %d, want 2", len(detail.SocialLinks()))
 	}
 }
 
+func TestParseProductDetailLinkCategories(t *testing.T) {
+	html := `<!DOCTYPE html><html><head><link rel="canonical" href="https://www.producthunt.com/products/demo"></head><body>
+	<div data-test="header">
+	  <h1>Demo</h1>
+	  <a data-test="visit-website-button" href="https://demo.example.com">Visit</a>
+	</div>
+	<a href="https://github.com/demo/demo">GitHub</a>
+	<a href="https://discord.gg/demo">Discord</a>
+	<a href="https://discord.com/invite/demo2">Discord invite</a>
+	<a href="https://discord.com/channels/1/2">Discord channel</a>
+	<a href="https://www.demo.example.com/docs">Docs</a>
+	<a href="https://x.com/demo">X</a>
+	<a href="https://www.producthunt.com/products/demo/reviews">Reviews</a>
+	<a href="https://other.example.org">Other</a>
+	</body></html>`
+
+	detail, err := ParseProductDetail(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+
+	want := map[string][]string{
+		types.LinkWebsite: {"https://demo.example.com", "https://www.demo.example.com/docs"},
+		types.LinkGitHub:  {"https://github.com/demo/demo"},
+		types.LinkDiscord: {"https://discord.gg/demo", "https://discord.com/invite/demo2"},
+		types.LinkSocial:  {"https://x.com/demo"},
+	}
+	if got := detail.Links(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Links = %v, want %v", got, want)
+	}
+	if got := detail.SocialLinks(); len(got) != 1 || got[0] != "https://x.com/demo" {
+		t.Errorf("SocialLinks = %v, want [https://x.com/demo]", got)
+	}
+	if got := detail.RepoOrWebsite(); got != "https://github.com/demo/demo" {
+		t.Errorf("RepoOrWebsite = %q, want GitHub link", got)
+	}
+}
+
 func TestParseProductDetailContent(t *testing.T) {
 	f, err := os.Open("../testdata/product_detail.html")
 	if err != nil {
@@ -220,6 +306,9 @@ func TestParseProductDetailMinimalHTML(t *testing.T) {
 	if len(detail.Categories()) != 0 {
 		t.Errorf("Categories length = %d, want 0", len(detail.Categories()))
 	}
+	if got := detail.Topics(); got == nil || len(got) != 0 {
+		t.Errorf("Topics = %#v, want an empty list", got)
+	}
 	if len(detail.SocialLinks()) != 0 {
 		t.Errorf("SocialLinks length = %d, want 0", len(detail.SocialLinks()))
 	}
@@ -260,6 +349,32 @@ func TestParseProductDetailLaunchDateUsesEarliestFeaturedAt(t *testing.T) {
 	}
 }
 
+func TestParseProductDetailFirstAndLatestLaunch(t *testing.T) {
+	html := `<!DOCTYPE html><html><head>
+	<link rel="canonical" href="https://www.producthunt.com/products/demo">
+	</head><body>
+	<div data-test="header"><h1>Demo</h1></div>
+	<script>{"featuredAt":"2025-06-10T00:01:00-07:00","featuredAt":"2024-01-03T00:01:00-08:00","featuredAt":"2026-02-05T00:01:00-08:00","featuredAt":"not-a-date"}</script>
+	</body></html>`
+
+	detail, err := ParseProductDetail(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+
+	wantFirst, _ := time.Parse(time.RFC3339, "2024-01-03T00:01:00-08:00")
+	wantLatest, _ := time.Parse(time.RFC3339, "2026-02-05T00:01:00-08:00")
+	if got := detail.FirstLaunchDate(); !got.Equal(wantFirst) {
+		t.Errorf("FirstLaunchDate = %v, want %v", got, wantFirst)
+	}
+	if got := detail.LatestLaunchDate(); !got.Equal(wantLatest) {
+		t.Errorf("LatestLaunchDate = %v, want %v", got, wantLatest)
+	}
+	if got := detail.LaunchDate(); !got.Equal(wantFirst) {
+		t.Errorf("LaunchDate = %v, want first launch %v", got, wantFirst)
+	}
+}
+
 func TestParseProductDetailProConTagUsesMaxCountForDuplicates(t *testing.T) {
 	html := `<!DOCTYPE html><html><head>
 	<link rel="canonical" href="https://www.producthunt.com/products/demo">
@@ -313,3 +428,180 @@ func TestParseProductDetailPricingPrefersJSONLDProductPrice(t *testing.T) {
 		t.Errorf("PricingInfo = %q, want %q", got, "$49")
 	}
 }
+
+func TestParseProductDetailPricingRaw(t *testing.T) {
+	html := `<!DOCTYPE html><html><head>
+	<link rel="canonical" href="https://www.producthunt.com/products/demo">
+	<script type="application/ld+json">
+	{"@context":"https://schema.org","@type":"Product","name":"Demo","offers":[
+		{"@type":"Offer","price":9,"priceCurrency":"USD","description":"From $9/mo"},
+		{"@type":"Offer","price":29,"priceCurrency":"USD","name":"Team"},
+		{"@type":"Offer","price":49.5,"priceCurrency":"USD"},
+		{"@type":"Offer","price":9,"priceCurrency":"USD","description":"From $9/mo"}
+	]}
+	</script>
+	</head><body><div data-test="header"><h1>Demo</h1></div></body></html>`
+
+	detail, err := ParseProductDetail(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+	want := []string{"From $9/mo", "Team", "49.5 USD"}
+	if got := detail.PricingRaw(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PricingRaw = %q, want %q", got, want)
+	}
+	// The parsed price is kept alongside the raw text.
+	if got := detail.PricingInfo(); got != "$9" {
+		t.Errorf("PricingInfo = %q, want %q", got, "$9")
+	}
+}
+
+func TestParseProductDetailRatingDistribution(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    [5]int
+	}{
+		// The empty histogram cached first is skipped.
+		{"../testdata/product_reviews.html", [5]int{3, 0, 2, 6, 12}},
+		{"../testdata/product_detail.html", [5]int{0, 0, 1, 5, 5}},
+	}
+	for _, tt := range tests {
+		f, err := os.Open(tt.fixture)
+		if err != nil {
+			t.Fatalf("open fixture: %v", err)
+		}
+		detail, err := ParseProductDetail(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("ParseProductDetail(%s): %v", tt.fixture, err)
+		}
+		if got := detail.RatingDistribution(); got != tt.want {
+			t.Errorf("%s: RatingDistribution = %v, want %v", tt.fixture, got, tt.want)
+		}
+	}
+
+	detail, err := ParseProductDetail(strings.NewReader(`<html><body><div data-test="header"><h1>Demo</h1></div></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.HasRatingDistribution() {
+		t.Errorf("RatingDistribution = %v without a histogram, want zeros", detail.RatingDistribution())
+	}
+}
+
+func TestParseProductDetailRelatedLaunches(t *testing.T) {
+	f, err := os.Open("../testdata/product_related.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+	detail, err := ParseProductDetail(f)
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+
+	// The "View all" link back to the product and the trending section
+	// are not launches.
+	want := []types.Product{
+		types.NewProduct("Quillnote Voice", "Dictate notes from your watch", nil, 0, 0, "quillnote-voice", "https://ph-files.imgix.net/quillnote-voice.png", 0, 0, false),
+		types.NewProduct("Inkpad", "A sketchbook for ideas", nil, 0, 0, "inkpad", "https://ph-files.imgix.net/inkpad.png", 0, 0, false),
+	}
+	if got := detail.RelatedLaunches(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RelatedLaunches = %+v, want %+v", got, want)
+	}
+
+	// Pages without the section have none.
+	f2, err := os.Open("../testdata/product_detail.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f2.Close()
+	if detail, err = ParseProductDetail(f2); err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+	if got := detail.RelatedLaunches(); got != nil {
+		t.Errorf("RelatedLaunches without the section = %+v, want nil", got)
+	}
+}
+
+func TestParseProductDetailPostCanonical(t *testing.T) {
+	html := `<html><head><link rel="canonical" href="https://www.producthunt.com/posts/lonely-launch"></head>
+	<body><div data-test="header"><h1>Lonely Launch</h1></div></body></html>`
+	detail, err := ParseProductDetail(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+	if got := detail.Product().Slug(); got != "posts/lonely-launch" {
+		t.Errorf("slug = %q, want %q", got, "posts/lonely-launch")
+	}
+}
+
+func TestParseProductDetailUnavailable(t *testing.T) {
+	f, err := os.Open("../testdata/product_unavailable.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := ParseProductDetail(f); !errors.Is(err, types.ErrProductUnavailable) {
+		t.Fatalf("err = %v, want ErrProductUnavailable", err)
+	}
+
+	// The offline flag alone marks a stub page.
+	stub := `<html><body><script>{"slug":"gone","isNoLongerOnline":true}</script></body></html>`
+	if _, err := ParseProductDetail(strings.NewReader(stub)); !errors.Is(err, types.ErrProductUnavailable) {
+		t.Errorf("offline stub err = %v, want ErrProductUnavailable", err)
+	}
+
+	// A page with the product header is parsed even when it mentions
+	// something being unavailable.
+	live := `<html><body><div data-test="header"><h1>Demo</h1></div><p>Not available in the EU</p></body></html>`
+	detail, err := ParseProductDetail(strings.NewReader(live))
+	if err != nil || detail.Product().Name() != "Demo" {
+		t.Errorf("live page = %q, %v; want Demo parsed", detail.Product().Name(), err)
+	}
+
+	// Without the header, copy elsewhere on the page doesn't count.
+	scripted := `<html><body><script>var msg = "Page not found";</script><p>This product is no longer available in your region</p></body></html>`
+	if _, err := ParseProductDetail(strings.NewReader(scripted)); errors.Is(err, types.ErrProductUnavailable) {
+		t.Errorf("page with unavailable copy outside the heading err = %v, want it parsed", err)
+	}
+}
+
+func TestParseProductDetailCounts(t *testing.T) {
+	// The SSR payload lists the launch after another product's post, whose
+	// counts must not be picked up, and repeats it with the comment count.
+	html := `<html><head><link rel="canonical" href="https://www.producthunt.com/products/demo"></head>
+	<body><div data-test="header"><h1>Demo</h1></div>
+	<script>{"__typename":"Post","id":"1","slug":"other-launch","name":"Other","product":{"__typename":"Product","id":"9","slug":"other"},"latestScore":999,"commentsCount":99},
+	{"__typename":"Post","id":"2","slug":"demo-2-0","name":"Demo 2.0","product":{"__typename":"Product","id":"7","slug":"demo"},"dailyRank":"3","latestScore":1234,"launchDayScore":800},
+	{"__typename":"Post","id":"2","slug":"demo-2-0","name":"Demo 2.0","product":{"__typename":"Product","id":"7","slug":"demo"},"commentsCount":56}</script>
+	</body></html>`
+	detail, err := ParseProductDetail(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+	if detail.VoteCount() != 1234 || detail.CommentCount() != 56 {
+		t.Errorf("counts = %d votes, %d comments; want 1234, 56", detail.VoteCount(), detail.CommentCount())
+	}
+	if p := detail.Product(); p.VoteCount() != 1234 || p.CommentCount() != 56 {
+		t.Errorf("product counts = %d, %d; want the detail's", p.VoteCount(), p.CommentCount())
+	}
+
+	// A launch without a product page matches by its post slug.
+	post := `<html><head><link rel="canonical" href="https://www.producthunt.com/posts/lonely-launch"></head>
+	<body><script>{"__typename":"Post","id":"3","slug":"lonely-launch","name":"Lonely","latestScore":42,"commentsCount":5}</script></body></html>`
+	detail, err = ParseProductDetail(strings.NewReader(post))
+	if err != nil {
+		t.Fatalf("ParseProductDetail: %v", err)
+	}
+	if detail.VoteCount() != 42 || detail.CommentCount() != 5 {
+		t.Errorf("post counts = %d, %d; want 42, 5", detail.VoteCount(), detail.CommentCount())
+	}
+
+	// Pages without the payload have no counts.
+	detail, _ = ParseProductDetail(strings.NewReader(`<html><body><h1>Bare</h1></body></html>`))
+	if detail.VoteCount() != 0 || detail.CommentCount() != 0 {
+		t.Errorf("bare page counts = %d, %d; want 0", detail.VoteCount(), detail.CommentCount())
+	}
+}
@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ParseProxyURL parses a proxy URL for SetProxy. The scheme must be http,
+// https, socks5 or socks5h, and a host is required; an empty string means no
+// proxy and returns nil.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https, socks5 or socks5h", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", u.Redacted())
+	}
+	return u, nil
+}
+
+// ProxyFromEnv returns the proxy set by PHTUI_PROXY, or nil when unset.
+func ProxyFromEnv() (*url.URL, error) {
+	u, err := ParseProxyURL(os.Getenv("PHTUI_PROXY"))
+	if err != nil {
+		return nil, fmt.Errorf("PHTUI_PROXY: %w", err)
+	}
+	return u, nil
+}
+
+// SetProxy routes the scraper's requests, thumbnail downloads included,
+// through proxy. HTTPS pages are tunnelled with CONNECT through http and
+// https proxies and dialled through socks5 ones. A nil proxy restores the
+// default transport, which honours HTTP_PROXY and HTTPS_PROXY.
+func (s *Scraper) SetProxy(proxy *url.URL) {
+	var transport http.RoundTripper
+	if proxy != nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.Proxy = http.ProxyURL(proxy)
+		transport = t
+	}
+	s.client.Transport = transport
+	if s.thumbnails != nil {
+		s.thumbnails.client.Transport = transport
+	}
+}
@@ -0,0 +1,113 @@
+package scraper
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestParseProxyURL(t *testing.T) {
+	for _, raw := range []string{"http://proxy.corp:3128", "https://user:pw@proxy.corp", "socks5://127.0.0.1:1080", " socks5h://proxy:1080 "} {
+		if u, err := ParseProxyURL(raw); err != nil || u == nil {
+			t.Errorf("ParseProxyURL(%q) = %v, %v", raw, u, err)
+		}
+	}
+	if u, err := ParseProxyURL(" "); u != nil || err != nil {
+		t.Errorf("empty proxy = %v, %v; want nil, nil", u, err)
+	}
+	for _, raw := range []string{"ftp://proxy.corp", "proxy.corp:3128", "http://", "http://%zz"} {
+		if _, err := ParseProxyURL(raw); err == nil {
+			t.Errorf("ParseProxyURL(%q) accepted an invalid proxy", raw)
+		}
+	}
+
+	t.Setenv("PHTUI_PROXY", "socks4://proxy")
+	if _, err := ProxyFromEnv(); err == nil {
+		t.Error("ProxyFromEnv accepted an unsupported scheme")
+	}
+	t.Setenv("PHTUI_PROXY", "")
+	if u, err := ProxyFromEnv(); u != nil || err != nil {
+		t.Errorf("unset PHTUI_PROXY = %v, %v", u, err)
+	}
+}
+
+func TestScraperHTTPProxy(t *testing.T) {
+	var seen []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Method+" "+r.RequestURI)
+		if r.Method == http.MethodConnect {
+			// Refuse the tunnel; seeing the CONNECT is enough.
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		io.WriteString(w, "via proxy")
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	s := NewWithOptions(ScraperOptions{Proxy: proxyURL, MaxRetries: -1})
+	thumbnails, err := NewThumbnailCache(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetThumbnailCache(thumbnails)
+
+	req, _ := http.NewRequest("GET", "http://example.test/page", nil)
+	resp, err := s.do(req)
+	if err != nil {
+		t.Fatalf("GET through proxy: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "via proxy" {
+		t.Fatalf("body = %q, want the proxy's answer", body)
+	}
+
+	// Product Hunt pages are HTTPS, so they're tunnelled with CONNECT.
+	if _, err := s.GetProductDetail("demo"); err == nil {
+		t.Fatal("expected the refused tunnel to fail the request")
+	}
+	want := []string{"GET http://example.test/page", "CONNECT www.producthunt.com:443"}
+	if len(seen) != len(want) || seen[0] != want[0] || seen[1] != want[1] {
+		t.Fatalf("proxy saw %q, want %q", seen, want)
+	}
+	if s.thumbnails.client.Transport != s.client.Transport {
+		t.Error("thumbnail downloads don't use the proxy")
+	}
+
+	s.SetProxy(nil)
+	if s.client.Transport != nil || s.thumbnails.client.Transport != nil {
+		t.Error("SetProxy(nil) kept the proxy transport")
+	}
+}
+
+func TestScraperSOCKS5Proxy(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	greeting := make(chan byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 1)
+		if _, err := io.ReadFull(conn, buf); err == nil {
+			greeting <- buf[0]
+		}
+	}()
+
+	s := NewWithOptions(ScraperOptions{Proxy: &url.URL{Scheme: "socks5", Host: ln.Addr().String()}, MaxRetries: -1})
+	if _, err := s.GetProductDetail("demo"); err == nil {
+		t.Fatal("expected the stub SOCKS proxy to fail the request")
+	}
+	if version := <-greeting; version != 5 {
+		t.Fatalf("proxy greeting version = %d, want 5", version)
+	}
+}
@@ -1,10 +1,16 @@
 package scraper
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,40 +19,459 @@ import (
 )
 
 const (
-	baseURL        = "https://www.producthunt.com"
-	userAgent      = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
-	searchPageSize = 10
+	baseURL = "https://www.producthunt.com"
+	// UserAgent is the browser User-Agent sent with every page request.
+	UserAgent      = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
 	maxSearchPages = 10
+	// DefaultSearchPageSize is how many results a full search page is
+	// assumed to hold until the scraper has seen one.
+	DefaultSearchPageSize = 10
+	// DefaultMaxBodySize caps how much of a response body is read into memory.
+	DefaultMaxBodySize int64 = 10 << 20
+	// DefaultRequestTimeout bounds each HTTP request, body included.
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultMaxRetries is how many times a request failing with a 5xx
+	// status or a network error is retried.
+	DefaultMaxRetries = 2
+	// defaultRetryBackoff is the base delay before the first retry; it
+	// doubles on each subsequent one.
+	defaultRetryBackoff = 500 * time.Millisecond
 )
 
-// Scraper implements types.ProductSource using HTTP client and in-memory cache.
-type Scraper struct {
-	client *http.Client
-	cache  map[string]cachedResult
-	mu     sync.Mutex
+// ErrBodyTooLarge is returned when a response body exceeds the scraper's
+// maximum body size.
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// maxRedirects caps how many redirects a single request follows.
+const maxRedirects = 5
+
+var (
+	// ErrTooManyRedirects is returned when a request is redirected more than
+	// maxRedirects times, usually because of a redirect loop.
+	ErrTooManyRedirects = errors.New("too many redirects")
+	// ErrRedirectToLogin is returned when Product Hunt redirects a request to
+	// a login or challenge page, whose HTML would otherwise parse as an empty
+	// result.
+	ErrRedirectToLogin = errors.New("redirected to a login or challenge page")
+)
+
+// nonContentPaths are path prefixes Product Hunt redirects to instead of
+// serving the requested page.
+var nonContentPaths = []string{"/login", "/signin", "/sign-in", "/signup", "/auth", "/cdn-cgi/challenge-platform"}
+
+// checkRedirect is the scraper client's redirect policy: it follows up to
+// maxRedirects redirects and refuses any that land on a non-content page.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > maxRedirects {
+		return fmt.Errorf("%w: stopped after %d", ErrTooManyRedirects, maxRedirects)
+	}
+	path := strings.ToLower(req.URL.Path)
+	for _, prefix := range nonContentPaths {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return fmt.Errorf("%w: %s", ErrRedirectToLogin, req.URL.Redacted())
+		}
+	}
+	return nil
 }
 
-type cachedResult struct {
-	value     any
-	timestamp time.Time
+// Scraper implements types.ProductSource using an HTTP client, a pluggable
+// result cache and a circuit breaker that backs off while Product Hunt is
+// blocking requests.
+type Scraper struct {
+	client      *http.Client
+	cache       Cache
+	breaker     *CircuitBreaker
+	maxBodySize int64
+	// maxRetries and retryDelay control how transient failures are
+	// retried; tests zero the backoff.
+	maxRetries int
+	retryDelay time.Duration
+	// cacheTTL is how long cached results are served before being
+	// refetched; 0 keeps them until ClearCache.
+	cacheTTL time.Duration
+	// trendingOnEmpty makes empty search queries return today's daily
+	// leaderboard instead of nothing.
+	trendingOnEmpty bool
+	thumbnails      *ThumbnailCache
+	// searchPageSize is the assumed size of a full search page;
+	// fullSearchPage, guarded by searchMu, is the largest page seen that
+	// had another after it and replaces the assumption once known.
+	searchPageSize int
+	searchMu       sync.Mutex
+	fullSearchPage int
+	// headers are added to every request, replacing built-in ones.
+	headers http.Header
+
+	// now is the scraper's clock; tests replace it to cross midnight.
+	now func() time.Time
 }
 
-// Compile-time interface check
-var _ types.ProductSource = (*Scraper)(nil)
+// Compile-time interface checks
+var (
+	_ types.ContextProductSource       = (*Scraper)(nil)
+	_ types.ContextSearchSource        = (*Scraper)(nil)
+	_ types.ContextCategoryPageSource  = (*Scraper)(nil)
+	_ types.ContextCategoryIndexSource = (*Scraper)(nil)
+	_ types.ContextUpcomingSource      = (*Scraper)(nil)
+	_ types.ContextCategoryTreeSource  = (*Scraper)(nil)
+	_ types.ContextFeaturedTimesSource = (*Scraper)(nil)
+	_ types.ContextRankHistorySource   = (*Scraper)(nil)
+	_ types.LeaderboardInvalidator     = (*Scraper)(nil)
+)
 
-// New creates a new Scraper with configured HTTP client and empty cache.
+// New creates a new Scraper with configured HTTP client and empty in-memory cache.
 func New() *Scraper {
+	return NewWithOptions(ScraperOptions{})
+}
+
+// ScraperOptions configures a Scraper built with NewWithOptions.
+type ScraperOptions struct {
+	// Cache stores results; nil selects an in-memory cache.
+	Cache Cache
+	// CacheTTL is how long a cached result is served before it counts as a
+	// miss and is refetched. 0 keeps results until ClearCache. It applies to
+	// caches that record when entries were stored, as MemoryCache and
+	// DiskCache do; a DiskCache also expires entries after its own TTL.
+	CacheTTL time.Duration
+	// RequestTimeout bounds each HTTP request; 0 selects
+	// DefaultRequestTimeout.
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a request that fails with a 5xx status
+	// or a network error is retried; 0 selects DefaultMaxRetries and a
+	// negative value disables retries.
+	MaxRetries int
+	// Proxy routes requests through an HTTP, HTTPS or SOCKS5 proxy; nil
+	// uses the default transport. See ParseProxyURL and SetProxy.
+	Proxy *url.URL
+}
+
+// NewWithOptions creates a new Scraper configured by opts.
+func NewWithOptions(opts ScraperOptions) *Scraper {
+	s := NewWithCache(opts.Cache)
+	s.cacheTTL = max(opts.CacheTTL, 0)
+	s.SetRequestTimeout(opts.RequestTimeout)
+	s.SetMaxRetries(opts.MaxRetries)
+	if opts.Proxy != nil {
+		s.SetProxy(opts.Proxy)
+	}
+	if mem, ok := s.cache.(*MemoryCache); ok && opts.Cache == nil {
+		// Stamp entries with the scraper's clock so tests can expire them.
+		mem.now = func() time.Time { return s.now() }
+	}
+	return s
+}
+
+// NewWithCache creates a new Scraper that stores results in the given cache.
+// A nil cache falls back to an in-memory cache.
+func NewWithCache(cache Cache) *Scraper {
+	if cache == nil {
+		cache = NewMemoryCache()
+	}
 	return &Scraper{
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:       DefaultRequestTimeout,
+			CheckRedirect: checkRedirect,
 		},
-		cache: make(map[string]cachedResult),
+		cache:       cache,
+		breaker:     NewCircuitBreaker(0, 0, 0),
+		maxBodySize: DefaultMaxBodySize,
+		maxRetries:  DefaultMaxRetries,
+		retryDelay:  defaultRetryBackoff,
+		now:         time.Now,
+
+		searchPageSize: DefaultSearchPageSize,
+	}
+}
+
+// SetCircuitBreaker replaces the scraper's circuit breaker. A nil breaker
+// disables it.
+func (s *Scraper) SetCircuitBreaker(b *CircuitBreaker) {
+	s.breaker = b
+}
+
+// SetThumbnailCache sets where leaderboard thumbnails are downloaded in the
+// background after each fetch, through the scraper's proxy if it has one. A
+// nil cache disables thumbnail downloads.
+func (s *Scraper) SetThumbnailCache(c *ThumbnailCache) {
+	s.thumbnails = c
+	if c != nil && s.client.Transport != nil {
+		c.client.Transport = s.client.Transport
+	}
+}
+
+// ThumbnailPath returns the downloaded thumbnail file for slug, if the
+// thumbnail cache has a fresh copy.
+func (s *Scraper) ThumbnailPath(slug string) (string, bool) {
+	return s.thumbnails.Path(slug)
+}
+
+// SetRequestTimeout sets how long a single HTTP request, including reading
+// its body, may take. Non-positive values restore DefaultRequestTimeout.
+func (s *Scraper) SetRequestTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultRequestTimeout
+	}
+	s.client.Timeout = d
+}
+
+// SetMaxRetries sets how many times a request that fails with a 5xx status or
+// a network error is retried, with exponential backoff and jitter between
+// attempts. 0 restores DefaultMaxRetries; a negative value disables retries.
+func (s *Scraper) SetMaxRetries(n int) {
+	if n == 0 {
+		n = DefaultMaxRetries
+	}
+	s.maxRetries = max(n, 0)
+}
+
+// SetMaxBodySize sets how many bytes of a response body the scraper reads
+// before failing with ErrBodyTooLarge. Non-positive values restore
+// DefaultMaxBodySize.
+func (s *Scraper) SetMaxBodySize(n int64) {
+	if n <= 0 {
+		n = DefaultMaxBodySize
+	}
+	s.maxBodySize = n
+}
+
+// SetHeaders sets headers sent with every request, such as Referer or
+// Sec-Fetch-Site for sites that block bare clients. They take precedence
+// over the scraper's built-in headers: a custom User-Agent replaces the
+// default one. A later call replaces the headers set by an earlier one,
+// including a cookie from SetCookie.
+func (s *Scraper) SetHeaders(headers map[string]string) {
+	s.headers = make(http.Header, len(headers))
+	for name, value := range headers {
+		s.headers.Set(name, value)
+	}
+}
+
+// SetCookie sends cookie, a "name=value; name2=value2" string as copied from
+// a browser, as the Cookie header of every request. An empty cookie removes
+// it.
+func (s *Scraper) SetCookie(cookie string) {
+	if s.headers == nil {
+		s.headers = make(http.Header)
+	}
+	if cookie = strings.TrimSpace(cookie); cookie == "" {
+		s.headers.Del("Cookie")
+		return
+	}
+	s.headers.Set("Cookie", cookie)
+}
+
+// HeadersFromEnv parses PHTUI_HEADERS, a ";"-separated list of "Name: value"
+// pairs such as "Referer: https://www.google.com/; Sec-Fetch-Site: cross-site".
+// Entries without a name are skipped. Cookies belong in PHTUI_COOKIE, since
+// their values contain ";".
+func HeadersFromEnv() map[string]string {
+	headers := make(map[string]string)
+	for _, entry := range strings.Split(os.Getenv("PHTUI_HEADERS"), ";") {
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// CookieFromEnv returns the cookie set by PHTUI_COOKIE for SetCookie.
+func CookieFromEnv() string {
+	return os.Getenv("PHTUI_COOKIE")
+}
+
+// SetTrendingOnEmptyQuery controls what an empty (or all-whitespace) search
+// query returns. By default SearchProducts and SearchProductsPage return no
+// results; with on set they fall back to today's daily leaderboard as a
+// single page of "trending" products.
+func (s *Scraper) SetTrendingOnEmptyQuery(on bool) {
+	s.trendingOnEmpty = on
+}
+
+// SetSearchPageSize sets how many results a full search page is assumed to
+// hold when the page doesn't say whether another follows. A non-positive
+// size restores DefaultSearchPageSize. Once a page followed by another has
+// been seen, its size is used instead.
+func (s *Scraper) SetSearchPageSize(n int) {
+	if n <= 0 {
+		n = DefaultSearchPageSize
+	}
+	s.searchPageSize = n
+}
+
+// SearchPageSizeFromEnv returns the search page size set by
+// PHTUI_SEARCH_PAGE_SIZE, or DefaultSearchPageSize when unset or invalid.
+func SearchPageSizeFromEnv() int {
+	n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("PHTUI_SEARCH_PAGE_SIZE")))
+	if err != nil || n <= 0 {
+		return DefaultSearchPageSize
+	}
+	return n
+}
+
+// searchPageSizeHint returns the size of a full search page: the largest
+// page seen with another after it, or the configured size before then.
+func (s *Scraper) searchPageSizeHint() int {
+	s.searchMu.Lock()
+	defer s.searchMu.Unlock()
+	if s.fullSearchPage > 0 {
+		return s.fullSearchPage
+	}
+	return s.searchPageSize
+}
+
+// observeFullSearchPage records the size of a search page known to be
+// followed by another.
+func (s *Scraper) observeFullSearchPage(n int) {
+	s.searchMu.Lock()
+	defer s.searchMu.Unlock()
+	s.fullSearchPage = max(s.fullSearchPage, n)
+}
+
+// trendingSearch answers an empty search query: today's daily leaderboard
+// when trendingOnEmpty is set, nothing otherwise.
+func (s *Scraper) trendingSearch(ctx context.Context) ([]types.Product, error) {
+	if !s.trendingOnEmpty {
+		return nil, nil
 	}
+	return s.GetLeaderboardContext(ctx, types.Daily, types.Today())
+}
+
+// MaxBodySizeFromEnv returns the body size cap set by PHTUI_MAX_BODY_MB, or
+// DefaultMaxBodySize when unset or invalid.
+func MaxBodySizeFromEnv() int64 {
+	mb, err := strconv.ParseInt(strings.TrimSpace(os.Getenv("PHTUI_MAX_BODY_MB")), 10, 64)
+	if err != nil || mb <= 0 {
+		return DefaultMaxBodySize
+	}
+	return mb << 20
+}
+
+// readBody reads r up to the scraper's maximum body size, returning
+// ErrBodyTooLarge instead of buffering anything beyond it.
+func (s *Scraper) readBody(r io.Reader) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, s.maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > s.maxBodySize {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrBodyTooLarge, s.maxBodySize)
+	}
+	return body, nil
+}
+
+// do sends req, retrying 5xx responses and network errors up to the
+// scraper's retry limit with exponential backoff and jitter. Other failures,
+// such as a 404, a Cloudflare challenge or an open circuit, are returned as
+// they are. The last attempt's outcome is returned when retries run out.
+func (s *Scraper) do(req *http.Request) (*http.Response, error) {
+	backoff := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := s.attempt(req)
+		if attempt >= s.maxRetries || !retryable(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := sleepCtx(req.Context(), jitter(backoff)); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+// retryable reports whether a request outcome is a transient upstream
+// failure worth another attempt: a 5xx status or a network error. Blocks,
+// challenge pages, redirect-policy refusals, oversized bodies and
+// cancellations are final.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen) && !errors.Is(err, ErrCloudflareChallenge) &&
+			!errors.Is(err, ErrRedirectToLogin) &&
+			!errors.Is(err, ErrTooManyRedirects) && !errors.Is(err, ErrBodyTooLarge) &&
+			!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// jitter spreads d over [d/2, 3d/2) so clients retrying together don't
+// hit Product Hunt in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d)
+}
+
+// sleepCtx waits for d or until ctx is done, returning ctx's error then.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// attempt sends req once unless the circuit breaker is open, and feeds the
+// outcome back to it. Cloudflare challenge pages served with 200 or a 5xx
+// status count as blocks too, so those bodies are buffered to inspect them.
+// A challenge behind a 5xx is returned as ErrCloudflareChallenge so do()
+// doesn't retry it.
+func (s *Scraper) attempt(req *http.Request) (*http.Response, error) {
+	if err := s.breaker.allow(); err != nil {
+		return nil, err
+	}
+	for name, values := range s.headers {
+		req.Header[name] = values
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.breaker.release()
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden, resp.StatusCode == http.StatusTooManyRequests:
+		s.breaker.recordBlock()
+		return resp, nil
+	case resp.StatusCode == http.StatusOK, resp.StatusCode >= http.StatusInternalServerError:
+		body, err := s.readBody(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			s.breaker.release()
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if looksLikeCloudflareChallenge(string(body)) {
+			s.breaker.recordBlock()
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("status %d: %w", resp.StatusCode, ErrCloudflareChallenge)
+			}
+			return resp, nil
+		}
+	}
+	s.breaker.recordSuccess()
+	return resp, nil
 }
 
 // GetLeaderboard fetches and parses the Product Hunt Featured leaderboard for the given period and date.
 func (s *Scraper) GetLeaderboard(period types.Period, date time.Time) ([]types.Product, error) {
-	url := baseURL + period.URLPath(date)
+	return s.GetLeaderboardContext(context.Background(), period, date)
+}
+
+// GetLeaderboardContext is GetLeaderboard with a context that cancels the
+// request, including retries.
+func (s *Scraper) GetLeaderboardContext(ctx context.Context, period types.Period, date time.Time) ([]types.Product, error) {
+	url := LeaderboardURL(period, date)
 
 	if val, ok := s.getCached(url); ok {
 		if products, ok := val.([]types.Product); ok {
@@ -54,13 +479,13 @@ func (s *Scraper) GetLeaderboard(period types.Period, date time.Time) ([]types.P
 		}
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", UserAgent)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("fetch leaderboard: %w", err)
 	}
@@ -70,18 +495,124 @@ func (s *Scraper) GetLeaderboard(period types.Period, date time.Time) ([]types.P
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	products, err := ParseLeaderboard(resp.Body)
+	body, err := s.readBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read leaderboard: %w", err)
+	}
+
+	products, hydrationOnly, err := parseLeaderboard(body, period)
+	if err != nil {
+		return nil, fmt.Errorf("parse leaderboard: %w", err)
+	}
+	featured, err := ParseLeaderboardFeaturedTimes(bytes.NewReader(body), period)
 	if err != nil {
 		return nil, fmt.Errorf("parse leaderboard: %w", err)
 	}
 
 	s.setCache(url, products)
+	s.setCache(featuredTimesKey(url), featured)
+	s.setCache(hydrationOnlyKey(url), hydrationOnly)
+	s.thumbnails.Prefetch(products)
 	return products, nil
 }
 
+// dayBoundary returns the midnight after which the entry under key, stored
+// at storedAt, is stale, for a daily leaderboard (or its featured times or
+// hydration-only slugs) cached while its day was still running. Votes keep
+// moving until midnight in the configured zone, so the cached list must not
+// outlive it whatever the cache's TTL. ok is false for every other key.
+func dayBoundary(key string, storedAt time.Time) (boundary time.Time, ok bool) {
+	url := strings.TrimPrefix(strings.TrimPrefix(key, featuredTimesKey("")), hydrationOnlyKey(""))
+	var year, month, day int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(url, baseURL), "/leaderboard/daily/%d/%d/%d", &year, &month, &day); err != nil {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, types.Timezone())
+	if LeaderboardURL(types.Daily, date) != url {
+		return time.Time{}, false
+	}
+	storedDay := types.DayIn(storedAt)
+	if date.Before(storedDay) {
+		return time.Time{}, false
+	}
+	return storedDay.AddDate(0, 0, 1), true
+}
+
+// GetFeaturedTimes returns when each product on the leaderboard was featured,
+// keyed by slug. Products without timing data are absent from the map.
+func (s *Scraper) GetFeaturedTimes(period types.Period, date time.Time) (map[string]time.Time, error) {
+	return s.GetFeaturedTimesContext(context.Background(), period, date)
+}
+
+// GetFeaturedTimesContext is GetFeaturedTimes with a context that cancels
+// the leaderboard fetch, if one is needed.
+func (s *Scraper) GetFeaturedTimesContext(ctx context.Context, period types.Period, date time.Time) (map[string]time.Time, error) {
+	url := LeaderboardURL(period, date)
+	if val, ok := s.getCached(featuredTimesKey(url)); ok {
+		if featured, ok := val.(map[string]time.Time); ok {
+			return featured, nil
+		}
+	}
+	// Fetching the leaderboard caches its featured times alongside.
+	if _, err := s.GetLeaderboardContext(ctx, period, date); err != nil {
+		return nil, err
+	}
+	if val, ok := s.getCached(featuredTimesKey(url)); ok {
+		if featured, ok := val.(map[string]time.Time); ok {
+			return featured, nil
+		}
+	}
+	return nil, nil
+}
+
+func featuredTimesKey(url string) string {
+	return "featured:" + url
+}
+
+// GetHydrationOnly returns the slugs of the leaderboard's products that were
+// found only in its hydration data, with no server-rendered card. They are
+// cached alongside the leaderboard, on disk too with a DiskCache. If the
+// leaderboard is cached without them, as when a disk cache written by an
+// older version holds it, GetHydrationOnly returns nil until it is fetched
+// again.
+func (s *Scraper) GetHydrationOnly(period types.Period, date time.Time) (map[string]bool, error) {
+	return s.GetHydrationOnlyContext(context.Background(), period, date)
+}
+
+// GetHydrationOnlyContext is GetHydrationOnly with a context that cancels
+// the leaderboard fetch, if one is needed.
+func (s *Scraper) GetHydrationOnlyContext(ctx context.Context, period types.Period, date time.Time) (map[string]bool, error) {
+	url := LeaderboardURL(period, date)
+	if val, ok := s.getCached(hydrationOnlyKey(url)); ok {
+		if slugs, ok := val.(map[string]bool); ok {
+			return slugs, nil
+		}
+	}
+	// Fetching the leaderboard caches its hydration-only slugs alongside.
+	if _, err := s.GetLeaderboardContext(ctx, period, date); err != nil {
+		return nil, err
+	}
+	if val, ok := s.getCached(hydrationOnlyKey(url)); ok {
+		if slugs, ok := val.(map[string]bool); ok {
+			return slugs, nil
+		}
+	}
+	return nil, nil
+}
+
+func hydrationOnlyKey(url string) string {
+	return "hydration-only:" + url
+}
+
 // GetProductDetail fetches and parses the Product Hunt product detail page for the given slug.
 func (s *Scraper) GetProductDetail(slug string) (types.ProductDetail, error) {
-	url := baseURL + "/products/" + slug
+	return s.GetProductDetailContext(context.Background(), slug)
+}
+
+// GetProductDetailContext is GetProductDetail with a context that cancels
+// the request, including retries.
+func (s *Scraper) GetProductDetailContext(ctx context.Context, slug string) (types.ProductDetail, error) {
+	url := ProductURL(slug)
 
 	if val, ok := s.getCached(url); ok {
 		if detail, ok := val.(types.ProductDetail); ok {
@@ -89,13 +620,13 @@ func (s *Scraper) GetProductDetail(slug string) (types.ProductDetail, error) {
 		}
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return types.ProductDetail{}, fmt.Errorf("create request: %w", err)
 	}
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", UserAgent)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return types.ProductDetail{}, fmt.Errorf("fetch product detail: %w", err)
 	}
@@ -103,32 +634,97 @@ func (s *Scraper) GetProductDetail(slug string) (types.ProductDetail, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		// Read body for error context
-		body, _ := io.ReadAll(resp.Body)
+		body, _ := s.readBody(resp.Body)
 		return types.ProductDetail{}, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
+	body, err := s.readBody(resp.Body)
+	if err != nil {
+		return types.ProductDetail{}, fmt.Errorf("read product detail: %w", err)
+	}
+
 	// Parse
-	detail, err := ParseProductDetail(resp.Body)
+	detail, err := ParseProductDetail(bytes.NewReader(body))
+	if err != nil {
+		return types.ProductDetail{}, fmt.Errorf("parse product detail: %w", err)
+	}
+	history, err := ParseRankHistory(bytes.NewReader(body), slug)
 	if err != nil {
 		return types.ProductDetail{}, fmt.Errorf("parse product detail: %w", err)
 	}
 
 	s.setCache(url, detail)
+	s.setCache(rankHistoryKey(url), history)
 	return detail, nil
 }
 
+// GetRankHistory returns the daily rank of each of the product's launches,
+// as embedded in its detail page, oldest first.
+func (s *Scraper) GetRankHistory(slug string) ([]types.RankPoint, error) {
+	return s.GetRankHistoryContext(context.Background(), slug)
+}
+
+// GetRankHistoryContext is GetRankHistory with a context that cancels the
+// detail fetch, if one is needed.
+func (s *Scraper) GetRankHistoryContext(ctx context.Context, slug string) ([]types.RankPoint, error) {
+	url := ProductURL(slug)
+	if val, ok := s.getCached(rankHistoryKey(url)); ok {
+		if history, ok := val.([]types.RankPoint); ok {
+			return history, nil
+		}
+	}
+	// Fetching the detail page caches its rank history alongside.
+	if _, err := s.GetProductDetailContext(ctx, slug); err != nil {
+		return nil, err
+	}
+	if val, ok := s.getCached(rankHistoryKey(url)); ok {
+		if history, ok := val.([]types.RankPoint); ok {
+			return history, nil
+		}
+	}
+	return nil, nil
+}
+
+// CachedRankHistory returns slug's rank history if an earlier detail fetch
+// left it in the cache. It never fetches.
+func (s *Scraper) CachedRankHistory(slug string) ([]types.RankPoint, bool) {
+	if val, ok := s.getCached(rankHistoryKey(ProductURL(slug))); ok {
+		if history, ok := val.([]types.RankPoint); ok {
+			return history, true
+		}
+	}
+	return nil, false
+}
+
+func rankHistoryKey(url string) string {
+	return "history:" + url
+}
+
 // SearchProducts fetches Product Hunt global search results for the query.
 func (s *Scraper) SearchProducts(query string) ([]types.Product, error) {
 	q := strings.TrimSpace(query)
 	if q == "" {
-		return nil, nil
+		return s.trendingSearch(context.Background())
 	}
 
-	all := make([]types.Product, 0, searchPageSize)
+	return collectSearchResults(q, s.SearchProductsPage)
+}
+
+// searchPageFunc fetches one page of search results; it matches
+// SearchProductsPage.
+type searchPageFunc func(query string, page int) ([]types.Product, int, bool, bool, int, error)
+
+// collectSearchResults aggregates search pages into a single ranked list.
+// Each page fetch already retries transient failures (see Scraper.do), so a
+// failed page stops pagination; failures after page 1 keep the results so far.
+// A page shorter than an earlier one is taken as the last.
+func collectSearchResults(q string, fetch searchPageFunc) ([]types.Product, error) {
+	all := make([]types.Product, 0, DefaultSearchPageSize)
 	seen := make(map[string]struct{})
+	fullPage := 0
 
 	for page := 1; page <= maxSearchPages; page++ {
-		products, _, _, hasNext, _, err := s.SearchProductsPage(q, page)
+		products, _, _, hasNext, _, err := fetch(q, page)
 		if err != nil {
 			if page == 1 {
 				return nil, err
@@ -157,52 +753,74 @@ func (s *Scraper) SearchProducts(query string) ([]types.Product, error) {
 				p.Slug(),
 				p.ThumbnailURL(),
 				len(all)+1,
+				p.Rating(),
+				p.Offline(),
 			))
 			added++
 		}
 
-		if added == 0 || len(products) < searchPageSize || !hasNext {
+		if added == 0 || len(products) < fullPage || !hasNext {
 			break
 		}
+		fullPage = max(fullPage, len(products))
 	}
 
 	return all, nil
 }
 
 // SearchProductsPage fetches a single search results page and paging metadata.
+// An empty query never hits the network; see SetTrendingOnEmptyQuery.
 func (s *Scraper) SearchProductsPage(query string, page int) ([]types.Product, int, bool, bool, int, error) {
+	return s.SearchProductsPageContext(context.Background(), query, page)
+}
+
+// SearchProductsPageContext is SearchProductsPage with a context that
+// cancels the request, including retries.
+func (s *Scraper) SearchProductsPageContext(ctx context.Context, query string, page int) ([]types.Product, int, bool, bool, int, error) {
 	if page < 1 {
 		page = 1
 	}
-	escaped := url.QueryEscape(query)
-	searchURL := fmt.Sprintf("%s/search?q=%s&page=%d", baseURL, escaped, page)
+	if strings.TrimSpace(query) == "" {
+		products, err := s.trendingSearch(ctx)
+		if err != nil {
+			return nil, 1, false, false, 0, err
+		}
+		if products == nil {
+			return nil, 1, false, false, 0, nil
+		}
+		return products, 1, false, false, 1, nil
+	}
+	searchURL := SearchURL(query, page)
 
 	if val, ok := s.getCached(searchURL); ok {
 		if searchCached, ok := val.(searchPageCache); ok {
 			return searchCached.products, searchCached.page, searchCached.hasPrev, searchCached.hasNext, searchCached.pagesCount, nil
 		}
 		if products, ok := val.([]types.Product); ok {
-			return products, page, page > 1, len(products) >= searchPageSize, page, nil
+			return products, page, page > 1, len(products) >= s.searchPageSizeHint(), page, nil
 		}
 	}
 
-	req, err := http.NewRequest("GET", searchURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
 	if err != nil {
 		return nil, page, false, false, page, fmt.Errorf("create search request: %w", err)
 	}
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", UserAgent)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, page, false, false, page, fmt.Errorf("fetch search results: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, page, false, false, page, fmt.Errorf("unexpected status code: %d: %w", resp.StatusCode, ErrCloudflareChallenge)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, page, false, false, page, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := s.readBody(resp.Body)
 	if err != nil {
 		return nil, page, false, false, page, fmt.Errorf("read search results: %w", err)
 	}
@@ -215,8 +833,10 @@ func (s *Scraper) SearchProductsPage(query string, page int) ([]types.Product, i
 	if !ok {
 		currentPage = page
 		hasPrev = page > 1
-		hasNext = len(products) >= searchPageSize
+		hasNext = len(products) >= s.searchPageSizeHint()
 		pagesCount = 0
+	} else if hasNext {
+		s.observeFullSearchPage(len(products))
 	}
 
 	s.setCache(searchURL, searchPageCache{
@@ -238,66 +858,248 @@ type searchPageCache struct {
 	pagesCount int
 }
 
-// GetCategoryProducts fetches and parses a Product Hunt category page.
+// GetCategoryProducts fetches and parses the first page of a Product Hunt
+// category; see GetCategoryProductsPage for the others.
 func (s *Scraper) GetCategoryProducts(slug string) ([]types.Product, []types.CategoryLink, error) {
-	categoryURL := baseURL + "/categories/" + slug
+	return s.GetCategoryProductsContext(context.Background(), slug)
+}
+
+// GetCategoryProductsContext is GetCategoryProducts with a context that
+// cancels the request, including retries.
+func (s *Scraper) GetCategoryProductsContext(ctx context.Context, slug string) ([]types.Product, []types.CategoryLink, error) {
+	products, categories, _, _, err := s.categoryProductsPage(ctx, slug, 1)
+	return products, categories, err
+}
+
+// GetCategoryProductsPage fetches one page of a Product Hunt category with
+// its related categories, whether a later page exists, and the category's
+// page count.
+func (s *Scraper) GetCategoryProductsPage(slug string, page int) ([]types.Product, []types.CategoryLink, bool, int, error) {
+	return s.GetCategoryProductsPageContext(context.Background(), slug, page)
+}
+
+// GetCategoryProductsPageContext is GetCategoryProductsPage with a context
+// that cancels the request, including retries.
+func (s *Scraper) GetCategoryProductsPageContext(ctx context.Context, slug string, page int) ([]types.Product, []types.CategoryLink, bool, int, error) {
+	return s.categoryProductsPage(ctx, slug, page)
+}
+
+func (s *Scraper) categoryProductsPage(ctx context.Context, slug string, page int) ([]types.Product, []types.CategoryLink, bool, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	categoryURL := CategoryPageURL(slug, page)
 
 	if val, ok := s.getCached(categoryURL); ok {
-		if result, ok := val.(categoryCache); ok {
-			return result.products, result.categories, nil
+		// Entries cached before pages were tracked have no page count;
+		// refetch those so the paging fields are right.
+		if result, ok := val.(categoryCache); ok && result.pagesCount > 0 {
+			return result.products, result.categories, result.hasNext, result.pagesCount, nil
 		}
 	}
 
-	req, err := http.NewRequest("GET", categoryURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", categoryURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("create request: %w", err)
+		return nil, nil, false, 0, fmt.Errorf("create request: %w", err)
 	}
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", UserAgent)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("fetch category: %w", err)
+		return nil, nil, false, 0, fmt.Errorf("fetch category: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, nil, false, 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	products, categories, err := ParseCategoryProducts(resp.Body)
+	products, categories, hasNext, pagesCount, err := ParseCategoryProductsPage(resp.Body, page)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse category: %w", err)
+		return nil, nil, false, 0, fmt.Errorf("parse category: %w", err)
 	}
 
-	s.setCache(categoryURL, categoryCache{products: products, categories: categories})
-	return products, categories, nil
+	s.setCache(categoryURL, categoryCache{products: products, categories: categories, hasNext: hasNext, pagesCount: pagesCount})
+	return products, categories, hasNext, pagesCount, nil
+}
+
+// GetAllCategories fetches Product Hunt's category index. It always hits the
+// live site so callers can refresh a stale category list.
+func (s *Scraper) GetAllCategories() ([]types.CategoryLink, error) {
+	return s.GetAllCategoriesContext(context.Background())
+}
+
+// GetAllCategoriesContext is GetAllCategories with a context that cancels
+// the request, including retries.
+func (s *Scraper) GetAllCategoriesContext(ctx context.Context) ([]types.CategoryLink, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", CategoriesURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch categories: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	categories, err := ParseCategoryIndex(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse categories: %w", err)
+	}
+	return categories, nil
+}
+
+// GetUpcomingProducts fetches and parses Product Hunt's coming-soon listing.
+func (s *Scraper) GetUpcomingProducts() ([]types.Product, error) {
+	return s.GetUpcomingProductsContext(context.Background())
+}
+
+// GetUpcomingProductsContext is GetUpcomingProducts with a context that
+// cancels the request, including retries.
+func (s *Scraper) GetUpcomingProductsContext(ctx context.Context) ([]types.Product, error) {
+	upcomingURL := UpcomingURL()
+
+	if val, ok := s.getCached(upcomingURL); ok {
+		if products, ok := val.([]types.Product); ok {
+			return products, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", upcomingURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch upcoming: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	products, err := ParseUpcomingProducts(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse upcoming: %w", err)
+	}
+
+	s.setCache(upcomingURL, products)
+	return products, nil
+}
+
+// GetCategoryTree fetches a Product Hunt category page and parses its parent
+// and subcategories.
+func (s *Scraper) GetCategoryTree(slug string) (types.CategoryTree, error) {
+	return s.GetCategoryTreeContext(context.Background(), slug)
+}
+
+// GetCategoryTreeContext is GetCategoryTree with a context that cancels the
+// request, including retries.
+func (s *Scraper) GetCategoryTreeContext(ctx context.Context, slug string) (types.CategoryTree, error) {
+	categoryURL := CategoryURL(slug)
+	cacheKey := "tree:" + categoryURL
+
+	if val, ok := s.getCached(cacheKey); ok {
+		if tree, ok := val.(types.CategoryTree); ok {
+			return tree, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", categoryURL, nil)
+	if err != nil {
+		return types.CategoryTree{}, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return types.CategoryTree{}, fmt.Errorf("fetch category: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return types.CategoryTree{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	tree, err := ParseCategoryTree(resp.Body, slug)
+	if err != nil {
+		return types.CategoryTree{}, fmt.Errorf("parse category tree: %w", err)
+	}
+
+	s.setCache(cacheKey, tree)
+	return tree, nil
 }
 
 type categoryCache struct {
 	products   []types.Product
 	categories []types.CategoryLink
+	hasNext    bool
+	pagesCount int
 }
 
 // getCached retrieves a cached value by key, returning (value, true) if found.
+// For caches that record when entries were stored, a value stored longer
+// ago than the cache TTL, or a daily leaderboard past its dayBoundary, is
+// removed and reported missing so the caller refetches it.
 func (s *Scraper) getCached(key string) (any, bool) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if cached, ok := s.cache[key]; ok {
-		return cached.value, true
+	stored, ok := s.cache.(storedAtCache)
+	if !ok {
+		return s.cache.Get(key)
 	}
-	return nil, false
+	value, storedAt, ok := stored.GetStored(key)
+	if !ok {
+		return nil, false
+	}
+	if s.expired(key, storedAt) {
+		s.cache.Delete(key)
+		return nil, false
+	}
+	return value, true
+}
+
+// expired reports whether the entry under key, stored at storedAt, is stale.
+func (s *Scraper) expired(key string, storedAt time.Time) bool {
+	now := s.now()
+	if s.cacheTTL > 0 && now.Sub(storedAt) > s.cacheTTL {
+		return true
+	}
+	boundary, ok := dayBoundary(key, storedAt)
+	return ok && !now.Before(boundary)
 }
 
 // setCache stores a value in the cache under the given key.
 func (s *Scraper) setCache(key string, value any) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.cache[key] = cachedResult{value: value, timestamp: time.Now()}
+	s.cache.Set(key, value)
 }
 
-// ClearCache clears the in-memory cache.
+// InvalidateLeaderboard drops the cached leaderboard for period and date,
+// with its featured times and hydration-only set, so the next fetch goes to
+// Product Hunt. Rank history is kept.
+func (s *Scraper) InvalidateLeaderboard(period types.Period, date time.Time) {
+	url := LeaderboardURL(period, date)
+	s.cache.Delete(url)
+	s.cache.Delete(featuredTimesKey(url))
+	s.cache.Delete(hydrationOnlyKey(url))
+}
+
+// ClearCache clears the scraper's cache.
 func (s *Scraper) ClearCache() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.cache = make(map[string]cachedResult)
+	s.cache.Clear()
+}
+
+// PersistentCache reports whether the scraper's cache outlives the process,
+// as a DiskCache does. Such a cache expires entries itself, so clearing it
+// on a timer would only throw away the warm start it exists for.
+func (s *Scraper) PersistentCache() bool {
+	_, ok := s.cache.(*DiskCache)
+	return ok
 }
@@ -1,6 +1,14 @@
 package scraper
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,7 +44,7 @@ func TestURLConstruction(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			url := baseURL + tt.period.URLPath(tt.date)
+			url := LeaderboardURL(tt.period, tt.date)
 			if url != tt.expected {
 				t.Errorf("URL mismatch:\ngot:  %s\nwant: %s", url, tt.expected)
 			}
@@ -47,9 +55,652 @@ func TestURLConstruction(t *testing.T) {
 func TestProductDetailURL(t *testing.T) {
 	slug := "example-product"
 	expected := "https://www.producthunt.com/products/example-product"
-	url := baseURL + "/products/" + slug
+	url := ProductURL(slug)
 
 	if url != expected {
 		t.Errorf("Product detail URL mismatch:\ngot:  %s\nwant: %s", url, expected)
 	}
 }
+
+func TestViewURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"category", CategoryURL("ai-agents"), "https://www.producthunt.com/categories/ai-agents"},
+		{"search first page", SearchURL("ai notes", 1), "https://www.producthunt.com/search?q=ai+notes&page=1"},
+		{"search later page", SearchURL("c++ & go", 3), "https://www.producthunt.com/search?q=c%2B%2B+%26+go&page=3"},
+		{"search page clamped", SearchURL("demo", 0), "https://www.producthunt.com/search?q=demo&page=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("URL mismatch:\ngot:  %s\nwant: %s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLConstructionConfiguredTimezone(t *testing.T) {
+	orig := types.Timezone()
+	defer types.SetTimezone(orig)
+
+	// 03:00 UTC on Feb 17 (a Monday) is still Sunday Feb 16 in Los Angeles.
+	instant := time.Date(2025, 2, 17, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		zone   string
+		period types.Period
+		want   string
+	}{
+		{"America/Los_Angeles", types.Daily, "/leaderboard/daily/2025/2/16"},
+		{"America/Los_Angeles", types.Weekly, "/leaderboard/weekly/2025/7"},
+		{"Asia/Seoul", types.Daily, "/leaderboard/daily/2025/2/17"},
+		{"Asia/Seoul", types.Weekly, "/leaderboard/weekly/2025/8"},
+		{"UTC", types.Daily, "/leaderboard/daily/2025/2/17"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone+"/"+tt.period.String(), func(t *testing.T) {
+			types.SetTimezone(types.LoadTimezone(tt.zone))
+			if got := tt.period.URLPath(types.DayIn(instant)); got != tt.want {
+				t.Errorf("URLPath = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTimezoneFallback(t *testing.T) {
+	if got := types.LoadTimezone("").String(); got != types.DefaultTimezone {
+		t.Errorf("empty zone = %s, want %s", got, types.DefaultTimezone)
+	}
+	if got := types.LoadTimezone("Not/AZone").String(); got != types.DefaultTimezone {
+		t.Errorf("unknown zone = %s, want %s", got, types.DefaultTimezone)
+	}
+}
+
+func TestScraperMaxBodySize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 2048))
+	}))
+	defer srv.Close()
+
+	s := New()
+	s.SetMaxBodySize(1024)
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	if _, err := s.do(req); !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("oversized body: err = %v, want ErrBodyTooLarge", err)
+	}
+
+	s.SetMaxBodySize(2048)
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	resp, err := s.do(req)
+	if err != nil {
+		t.Fatalf("body at the cap: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); len(body) != 2048 {
+		t.Fatalf("body length = %d, want 2048", len(body))
+	}
+
+	if _, err := s.readBody(strings.NewReader(strings.Repeat("x", 2049))); !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("readBody over the cap: err = %v, want ErrBodyTooLarge", err)
+	}
+}
+
+func TestMaxBodySizeFromEnv(t *testing.T) {
+	t.Setenv("PHTUI_MAX_BODY_MB", "")
+	if got := MaxBodySizeFromEnv(); got != DefaultMaxBodySize {
+		t.Fatalf("default = %d, want %d", got, DefaultMaxBodySize)
+	}
+	t.Setenv("PHTUI_MAX_BODY_MB", "3")
+	if got := MaxBodySizeFromEnv(); got != 3<<20 {
+		t.Fatalf("3MB = %d", got)
+	}
+	t.Setenv("PHTUI_MAX_BODY_MB", "-1")
+	if got := MaxBodySizeFromEnv(); got != DefaultMaxBodySize {
+		t.Fatalf("invalid = %d, want default", got)
+	}
+}
+
+func TestScraperRequestTimeout(t *testing.T) {
+	if got := New().client.Timeout; got != DefaultRequestTimeout {
+		t.Fatalf("New timeout = %v, want %v", got, DefaultRequestTimeout)
+	}
+	if got := NewWithOptions(ScraperOptions{}).client.Timeout; got != DefaultRequestTimeout {
+		t.Fatalf("zero option timeout = %v, want %v", got, DefaultRequestTimeout)
+	}
+	s := NewWithOptions(ScraperOptions{RequestTimeout: 30 * time.Second})
+	if got := s.client.Timeout; got != 30*time.Second {
+		t.Fatalf("option timeout = %v, want 30s", got)
+	}
+	s.SetRequestTimeout(0)
+	if got := s.client.Timeout; got != DefaultRequestTimeout {
+		t.Fatalf("reset timeout = %v, want %v", got, DefaultRequestTimeout)
+	}
+}
+
+func TestScraperRetriesTransientFailures(t *testing.T) {
+	page, err := os.ReadFile("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2025, 2, 10, 0, 0, 0, 0, types.Timezone())
+
+	tests := []struct {
+		name         string
+		maxRetries   int
+		fail         func(w http.ResponseWriter) // the failure served before successes
+		failures     int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{"503 twice then success", 0, func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }, 2, false, 3},
+		{"502 beyond the retry limit", 1, func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) }, 5, true, 2},
+		{"retries disabled", -1, func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }, 1, true, 1},
+		{"connection reset twice then success", 0, func(w http.ResponseWriter) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		}, 2, false, 3},
+		{"404 is not retried", 0, func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) }, 5, true, 1},
+		{"cloudflare challenge is not retried", 0, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<title>Just a moment...</title>")
+		}, 5, true, 1},
+		{"cloudflare challenge behind a 503 is not retried", 0, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, `<title>Just a moment...</title><script>window._cf_chl_opt={}</script>`)
+		}, 5, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				if attempts <= tt.failures {
+					tt.fail(w)
+					return
+				}
+				w.Write(page)
+			}))
+			defer srv.Close()
+
+			s := NewWithOptions(ScraperOptions{MaxRetries: tt.maxRetries})
+			s.retryDelay = 0
+			redirectTo(t, s, srv.URL)
+
+			_, err := s.GetLeaderboard(types.Daily, day)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLeaderboard err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestScraperContextCancelsRetries(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := New()
+	s.retryDelay = time.Hour
+	redirectTo(t, s, srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := s.GetProductDetailContext(ctx, "alpha")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetProductDetailContext err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancellation took %s, want the request to stop during its retry backoff", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.GetLeaderboardContext(canceled, types.Daily, types.Today()); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetLeaderboardContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, _, err := s.GetCategoryProductsContext(canceled, "productivity"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCategoryProductsContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, _, _, _, err := s.GetCategoryProductsPageContext(canceled, "productivity", 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCategoryProductsPageContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, _, _, _, _, err := s.SearchProductsPageContext(canceled, "notes", 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("SearchProductsPageContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, err := s.GetAllCategoriesContext(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAllCategoriesContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, err := s.GetUpcomingProductsContext(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetUpcomingProductsContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, err := s.GetCategoryTreeContext(canceled, "productivity"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCategoryTreeContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, err := s.GetFeaturedTimesContext(canceled, types.Daily, types.Today()); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetFeaturedTimesContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if _, err := s.GetRankHistoryContext(canceled, "alpha"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetRankHistoryContext on a canceled context: err = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d after canceled calls, want 1", attempts)
+	}
+}
+
+func TestScraperRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/leaderboard/daily", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/leaderboard/daily/", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/leaderboard/daily/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/login?origin=leaderboard", http.StatusFound)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, "<html><body>Sign in</body></html>")
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/content", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/content", func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, "ok")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := New()
+	req, _ := http.NewRequest("GET", srv.URL+"/leaderboard/daily", nil)
+	if _, err := s.do(req); !errors.Is(err, ErrRedirectToLogin) {
+		t.Fatalf("redirect to login: err = %v, want ErrRedirectToLogin", err)
+	}
+
+	req, _ = http.NewRequest("GET", srv.URL+"/loop", nil)
+	if _, err := s.do(req); !errors.Is(err, ErrTooManyRedirects) {
+		t.Fatalf("redirect loop: err = %v, want ErrTooManyRedirects", err)
+	}
+
+	req, _ = http.NewRequest("GET", srv.URL+"/moved", nil)
+	resp, err := s.do(req)
+	if err != nil {
+		t.Fatalf("single redirect: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Fatalf("body = %q, want the redirect target", body)
+	}
+}
+
+func TestGetCategoryProductsPage(t *testing.T) {
+	page, err := os.ReadFile("../testdata/category_products_page2.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requested []string
+	s := New()
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+	})
+
+	for range 2 {
+		products, categories, hasNext, pagesCount, err := s.GetCategoryProductsPage("ai-agents", 2)
+		if err != nil {
+			t.Fatalf("GetCategoryProductsPage: %v", err)
+		}
+		if len(products) != 2 || products[0].Rank() != 16 || len(categories) != 2 || !hasNext || pagesCount != 32 {
+			t.Fatalf("page 2 = %d products (first rank %d), %d categories, hasNext %v, pagesCount %d", len(products), products[0].Rank(), len(categories), hasNext, pagesCount)
+		}
+	}
+	if want := []string{CategoryURL("ai-agents") + "?page=2"}; !reflect.DeepEqual(requested, want) {
+		t.Fatalf("requested %v, want %v (the second call cached)", requested, want)
+	}
+}
+
+func TestGetProductDetailUnavailable(t *testing.T) {
+	page, err := os.ReadFile("../testdata/product_unavailable.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetches := 0
+	s := New()
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetches++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+	})
+
+	for range 2 {
+		if _, err := s.GetProductDetail("gone-app"); !errors.Is(err, types.ErrProductUnavailable) {
+			t.Fatalf("GetProductDetail err = %v, want ErrProductUnavailable", err)
+		}
+	}
+	if fetches != 2 {
+		t.Fatalf("fetches = %d, want 2 (unavailable pages aren't cached)", fetches)
+	}
+}
+
+func TestGetHydrationOnly(t *testing.T) {
+	page, err := os.ReadFile("../testdata/leaderboard_nextdata.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetches := 0
+	s := New()
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetches++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+	})
+
+	date := time.Date(2025, 2, 18, 0, 0, 0, 0, time.UTC)
+	products, err := s.GetLeaderboard(types.Daily, date)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	hydrationOnly, err := s.GetHydrationOnly(types.Daily, date)
+	if err != nil {
+		t.Fatalf("GetHydrationOnly: %v", err)
+	}
+	// The fixture has no cards: every product came from hydration data.
+	if len(hydrationOnly) != len(products) {
+		t.Fatalf("hydrationOnly = %v, want all %d products", hydrationOnly, len(products))
+	}
+	for _, p := range products {
+		if !hydrationOnly[p.Slug()] {
+			t.Errorf("%s not marked hydration-only", p.Slug())
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("fetches = %d, want 1 (cached with the leaderboard)", fetches)
+	}
+}
+
+func TestGetHydrationOnlyAfterRestart(t *testing.T) {
+	page, err := os.ReadFile("../testdata/leaderboard_nextdata.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	date := time.Date(2025, 2, 18, 0, 0, 0, 0, time.UTC)
+	first := NewWithCache(newTestDiskCache(t, dir))
+	first.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+	})
+	products, err := first.GetLeaderboard(types.Daily, date)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+
+	second := NewWithCache(newTestDiskCache(t, dir))
+	second.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected fetch of %s", r.URL)
+		return nil, errors.New("offline")
+	})
+	hydrationOnly, err := second.GetHydrationOnly(types.Daily, date)
+	if err != nil {
+		t.Fatalf("GetHydrationOnly: %v", err)
+	}
+	if len(hydrationOnly) != len(products) {
+		t.Fatalf("hydrationOnly after restart = %v, want all %d products", hydrationOnly, len(products))
+	}
+}
+
+func TestInvalidateLeaderboard(t *testing.T) {
+	page, err := os.ReadFile("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetches := 0
+	s := New()
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetches++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+	})
+
+	date := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	for range 2 {
+		if _, err := s.GetLeaderboard(types.Daily, date); err != nil {
+			t.Fatalf("GetLeaderboard: %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("fetches = %d, want 1 before invalidating", fetches)
+	}
+	s.InvalidateLeaderboard(types.Daily, date)
+	if _, err := s.GetLeaderboard(types.Daily, date); err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if fetches != 2 {
+		t.Fatalf("fetches = %d, want 2 after invalidating", fetches)
+	}
+}
+
+func TestTodayLeaderboardExpiresAtMidnight(t *testing.T) {
+	page, err := os.ReadFile("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetches := 0
+	s := New()
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetches++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+	})
+	loc := types.Timezone()
+	now := time.Date(2025, 2, 18, 23, 30, 0, 0, loc)
+	s.now = func() time.Time { return now }
+	day := time.Date(2025, 2, 18, 0, 0, 0, 0, loc)
+
+	fetch := func(period types.Period, date time.Time) {
+		t.Helper()
+		if _, err := s.GetLeaderboard(period, date); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fetch(types.Daily, day)
+	fetch(types.Weekly, day)
+	now = now.Add(20 * time.Minute)
+	fetch(types.Daily, day)
+	if fetches != 2 {
+		t.Fatalf("fetches before midnight = %d, want 2", fetches)
+	}
+
+	// Past midnight the cached list is stale even though the TTL isn't up.
+	now = time.Date(2025, 2, 19, 0, 5, 0, 0, loc)
+	if _, err := s.GetFeaturedTimes(types.Daily, day); err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 3 {
+		t.Fatalf("fetches after midnight = %d, want 3", fetches)
+	}
+	fetch(types.Weekly, day)
+	if fetches != 3 {
+		t.Fatalf("the weekly leaderboard was refetched at midnight (%d fetches)", fetches)
+	}
+
+	// The finished day's list is now final and stays cached.
+	now = now.Add(48 * time.Hour)
+	fetch(types.Daily, day)
+	if fetches != 3 {
+		t.Fatalf("fetches for a finished day = %d, want 3", fetches)
+	}
+}
+
+func TestTodayLeaderboardExpiresAtMidnightAfterRestart(t *testing.T) {
+	page, err := os.ReadFile("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	loc := types.Timezone()
+	now := time.Date(2025, 2, 18, 23, 30, 0, 0, loc)
+	day := time.Date(2025, 2, 18, 0, 0, 0, 0, loc)
+	fetches := 0
+	// start builds a scraper on a DiskCache in dir, as after a restart.
+	start := func() *Scraper {
+		t.Helper()
+		cache, err := NewDiskCache(dir, 24*time.Hour, 0)
+		if err != nil {
+			t.Fatalf("NewDiskCache: %v", err)
+		}
+		cache.now = func() time.Time { return now }
+		s := NewWithCache(cache)
+		s.now = func() time.Time { return now }
+		s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			fetches++
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(page))), Request: r}, nil
+		})
+		return s
+	}
+
+	if _, err := start().GetLeaderboard(types.Daily, day); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := start().GetLeaderboard(types.Daily, day); err != nil || fetches != 1 {
+		t.Fatalf("restart before midnight: fetches = %d, %v; want the disk copy", fetches, err)
+	}
+
+	now = time.Date(2025, 2, 19, 0, 5, 0, 0, loc)
+	if _, err := start().GetLeaderboard(types.Daily, day); err != nil || fetches != 2 {
+		t.Fatalf("restart after midnight: fetches = %d, %v; want a refetch", fetches, err)
+	}
+}
+
+func TestScraperCacheTTL(t *testing.T) {
+	leaderboard, err := os.ReadFile("../testdata/leaderboard_daily.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail, err := os.ReadFile("../testdata/product_detail.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc := types.Timezone()
+	day := time.Date(2025, 2, 10, 0, 0, 0, 0, loc)
+
+	for _, tt := range []struct {
+		name        string
+		ttl         time.Duration
+		wantRefetch bool
+	}{
+		{"expired entries are refetched", time.Hour, true},
+		{"zero TTL caches forever", 0, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := map[string]int{}
+			s := NewWithOptions(ScraperOptions{CacheTTL: tt.ttl})
+			s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				body := string(leaderboard)
+				if strings.HasPrefix(r.URL.Path, "/products/") {
+					body = string(detail)
+				}
+				fetches[r.URL.Path]++
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+			})
+			now := time.Date(2025, 2, 18, 12, 0, 0, 0, loc)
+			s.now = func() time.Time { return now }
+
+			fetchAll := func() {
+				t.Helper()
+				if _, err := s.GetLeaderboard(types.Daily, day); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := s.GetProductDetail("demo"); err != nil {
+					t.Fatal(err)
+				}
+				if _, _, _, _, _, err := s.SearchProductsPage("demo", 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			fetchAll()
+			now = now.Add(59 * time.Minute)
+			fetchAll()
+			for path, n := range fetches {
+				if n != 1 {
+					t.Fatalf("%s fetched %d times within the TTL, want 1", path, n)
+				}
+			}
+			if len(fetches) != 3 {
+				t.Fatalf("fetched paths = %v, want leaderboard, detail and search", fetches)
+			}
+
+			now = now.Add(2 * time.Minute)
+			fetchAll()
+			want := 1
+			if tt.wantRefetch {
+				want = 2
+			}
+			for path, n := range fetches {
+				if n != want {
+					t.Errorf("%s fetched %d times after the TTL, want %d", path, n, want)
+				}
+			}
+		})
+	}
+}
+
+func TestScraperCustomHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	s := New()
+	s.SetHeaders(map[string]string{
+		"referer":        "https://www.google.com/",
+		"Sec-Fetch-Site": "cross-site",
+		"User-Agent":     "custom-agent",
+	})
+	s.SetCookie(" session=abc; theme=dark ")
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := s.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	for name, want := range map[string]string{
+		"Referer":        "https://www.google.com/",
+		"Sec-Fetch-Site": "cross-site",
+		"User-Agent":     "custom-agent",
+		"Cookie":         "session=abc; theme=dark",
+	} {
+		if got.Get(name) != want {
+			t.Errorf("%s = %q, want %q", name, got.Get(name), want)
+		}
+	}
+
+	s.SetCookie("")
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	if resp, err = s.do(req); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got.Get("Cookie") != "" || got.Get("Referer") == "" {
+		t.Errorf("after clearing the cookie: Cookie = %q, Referer = %q", got.Get("Cookie"), got.Get("Referer"))
+	}
+}
+
+func TestHeadersFromEnv(t *testing.T) {
+	t.Setenv("PHTUI_HEADERS", "Referer: https://www.google.com/; Sec-Fetch-Mode:navigate;;bogus; : empty")
+	want := map[string]string{"Referer": "https://www.google.com/", "Sec-Fetch-Mode": "navigate"}
+	if got := HeadersFromEnv(); !reflect.DeepEqual(got, want) {
+		t.Errorf("HeadersFromEnv() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
@@ -12,6 +13,10 @@ import (
 	"github.com/qyinm/phtui/types"
 )
 
+// ErrCloudflareChallenge reports that Product Hunt served a Cloudflare
+// challenge instead of the requested page. Retrying won't help.
+var ErrCloudflareChallenge = errors.New("blocked by Cloudflare challenge; interactive browser or API token is required")
+
 // ParseSearchResults parses Product Hunt search HTML.
 // Search page markup differs from leaderboard markup, so parse with
 // broader selectors anchored to main content.
@@ -22,7 +27,7 @@ func ParseSearchResults(reader io.Reader) ([]types.Product, error) {
 	}
 	rawText := string(raw)
 	if looksLikeCloudflareChallenge(rawText) {
-		return nil, fmt.Errorf("search blocked by Cloudflare challenge; interactive browser or API token is required")
+		return nil, ErrCloudflareChallenge
 	}
 
 	if products := parseHydrationSearchProducts(rawText); len(products) > 0 {
@@ -84,6 +89,8 @@ func ParseSearchResults(reader io.Reader) ([]types.Product, error) {
 			slug,
 			thumbnailURL,
 			len(products)+1,
+			0,
+			false,
 		))
 	})
 
@@ -96,7 +103,9 @@ func ParseSearchResults(reader io.Reader) ([]types.Product, error) {
 
 var searchBlockRe = regexp.MustCompile(`(?s)"productSearch":\{"__typename":"ProductSearchConnection","edges":\[(.*?)\],"pageInfo":\{`)
 var searchNodeRe = regexp.MustCompile(`(?s)"node":\{"__typename":"Product","id":"[^"]+","name":"([^"]+)","tagline":"([^"]*)","slug":"([^"]+)".*?"reviewsCount":([0-9]+).*?"logoUuid":"([^"]*)"`)
-var searchPageInfoRe = regexp.MustCompile(`"productSearch":\{"__typename":"ProductSearchConnection","edges":\[.*?\],"pageInfo":\{"__typename":"PageInfo","page":([0-9]+),"hasPreviousPage":(true|false),"hasNextPage":(true|false)\},"pagesCount":([0-9]+)`)
+var searchRatingRe = regexp.MustCompile(`"reviewsRating":([0-9.]+)`)
+var searchOfflineRe = regexp.MustCompile(`"isNoLongerOnline":true`)
+var searchPageInfoRe = regexp.MustCompile(`"productSearch":\{"__typename":"ProductSearchConnection","edges":\[.*?\],"pageInfo":\{"__typename":"PageInfo","page":([0-9]+),"hasPreviousPage":(true|false),"hasNextPage":(true|false)\}(?:,"pagesCount":([0-9]+))?`)
 
 func parseHydrationSearchProducts(raw string) []types.Product {
 	blocks := searchBlockRe.FindAllStringSubmatch(raw, -1)
@@ -111,15 +120,27 @@ func parseHydrationSearchProducts(raw string) []types.Product {
 			continue
 		}
 		nodes := searchNodeRe.FindAllStringSubmatch(b[1], -1)
-		for _, n := range nodes {
+		locs := searchNodeRe.FindAllStringIndex(b[1], -1)
+		for i, n := range nodes {
 			if len(n) < 6 {
 				continue
 			}
+			// isNoLongerOnline follows logoUuid, past the end of the node
+			// match, so look at everything up to the next node.
+			nodeEnd := len(b[1])
+			if i+1 < len(locs) {
+				nodeEnd = locs[i+1][0]
+			}
+			offline := searchOfflineRe.MatchString(b[1][locs[i][0]:nodeEnd])
 			name := strings.TrimSpace(decodeJSONEscaped(n[1]))
 			tagline := strings.TrimSpace(decodeJSONEscaped(n[2]))
 			slug := strings.TrimSpace(decodeJSONEscaped(n[3]))
 			reviewCount, _ := strconv.Atoi(n[4])
 			logo := strings.TrimSpace(decodeJSONEscaped(n[5]))
+			var rating float64
+			if m := searchRatingRe.FindStringSubmatch(n[0]); len(m) == 2 {
+				rating, _ = strconv.ParseFloat(m[1], 64)
+			}
 
 			if slug == "" || name == "" {
 				continue
@@ -137,6 +158,8 @@ func parseHydrationSearchProducts(raw string) []types.Product {
 				slug,
 				logo,
 				len(products)+1,
+				rating,
+				offline,
 			))
 		}
 	}
@@ -144,6 +167,9 @@ func parseHydrationSearchProducts(raw string) []types.Product {
 	return products
 }
 
+// parseSearchPageInfo extracts page, hasPrev, hasNext and pagesCount from
+// the search hydration. pagesCount is 0 when the field is missing, so a
+// renamed pagesCount doesn't throw away the rest of the page info.
 func parseSearchPageInfo(raw string) (int, bool, bool, int, bool) {
 	m := searchPageInfoRe.FindStringSubmatch(raw)
 	if len(m) < 5 {
@@ -152,10 +178,13 @@ func parseSearchPageInfo(raw string) (int, bool, bool, int, bool) {
 	page, _ := strconv.Atoi(m[1])
 	hasPrev := m[2] == "true"
 	hasNext := m[3] == "true"
-	pagesCount, _ := strconv.Atoi(m[4])
 	if page <= 0 {
 		page = 1
 	}
+	if m[4] == "" {
+		return page, hasPrev, hasNext, 0, true
+	}
+	pagesCount, _ := strconv.Atoi(m[4])
 	if pagesCount <= 0 {
 		pagesCount = 1
 	}
@@ -1,8 +1,16 @@
 package scraper
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/qyinm/phtui/types"
 )
 
 func TestParseSearchResults(t *testing.T) {
@@ -75,4 +83,299 @@ func TestParseHydrationSearchProducts(t *testing.T) {
 	if got[0].Slug() != "claude" || got[1].Slug() != "claude-code" {
 		t.Fatalf("unexpected slugs: %q %q", got[0].Slug(), got[1].Slug())
 	}
+	if got[0].Rating() != 4.96 || got[1].Rating() != 5 {
+		t.Fatalf("unexpected ratings: %v %v", got[0].Rating(), got[1].Rating())
+	}
+	if got[0].CommentCount() != 627 {
+		t.Fatalf("unexpected review count: %d", got[0].CommentCount())
+	}
+}
+
+func TestParseHydrationSearchProductsOffline(t *testing.T) {
+	raw := `"productSearch":{"__typename":"ProductSearchConnection","edges":[` +
+		`{"__typename":"ProductEdge","node":{"__typename":"Product","id":"1","name":"Gone App","tagline":"Shut down","slug":"gone-app","reviewsRating":3,"reviewsCount":4,"logoUuid":"logo1.png","isNoLongerOnline":true}},` +
+		`{"__typename":"ProductEdge","node":{"__typename":"Product","id":"2","name":"Live App","tagline":"Still here","slug":"live-app","reviewsRating":5,"reviewsCount":9,"logoUuid":"logo2.png","isNoLongerOnline":false}}` +
+		`],"pageInfo":{"__typename":"PageInfo"}}`
+
+	got := parseHydrationSearchProducts(raw)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(got))
+	}
+	if !got[0].Offline() {
+		t.Errorf("%s should be offline", got[0].Slug())
+	}
+	if got[1].Offline() {
+		t.Errorf("%s should be online", got[1].Slug())
+	}
+}
+
+func TestParseSearchPageInfo(t *testing.T) {
+	const edges = `"productSearch":{"__typename":"ProductSearchConnection","edges":[{"__typename":"ProductEdge"}],`
+	tests := []struct {
+		name                 string
+		raw                  string
+		page, pagesCount     int
+		hasPrev, hasNext, ok bool
+	}{
+		{
+			name: "full page info",
+			raw:  edges + `"pageInfo":{"__typename":"PageInfo","page":2,"hasPreviousPage":true,"hasNextPage":true},"pagesCount":7}`,
+			page: 2, hasPrev: true, hasNext: true, pagesCount: 7, ok: true,
+		},
+		{
+			name: "pagesCount missing",
+			raw:  edges + `"pageInfo":{"__typename":"PageInfo","page":3,"hasPreviousPage":true,"hasNextPage":false}}`,
+			page: 3, hasPrev: true, hasNext: false, pagesCount: 0, ok: true,
+		},
+		{
+			name: "pagesCount renamed",
+			raw:  edges + `"pageInfo":{"__typename":"PageInfo","page":1,"hasPreviousPage":false,"hasNextPage":true},"totalPages":4}`,
+			page: 1, hasPrev: false, hasNext: true, pagesCount: 0, ok: true,
+		},
+		{
+			name: "no page info",
+			raw:  edges + `"somethingElse":{}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, hasPrev, hasNext, pagesCount, ok := parseSearchPageInfo(tt.raw)
+			if page != tt.page || hasPrev != tt.hasPrev || hasNext != tt.hasNext || pagesCount != tt.pagesCount || ok != tt.ok {
+				t.Fatalf("got (%d, %v, %v, %d, %v), want (%d, %v, %v, %d, %v)",
+					page, hasPrev, hasNext, pagesCount, ok, tt.page, tt.hasPrev, tt.hasNext, tt.pagesCount, tt.ok)
+			}
+		})
+	}
+}
+
+// fakeSearchPager serves numbered pages of DefaultSearchPageSize products and fails
+// the configured page a fixed number of times.
+type fakeSearchPager struct {
+	pages    int
+	failPage int
+	failures int
+	failErr  error
+	calls    map[int]int
+}
+
+func (f *fakeSearchPager) fetch(query string, page int) ([]types.Product, int, bool, bool, int, error) {
+	f.calls[page]++
+	if page == f.failPage && f.calls[page] <= f.failures {
+		return nil, page, page > 1, false, 0, f.failErr
+	}
+	products := make([]types.Product, 0, DefaultSearchPageSize)
+	for i := 0; i < DefaultSearchPageSize; i++ {
+		slug := fmt.Sprintf("p%d-%d", page, i)
+		products = append(products, types.NewProduct(slug, "", nil, 0, 0, slug, "", i+1, 0, false))
+	}
+	return products, page, page > 1, page < f.pages, f.pages, nil
+}
+
+func TestCollectSearchResultsPageFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		failPage  int
+		failures  int
+		failErr   error
+		wantCount int
+		wantErr   bool
+		wantCalls int
+	}{
+		// The page fetch has already retried by the time it fails here.
+		{"failed middle page truncates", 2, 1, errors.New("timeout"), DefaultSearchPageSize, false, 1},
+		{"cloudflare middle page stops", 2, 1, ErrCloudflareChallenge, DefaultSearchPageSize, false, 1},
+		{"cloudflare first page errors", 1, 1, fmt.Errorf("status 403: %w", ErrCloudflareChallenge), 0, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := &fakeSearchPager{pages: 3, failPage: tt.failPage, failures: tt.failures, failErr: tt.failErr, calls: map[int]int{}}
+			products, err := collectSearchResults("demo", pager.fetch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(products) != tt.wantCount {
+				t.Errorf("products = %d, want %d", len(products), tt.wantCount)
+			}
+			if got := pager.calls[tt.failPage]; got != tt.wantCalls {
+				t.Errorf("calls for page %d = %d, want %d", tt.failPage, got, tt.wantCalls)
+			}
+			for i, p := range products {
+				if p.Rank() != i+1 {
+					t.Fatalf("product[%d] rank = %d, want %d", i, p.Rank(), i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchProductsRetriesTransientPage(t *testing.T) {
+	// Three pages of distinct products; page 2 fails once with a 503.
+	var mu sync.Mutex
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		mu.Lock()
+		calls[page]++
+		n := calls[page]
+		mu.Unlock()
+		if page == "2" && n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		nodes := make([]string, DefaultSearchPageSize)
+		for i := range nodes {
+			nodes[i] = fmt.Sprintf(`{"__typename":"ProductEdge","node":{"__typename":"Product","id":"%s%d","name":"Product %s-%d","tagline":"","slug":"p%s-%d","reviewsRating":0,"reviewsCount":0,"logoUuid":""}}`, page, i, page, i, page, i)
+		}
+		fmt.Fprintf(w, `<html><body><script>{"productSearch":{"__typename":"ProductSearchConnection","edges":[%s],"pageInfo":{"__typename":"PageInfo","page":%s,"hasPreviousPage":false,"hasNextPage":%v}}}</script></body></html>`,
+			strings.Join(nodes, ","), page, page != "3")
+	}))
+	defer srv.Close()
+
+	s := New()
+	s.retryDelay = 0
+	redirectTo(t, s, srv.URL)
+	products, err := s.SearchProducts("demo")
+	if err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+	if len(products) != 3*DefaultSearchPageSize {
+		t.Fatalf("products = %d, want all 3 pages (%d)", len(products), 3*DefaultSearchPageSize)
+	}
+	if calls["2"] != 2 {
+		t.Errorf("page 2 requests = %d, want 2", calls["2"])
+	}
+}
+
+func TestSearchEmptyQuery(t *testing.T) {
+	s := New()
+	s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("empty query hit the network: %s", r.URL)
+		return nil, nil
+	})
+	trending := []types.Product{types.NewProduct("Top", "", nil, 300, 0, "top", "", 1, 0, false)}
+	s.cache.Set(LeaderboardURL(types.Daily, types.Today()), trending)
+
+	// Strict by default: nothing comes back.
+	if got, err := s.SearchProducts("  "); err != nil || got != nil {
+		t.Fatalf("strict SearchProducts = %v, %v; want nil, nil", got, err)
+	}
+	if got, page, _, _, pages, err := s.SearchProductsPage("", 2); err != nil || got != nil || page != 1 || pages != 0 {
+		t.Fatalf("strict SearchProductsPage = %v, page %d/%d, %v", got, page, pages, err)
+	}
+
+	s.SetTrendingOnEmptyQuery(true)
+	got, err := s.SearchProducts("")
+	if err != nil || len(got) != 1 || got[0].Slug() != "top" {
+		t.Fatalf("fallback SearchProducts = %v, %v; want today's leaderboard", got, err)
+	}
+	got, page, hasPrev, hasNext, pages, err := s.SearchProductsPage(" ", 3)
+	if err != nil || len(got) != 1 || page != 1 || hasPrev || hasNext || pages != 1 {
+		t.Fatalf("fallback SearchProductsPage = %v, page %d/%d prev=%v next=%v, %v", got, page, pages, hasPrev, hasNext, err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// searchPageHTML returns a search page of n products. With pageInfo set it
+// carries the hydration page info saying whether another page follows;
+// without it the scraper has to guess from the page size.
+func searchPageHTML(n int, pageInfo, hasNext bool) string {
+	if !pageInfo {
+		var b strings.Builder
+		b.WriteString("<html><body><main>")
+		for i := range n {
+			fmt.Fprintf(&b, `<article><a href="/products/p%d">Product %d</a></article>`, i, i)
+		}
+		b.WriteString("</main></body></html>")
+		return b.String()
+	}
+	nodes := make([]string, n)
+	for i := range n {
+		nodes[i] = fmt.Sprintf(`{"__typename":"ProductEdge","node":{"__typename":"Product","id":"%d","name":"Product %d","tagline":"","slug":"p%d","reviewsRating":0,"reviewsCount":0,"logoUuid":""}}`, i, i, i)
+	}
+	return `<html><body><script>{"productSearch":{"__typename":"ProductSearchConnection","edges":[` + strings.Join(nodes, ",") +
+		fmt.Sprintf(`],"pageInfo":{"__typename":"PageInfo","page":1,"hasPreviousPage":false,"hasNextPage":%v}}}</script></body></html>`, hasNext)
+}
+
+func TestSearchPageSize(t *testing.T) {
+	serve := func(s *Scraper, page string) {
+		s.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(page)), Request: r}, nil
+		})
+	}
+	hasNext := func(s *Scraper, query string) bool {
+		t.Helper()
+		_, _, _, next, _, err := s.SearchProductsPage(query, 1)
+		if err != nil {
+			t.Fatalf("SearchProductsPage(%q): %v", query, err)
+		}
+		return next
+	}
+
+	// A configured size decides whether a page without page info is full.
+	s := New()
+	s.SetSearchPageSize(5)
+	serve(s, searchPageHTML(5, false, false))
+	if !hasNext(s, "five") {
+		t.Error("a page of 5 with page size 5 should have a next page")
+	}
+	serve(s, searchPageHTML(4, false, false))
+	if hasNext(s, "four") {
+		t.Error("a page of 4 with page size 5 should be the last")
+	}
+
+	// Without configuration, a page known to be followed by another sets
+	// the size used for later guesses.
+	s = New()
+	serve(s, searchPageHTML(7, false, false))
+	if hasNext(s, "before") {
+		t.Error("a page of 7 with the default size should be the last")
+	}
+	serve(s, searchPageHTML(7, true, true))
+	if !hasNext(s, "full") {
+		t.Fatal("page info says another page follows")
+	}
+	serve(s, searchPageHTML(7, false, false))
+	if !hasNext(s, "after") {
+		t.Error("a page of 7 after a full page of 7 should have a next page")
+	}
+}
+
+func TestSearchPageSizeFromEnv(t *testing.T) {
+	for raw, want := range map[string]int{"": DefaultSearchPageSize, "20": 20, " 5 ": 5, "0": DefaultSearchPageSize, "x": DefaultSearchPageSize} {
+		t.Setenv("PHTUI_SEARCH_PAGE_SIZE", raw)
+		if got := SearchPageSizeFromEnv(); got != want {
+			t.Errorf("PHTUI_SEARCH_PAGE_SIZE=%q: got %d, want %d", raw, got, want)
+		}
+	}
+}
+
+func TestCollectSearchResultsPageSize(t *testing.T) {
+	// Pages of 7 then a short last page; no page says whether more follow.
+	pages := [][]types.Product{}
+	for page, size := range []int{7, 7, 3} {
+		var products []types.Product
+		for i := range size {
+			slug := fmt.Sprintf("p%d-%d", page, i)
+			products = append(products, types.NewProduct(slug, "", nil, 0, 0, slug, "", i+1, 0, false))
+		}
+		pages = append(pages, products)
+	}
+	calls := 0
+	fetch := func(_ string, page int) ([]types.Product, int, bool, bool, int, error) {
+		calls++
+		if page > len(pages) {
+			return nil, page, true, false, 0, nil
+		}
+		return pages[page-1], page, page > 1, true, 0, nil
+	}
+	products, err := collectSearchResults("demo", fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 17 || calls != 3 {
+		t.Fatalf("collected %d products in %d calls, want 17 in 3", len(products), calls)
+	}
 }
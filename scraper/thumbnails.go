@@ -0,0 +1,184 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+const (
+	defaultThumbnailTTL     = 7 * 24 * time.Hour
+	defaultThumbnailMaxSize = 32 << 20 // 32 MiB
+	thumbnailExt            = ".img"
+	// maxThumbnailBytes skips images too big to be a list thumbnail.
+	maxThumbnailBytes = 2 << 20
+)
+
+// ThumbnailCache downloads product thumbnails into a directory so repeated
+// views reuse the file on disk instead of downloading again. Files are named
+// by a hash of the product slug, expire after a TTL, and the oldest are
+// evicted once the directory exceeds its size cap. Failed downloads are
+// skipped; nothing is written for them.
+type ThumbnailCache struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+	client  *http.Client
+	now     func() time.Time
+
+	mu       sync.Mutex
+	inflight map[string]bool
+}
+
+// NewThumbnailCache creates a ThumbnailCache rooted at dir, creating the
+// directory if needed. A non-positive ttl or maxSize selects the default
+// (7 days, 32 MiB).
+func NewThumbnailCache(dir string, ttl time.Duration, maxSize int64) (*ThumbnailCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = defaultThumbnailTTL
+	}
+	if maxSize <= 0 {
+		maxSize = defaultThumbnailMaxSize
+	}
+	return &ThumbnailCache{
+		dir:      dir,
+		ttl:      ttl,
+		maxSize:  maxSize,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+		inflight: make(map[string]bool),
+	}, nil
+}
+
+// ThumbnailCacheFromEnv returns the thumbnail cache enabled by
+// PHTUI_THUMBNAILS=true, stored under DefaultDataDir()/thumbnails and tuned
+// by PHTUI_THUMBNAIL_TTL and PHTUI_THUMBNAIL_MAX_MB. It returns nil when
+// disabled or when the directory can't be created.
+func ThumbnailCacheFromEnv() *ThumbnailCache {
+	enabled, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("PHTUI_THUMBNAILS")))
+	if !enabled {
+		return nil
+	}
+	dir, err := DefaultDataDir()
+	if err != nil {
+		return nil
+	}
+	ttl, _ := time.ParseDuration(strings.TrimSpace(os.Getenv("PHTUI_THUMBNAIL_TTL")))
+	maxMB, _ := strconv.ParseInt(strings.TrimSpace(os.Getenv("PHTUI_THUMBNAIL_MAX_MB")), 10, 64)
+	cache, err := NewThumbnailCache(filepath.Join(dir, "thumbnails"), ttl, maxMB<<20)
+	if err != nil {
+		return nil
+	}
+	return cache
+}
+
+// thumbnailPath is where the thumbnail for slug is stored.
+func (c *ThumbnailCache) thumbnailPath(slug string) string {
+	sum := sha256.Sum256([]byte(slug))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+thumbnailExt)
+}
+
+// Path returns the file holding slug's thumbnail if it has been downloaded
+// and hasn't expired.
+func (c *ThumbnailCache) Path(slug string) (string, bool) {
+	if c == nil || slug == "" {
+		return "", false
+	}
+	path := c.thumbnailPath(slug)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	if c.now().Sub(info.ModTime()) > c.ttl {
+		os.Remove(path)
+		return "", false
+	}
+	return path, true
+}
+
+// Fetch returns the file holding slug's thumbnail, downloading it from url
+// unless a fresh copy is already on disk.
+func (c *ThumbnailCache) Fetch(slug, url string) (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("thumbnail cache disabled")
+	}
+	if path, ok := c.Path(slug); ok {
+		return path, nil
+	}
+	if slug == "" || url == "" {
+		return "", fmt.Errorf("thumbnail for %q: missing slug or url", slug)
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("create thumbnail request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch thumbnail: unexpected status code: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxThumbnailBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read thumbnail: %w", err)
+	}
+	if len(data) > maxThumbnailBytes {
+		return "", fmt.Errorf("read thumbnail: %w", ErrBodyTooLarge)
+	}
+
+	path := c.thumbnailPath(slug)
+	if err := writeFileAtomic(path, data); err != nil {
+		return "", fmt.Errorf("write thumbnail: %w", err)
+	}
+	c.mu.Lock()
+	evictOldest(c.dir, thumbnailExt, c.maxSize)
+	c.mu.Unlock()
+	return path, nil
+}
+
+// Prefetch downloads the thumbnails of products in the background, skipping
+// ones already on disk or already being fetched. Failures are ignored.
+func (c *ThumbnailCache) Prefetch(products []types.Product) {
+	if c == nil {
+		return
+	}
+	var todo []types.Product
+	c.mu.Lock()
+	for _, p := range products {
+		if p.Slug() == "" || p.ThumbnailURL() == "" || c.inflight[p.Slug()] {
+			continue
+		}
+		c.inflight[p.Slug()] = true
+		todo = append(todo, p)
+	}
+	c.mu.Unlock()
+	if len(todo) == 0 {
+		return
+	}
+
+	go func() {
+		for _, p := range todo {
+			_, _ = c.Fetch(p.Slug(), p.ThumbnailURL())
+			c.mu.Lock()
+			delete(c.inflight, p.Slug())
+			c.mu.Unlock()
+		}
+	}()
+}
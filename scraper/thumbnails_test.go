@@ -0,0 +1,119 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func newThumbnailServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/missing.png") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(strings.Repeat("p", 400)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestThumbnailCacheReusesDisk(t *testing.T) {
+	var hits atomic.Int32
+	srv := newThumbnailServer(t, &hits)
+	dir := t.TempDir()
+	c, err := NewThumbnailCache(dir, time.Hour, 0)
+	if err != nil {
+		t.Fatalf("NewThumbnailCache: %v", err)
+	}
+
+	first, err := c.Fetch("demo", srv.URL+"/demo.png")
+	if err != nil {
+		t.Fatalf("first Fetch: %v", err)
+	}
+	second, err := c.Fetch("demo", srv.URL+"/demo.png")
+	if err != nil || second != first {
+		t.Fatalf("second Fetch = %q, %v; want %q", second, err, first)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("server hit %d times, want 1", hits.Load())
+	}
+	if path, ok := c.Path("demo"); !ok || path != c.thumbnailPath("demo") {
+		t.Fatalf("Path = %q, %v", path, ok)
+	}
+
+	// A new cache on the same directory, as after a restart, reads from disk too.
+	reopened, _ := NewThumbnailCache(dir, time.Hour, 0)
+	if _, err := reopened.Fetch("demo", srv.URL+"/demo.png"); err != nil || hits.Load() != 1 {
+		t.Fatalf("reopened Fetch: err=%v hits=%d", err, hits.Load())
+	}
+
+	// Once expired, the thumbnail is downloaded again.
+	reopened.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, ok := reopened.Path("demo"); ok {
+		t.Fatal("expired thumbnail still reported")
+	}
+	if _, err := reopened.Fetch("demo", srv.URL+"/demo.png"); err != nil || hits.Load() != 2 {
+		t.Fatalf("Fetch after expiry: err=%v hits=%d", err, hits.Load())
+	}
+}
+
+func TestThumbnailCacheSkipsFailures(t *testing.T) {
+	var hits atomic.Int32
+	srv := newThumbnailServer(t, &hits)
+	c, _ := NewThumbnailCache(t.TempDir(), time.Hour, 0)
+
+	if _, err := c.Fetch("gone", srv.URL+"/missing.png"); err == nil {
+		t.Fatal("expected an error for a 404 thumbnail")
+	}
+	if _, err := os.Stat(c.thumbnailPath("gone")); !os.IsNotExist(err) {
+		t.Fatalf("failed download left a file: %v", err)
+	}
+
+	c.Prefetch([]types.Product{
+		types.NewProduct("Gone", "", nil, 0, 0, "gone", srv.URL+"/missing.png", 1, 0, false),
+		types.NewProduct("Demo", "", nil, 0, 0, "demo", srv.URL+"/demo.png", 2, 0, false),
+	})
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := c.Path("demo"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Prefetch did not download past a failed thumbnail")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestThumbnailCacheSizeCap(t *testing.T) {
+	var hits atomic.Int32
+	srv := newThumbnailServer(t, &hits)
+	c, _ := NewThumbnailCache(t.TempDir(), time.Hour, 1000)
+
+	base := time.Now()
+	for i, slug := range []string{"a", "b"} {
+		if _, err := c.Fetch(slug, srv.URL+"/"+slug+".png"); err != nil {
+			t.Fatalf("Fetch(%s): %v", slug, err)
+		}
+		mod := base.Add(time.Duration(i) * time.Second)
+		os.Chtimes(c.thumbnailPath(slug), mod, mod)
+	}
+	if _, err := c.Fetch("c", srv.URL+"/c.png"); err != nil {
+		t.Fatalf("Fetch(c): %v", err)
+	}
+	if _, ok := c.Path("a"); ok {
+		t.Fatal("oldest thumbnail not evicted")
+	}
+	if _, ok := c.Path("c"); !ok {
+		t.Fatal("newest thumbnail evicted")
+	}
+}
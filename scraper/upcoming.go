@@ -0,0 +1,102 @@
+package scraper
+
+import (
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/qyinm/phtui/types"
+)
+
+// ParseUpcomingProducts parses Product Hunt's coming-soon page. Upcoming
+// products haven't launched yet, so vote and comment counts are left at zero.
+// A page without any product cards yields an empty slice, not an error.
+func ParseUpcomingProducts(reader io.Reader) ([]types.Product, error) {
+	doc, err := goquery.NewDocumentFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	root := doc.Find("main").First()
+	if root.Length() == 0 {
+		root = doc.Selection
+	}
+
+	products := make([]types.Product, 0)
+	seen := make(map[string]struct{})
+
+	root.Find("a[href^='/products/']").Each(func(_ int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		slug := normalizeProductSlug(href)
+		if slug == "" {
+			return
+		}
+		if _, ok := seen[slug]; ok {
+			return
+		}
+
+		card := link.Closest("[data-test^='upcoming-'],article,section,li")
+		if card.Length() == 0 {
+			card = link.Parent()
+		}
+
+		name := strings.TrimSpace(link.Text())
+		if name == "" {
+			name = strings.TrimSpace(card.Find("h1,h2,h3,h4,[data-test*='name']").First().Text())
+		}
+		if name == "" {
+			return
+		}
+
+		var categories []string
+		card.Find("a[href^='/topics/'],a[href^='/categories/']").Each(func(_ int, a *goquery.Selection) {
+			cat := strings.TrimSpace(a.Text())
+			if cat != "" {
+				categories = append(categories, cat)
+			}
+		})
+
+		thumbnailURL, _ := card.Find("img").First().Attr("src")
+
+		seen[slug] = struct{}{}
+		products = append(products, types.NewProduct(
+			name,
+			extractUpcomingTagline(card, name, categories),
+			categories,
+			0,
+			0,
+			slug,
+			thumbnailURL,
+			len(products)+1,
+			0,
+			false,
+		))
+	})
+
+	return products, nil
+}
+
+// extractUpcomingTagline is extractSearchTagline that also skips the
+// card's topic labels, follower counts and call-to-action text.
+func extractUpcomingTagline(card *goquery.Selection, name string, categories []string) string {
+	skip := map[string]struct{}{"notify me": {}, "get notified": {}}
+	for _, cat := range categories {
+		skip[strings.ToLower(cat)] = struct{}{}
+	}
+	nameFold := strings.ToLower(strings.TrimSpace(name))
+
+	var tagline string
+	card.Find("p,span").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		text := strings.TrimSpace(s.Text())
+		lower := strings.ToLower(text)
+		if text == "" || lower == nameFold || strings.HasPrefix(text, "#") || strings.HasSuffix(lower, "followers") {
+			return true
+		}
+		if _, ok := skip[lower]; ok {
+			return true
+		}
+		tagline = text
+		return false
+	})
+	return tagline
+}
@@ -0,0 +1,87 @@
+package scraper
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseUpcomingProducts(t *testing.T) {
+	f, err := os.Open("../testdata/upcoming.html")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer f.Close()
+
+	products, err := ParseUpcomingProducts(f)
+	if err != nil {
+		t.Fatalf("ParseUpcomingProducts error: %v", err)
+	}
+	if len(products) != 3 {
+		t.Fatalf("expected 3 products, got %d", len(products))
+	}
+
+	first := products[0]
+	if first.Slug() != "orbit-notes" || first.Name() != "Orbit Notes" {
+		t.Fatalf("unexpected first product: slug=%q name=%q", first.Slug(), first.Name())
+	}
+	if first.Tagline() != "Meeting notes that write themselves" {
+		t.Errorf("unexpected first tagline: %q", first.Tagline())
+	}
+	if got := first.Categories(); len(got) != 2 || got[0] != "Productivity" {
+		t.Errorf("unexpected first categories: %v", got)
+	}
+	if first.ThumbnailURL() != "https://ph-files.imgix.net/orbit-notes.png" {
+		t.Errorf("unexpected first thumbnail: %q", first.ThumbnailURL())
+	}
+	if first.VoteCount() != 0 || first.CommentCount() != 0 {
+		t.Errorf("upcoming product should have no votes or comments, got %d/%d", first.VoteCount(), first.CommentCount())
+	}
+
+	second := products[1]
+	if second.Slug() != "pixel-forge" || second.Name() != "Pixel Forge" {
+		t.Fatalf("unexpected second product: slug=%q name=%q", second.Slug(), second.Name())
+	}
+	if second.Tagline() != "Design assets from a single prompt" {
+		t.Errorf("unexpected second tagline: %q", second.Tagline())
+	}
+
+	for i, p := range products {
+		if p.Rank() != i+1 {
+			t.Errorf("product %d: expected rank %d, got %d", i, i+1, p.Rank())
+		}
+	}
+}
+
+func TestParseUpcomingProducts_MinimalData(t *testing.T) {
+	f, err := os.Open("../testdata/upcoming.html")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer f.Close()
+
+	products, err := ParseUpcomingProducts(f)
+	if err != nil {
+		t.Fatalf("ParseUpcomingProducts error: %v", err)
+	}
+	last := products[len(products)-1]
+	if last.Slug() != "quiet-inbox" || last.Name() != "Quiet Inbox" {
+		t.Fatalf("unexpected last product: slug=%q name=%q", last.Slug(), last.Name())
+	}
+	if last.Tagline() != "" || last.ThumbnailURL() != "" || len(last.Categories()) != 0 {
+		t.Errorf("expected bare product, got tagline=%q thumbnail=%q categories=%v",
+			last.Tagline(), last.ThumbnailURL(), last.Categories())
+	}
+}
+
+func TestParseUpcomingProducts_Empty(t *testing.T) {
+	r := strings.NewReader("<html><body><main><h1>Coming soon</h1></main></body></html>")
+
+	products, err := ParseUpcomingProducts(r)
+	if err != nil {
+		t.Fatalf("ParseUpcomingProducts should not error on empty page, got: %v", err)
+	}
+	if len(products) != 0 {
+		t.Errorf("expected 0 products, got %d", len(products))
+	}
+}
@@ -0,0 +1,71 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// LeaderboardURL returns the Product Hunt leaderboard URL for period and date.
+func LeaderboardURL(period types.Period, date time.Time) string {
+	return baseURL + period.URLPath(date)
+}
+
+// postSlugPrefix marks product slugs that refer to a launch post rather than
+// a product page.
+const postSlugPrefix = "posts/"
+
+// PostSlug returns the product slug used for a launch post that has no
+// product page. ProductURL and GetProductDetail resolve it to /posts/{slug}.
+func PostSlug(slug string) string {
+	return postSlugPrefix + slug
+}
+
+// isPostSlug reports whether slug was built by PostSlug.
+func isPostSlug(slug string) bool {
+	return strings.HasPrefix(slug, postSlugPrefix)
+}
+
+// ProductURL returns the Product Hunt page URL for slug: the product page,
+// or the launch post page for slugs built by PostSlug.
+func ProductURL(slug string) string {
+	if isPostSlug(slug) {
+		return baseURL + "/" + slug
+	}
+	return baseURL + "/products/" + slug
+}
+
+// CategoriesURL returns the URL of Product Hunt's category index.
+func CategoriesURL() string {
+	return baseURL + "/categories"
+}
+
+// CategoryURL returns the Product Hunt category page URL for slug.
+func CategoryURL(slug string) string {
+	return baseURL + "/categories/" + slug
+}
+
+// CategoryPageURL returns the URL of page of a Product Hunt category
+// listing; page 1 is the plain CategoryURL.
+func CategoryPageURL(slug string, page int) string {
+	if page <= 1 {
+		return CategoryURL(slug)
+	}
+	return fmt.Sprintf("%s?page=%d", CategoryURL(slug), page)
+}
+
+// UpcomingURL returns the URL of Product Hunt's coming-soon listing.
+func UpcomingURL() string {
+	return baseURL + "/coming-soon"
+}
+
+// SearchURL returns the Product Hunt search URL for query and page.
+func SearchURL(query string, page int) string {
+	if page < 1 {
+		page = 1
+	}
+	return fmt.Sprintf("%s/search?q=%s&page=%d", baseURL, url.QueryEscape(query), page)
+}
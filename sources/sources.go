@@ -0,0 +1,89 @@
+// Package sources builds the types.ProductSource selected by name, so the
+// TUI and MCP servers share one switch for picking a data backend.
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/qyinm/phtui/apisource"
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// Default is the source used when no name is given. The API source covers
+// less than the scraper, so it is only used when asked for by name.
+const Default = "scraper"
+
+// API is the source backed by the official Product Hunt API.
+const API = "api"
+
+// ErrUnknownSource is returned by New for names that aren't registered.
+var ErrUnknownSource = errors.New("unknown source")
+
+// factories maps each source name to a constructor.
+var factories = map[string]func() (types.ProductSource, error){
+	"scraper": newScraper,
+	API:       newAPISource,
+}
+
+// Names returns the registered source names in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New instantiates the source registered under name (case-insensitive).
+// An empty name selects Default.
+func New(name string) (types.ProductSource, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		key = Default
+	}
+	factory, ok := factories[key]
+	if !ok {
+		return nil, fmt.Errorf("%w %q; expected one of: %s", ErrUnknownSource, name, strings.Join(Names(), ", "))
+	}
+	return factory()
+}
+
+// newScraper builds the web scraper configured from the environment.
+func newScraper() (types.ProductSource, error) {
+	proxy, err := scraper.ProxyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	s := scraper.NewWithOptions(scraper.ScraperOptions{
+		Cache:    scraper.CacheFromEnv(),
+		CacheTTL: scraper.CacheTTLFromEnv(),
+		Proxy:    proxy,
+	})
+	s.SetCircuitBreaker(scraper.CircuitBreakerFromEnv())
+	s.SetMaxBodySize(scraper.MaxBodySizeFromEnv())
+	s.SetThumbnailCache(scraper.ThumbnailCacheFromEnv())
+	s.SetHeaders(scraper.HeadersFromEnv())
+	s.SetCookie(scraper.CookieFromEnv())
+	s.SetSearchPageSize(scraper.SearchPageSizeFromEnv())
+	return s, nil
+}
+
+// newAPISource builds the Product Hunt API source from PHTUI_PH_API_TOKEN,
+// routed through PHTUI_PROXY like the scraper.
+func newAPISource() (types.ProductSource, error) {
+	proxy, err := scraper.ProxyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	s, err := apisource.New(apisource.TokenFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	s.SetProxy(proxy)
+	return s, nil
+}
@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/qyinm/phtui/apisource"
+	"github.com/qyinm/phtui/scraper"
+)
+
+func TestNew(t *testing.T) {
+	t.Setenv("PHTUI_PH_API_TOKEN", "")
+	for _, name := range []string{"", "scraper", " Scraper "} {
+		source, err := New(name)
+		if err != nil {
+			t.Fatalf("New(%q): %v", name, err)
+		}
+		if _, ok := source.(*scraper.Scraper); !ok {
+			t.Fatalf("New(%q) = %T, want *scraper.Scraper", name, source)
+		}
+	}
+}
+
+func TestNewRegisteredNames(t *testing.T) {
+	t.Setenv("PHTUI_PH_API_TOKEN", "test-token")
+	for _, name := range Names() {
+		source, err := New(name)
+		if err != nil || source == nil {
+			t.Fatalf("New(%q) = %v, %v", name, source, err)
+		}
+	}
+}
+
+func TestNewUnknown(t *testing.T) {
+	_, err := New("carrier-pigeon")
+	if !errors.Is(err, ErrUnknownSource) {
+		t.Fatalf("err = %v, want ErrUnknownSource", err)
+	}
+	want := `unknown source "carrier-pigeon"; expected one of: api, scraper`
+	if err.Error() != want {
+		t.Fatalf("err = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestNewInvalidProxy(t *testing.T) {
+	t.Setenv("PHTUI_PH_API_TOKEN", "")
+	t.Setenv("PHTUI_PROXY", "ftp://proxy.corp")
+	if _, err := New(""); err == nil || !strings.Contains(err.Error(), "PHTUI_PROXY") {
+		t.Fatalf("err = %v, want an invalid PHTUI_PROXY error", err)
+	}
+	t.Setenv("PHTUI_PROXY", "socks5://127.0.0.1:1080")
+	if _, err := New(""); err != nil {
+		t.Fatalf("New with a SOCKS proxy: %v", err)
+	}
+}
+
+func TestNewAPIOnlyByName(t *testing.T) {
+	t.Setenv("PHTUI_PH_API_TOKEN", "test-token")
+	source, err := New("")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, ok := source.(*scraper.Scraper); !ok {
+		t.Fatalf("New with a token = %T, want *scraper.Scraper", source)
+	}
+	if source, _ := New("api"); source == nil {
+		t.Fatal("New(api) with a token failed")
+	} else if _, ok := source.(*apisource.Source); !ok {
+		t.Fatalf("New(api) = %T, want *apisource.Source", source)
+	}
+
+	t.Setenv("PHTUI_PROXY", "ftp://proxy.corp")
+	if _, err := New("api"); err == nil || !strings.Contains(err.Error(), "PHTUI_PROXY") {
+		t.Fatalf("New(api) err = %v, want an invalid PHTUI_PROXY error", err)
+	}
+	t.Setenv("PHTUI_PROXY", "")
+
+	t.Setenv("PHTUI_PH_API_TOKEN", "")
+	if _, err := New("api"); !errors.Is(err, apisource.ErrNoToken) {
+		t.Fatalf("New(api) without a token: err = %v, want ErrNoToken", err)
+	}
+}
@@ -264,3 +264,33 @@ func CategoryIndexBySlug(slug string) int {
 	}
 	return -1
 }
+
+// MergeCategories returns base updated with fetched: categories already in
+// base keep their position but take the fetched name, and categories only in
+// fetched are appended in their fetched order. Categories missing from
+// fetched are kept, so a partial fetch never shrinks the list.
+func MergeCategories(base, fetched []CategoryLink) []CategoryLink {
+	names := make(map[string]string, len(fetched))
+	for _, c := range fetched {
+		if c.Slug() != "" && c.Name() != "" {
+			names[c.Slug()] = c.Name()
+		}
+	}
+	merged := make([]CategoryLink, 0, len(base)+len(fetched))
+	seen := make(map[string]struct{}, len(base)+len(fetched))
+	for _, c := range base {
+		if name, ok := names[c.Slug()]; ok {
+			c = NewCategoryLink(name, c.Slug())
+		}
+		seen[c.Slug()] = struct{}{}
+		merged = append(merged, c)
+	}
+	for _, c := range fetched {
+		if _, ok := seen[c.Slug()]; ok || c.Slug() == "" || c.Name() == "" {
+			continue
+		}
+		seen[c.Slug()] = struct{}{}
+		merged = append(merged, c)
+	}
+	return merged
+}
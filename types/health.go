@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// HealthStatus summarizes whether a source can currently fetch pages.
+type HealthStatus string
+
+const (
+	// HealthOK means a test request came back with a usable page.
+	HealthOK HealthStatus = "ok"
+	// HealthBlocked means the site answered with a challenge page, a
+	// block status, or the source is backing off after repeated blocks.
+	HealthBlocked HealthStatus = "blocked"
+	// HealthError means the test request failed for any other reason.
+	HealthError HealthStatus = "error"
+)
+
+// HealthReport is the outcome of a source's end-to-end health check.
+type HealthReport struct {
+	status  HealthStatus
+	latency time.Duration
+	detail  string
+}
+
+// NewHealthReport creates a new HealthReport. detail explains a status other
+// than HealthOK and may be empty.
+func NewHealthReport(status HealthStatus, latency time.Duration, detail string) HealthReport {
+	return HealthReport{status: status, latency: latency, detail: detail}
+}
+
+// Getters for HealthReport fields
+func (h HealthReport) Status() HealthStatus   { return h.status }
+func (h HealthReport) Latency() time.Duration { return h.latency }
+func (h HealthReport) Detail() string         { return h.detail }
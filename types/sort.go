@@ -0,0 +1,99 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// SortOrder orders a product list client-side.
+type SortOrder string
+
+const (
+	SortRelevance SortOrder = "relevance" // source order
+	SortVotes     SortOrder = "votes"
+	SortReviews   SortOrder = "reviews"
+	SortRating    SortOrder = "rating"
+)
+
+// SortOrders lists the supported orders in toggle order.
+var SortOrders = []SortOrder{SortRelevance, SortVotes, SortReviews, SortRating}
+
+// Next returns the order after o in SortOrders, wrapping around. The zero
+// value counts as SortRelevance.
+func (o SortOrder) Next() SortOrder {
+	if o == "" {
+		o = SortRelevance
+	}
+	for i, order := range SortOrders {
+		if order == o {
+			return SortOrders[(i+1)%len(SortOrders)]
+		}
+	}
+	return SortRelevance
+}
+
+// SortProducts returns a copy of products sorted by order, highest first.
+// Ties keep their source order. SortRelevance returns products unchanged.
+func SortProducts(products []Product, order SortOrder) []Product {
+	var less func(a, b Product) bool
+	switch order {
+	case SortVotes:
+		less = func(a, b Product) bool { return a.VoteCount() > b.VoteCount() }
+	case SortReviews:
+		// Review counts are carried in CommentCount for search and category results.
+		less = func(a, b Product) bool { return a.CommentCount() > b.CommentCount() }
+	case SortRating:
+		less = func(a, b Product) bool { return a.Rating() > b.Rating() }
+	default:
+		return products
+	}
+	sorted := append([]Product(nil), products...)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted
+}
+
+// SortByLaunchTime returns a copy of products ordered by when each was
+// featured, newest first. Products without a featured time follow in their
+// source order, so with no times at all the leaderboard keeps rank order.
+func SortByLaunchTime(products []Product, featured map[string]time.Time) []Product {
+	sorted := append([]Product(nil), products...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, aok := featured[sorted[i].Slug()]
+		b, bok := featured[sorted[j].Slug()]
+		if aok != bok {
+			return aok
+		}
+		return a.After(b)
+	})
+	return sorted
+}
+
+// FilterByMinReviews returns the products with at least minReviews reviews
+// and how many were left out. Like SortReviews, it reads review counts from
+// CommentCount. A threshold of 0 or less keeps every product.
+func FilterByMinReviews(products []Product, minReviews int) ([]Product, int) {
+	if minReviews <= 0 {
+		return products, 0
+	}
+	kept := make([]Product, 0, len(products))
+	for _, p := range products {
+		if p.CommentCount() >= minReviews {
+			kept = append(kept, p)
+		}
+	}
+	return kept, len(products) - len(kept)
+}
+
+// FilterFeatured returns the products that look like real, established
+// launches, online and with at least one review or a rating, and how many
+// were left out. Like FilterByMinReviews, it reads review counts from
+// CommentCount.
+func FilterFeatured(products []Product) ([]Product, int) {
+	kept := make([]Product, 0, len(products))
+	for _, p := range products {
+		if !p.Offline() && (p.CommentCount() > 0 || p.Rating() > 0) {
+			kept = append(kept, p)
+		}
+	}
+	return kept, len(products) - len(kept)
+}
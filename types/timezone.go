@@ -0,0 +1,58 @@
+package types
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+	_ "time/tzdata" // embed zone database so PHTUI_TZ works without system tzdata
+)
+
+// DefaultTimezone is the zone Product Hunt uses for leaderboard day boundaries.
+const DefaultTimezone = "America/Los_Angeles"
+
+var timezone atomic.Pointer[time.Location]
+
+func init() {
+	timezone.Store(LoadTimezone(os.Getenv("PHTUI_TZ")))
+}
+
+// LoadTimezone resolves an IANA zone name, falling back to DefaultTimezone
+// when the name is empty or unknown.
+func LoadTimezone(name string) *time.Location {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultTimezone
+	}
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocation(DefaultTimezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Timezone returns the zone used to interpret "today" and calendar dates.
+// It is read from PHTUI_TZ at startup and defaults to Product Hunt's zone.
+func Timezone() *time.Location {
+	return timezone.Load()
+}
+
+// SetTimezone overrides the configured zone. A nil location is ignored.
+func SetTimezone(loc *time.Location) {
+	if loc != nil {
+		timezone.Store(loc)
+	}
+}
+
+// DayIn returns midnight of the calendar day containing t in the configured zone.
+func DayIn(t time.Time) time.Time {
+	y, m, d := t.In(Timezone()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, Timezone())
+}
+
+// Today returns midnight of the current day in the configured zone.
+func Today() time.Time {
+	return DayIn(time.Now())
+}
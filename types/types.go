@@ -1,6 +1,8 @@
 package types
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -30,9 +32,12 @@ func (p Period) String() string {
 	}
 }
 
-// URLPath returns the Product Hunt leaderboard URL path for the given date
+// URLPath returns the Product Hunt leaderboard URL path for the given date.
+// The date's calendar fields are used as-is; convert instants with DayIn first
+// so they land on the configured timezone's day.
 // Daily: /leaderboard/daily/YYYY/M/DD (month and day without leading zeros)
-// Weekly: /leaderboard/weekly/YYYY/W (ISO week number)
+// Weekly: /leaderboard/weekly/YYYY/W (ISO year and week number, so the days
+// of a week that straddles New Year all map to the same leaderboard)
 // Monthly: /leaderboard/monthly/YYYY/M (month without leading zero)
 func (p Period) URLPath(date time.Time) string {
 	year := date.Year()
@@ -43,8 +48,8 @@ func (p Period) URLPath(date time.Time) string {
 	case Daily:
 		return fmt.Sprintf("/leaderboard/daily/%d/%d/%d", year, month, day)
 	case Weekly:
-		_, week := date.ISOWeek()
-		return fmt.Sprintf("/leaderboard/weekly/%d/%d", year, week)
+		isoYear, week := date.ISOWeek()
+		return fmt.Sprintf("/leaderboard/weekly/%d/%d", isoYear, week)
 	case Monthly:
 		return fmt.Sprintf("/leaderboard/monthly/%d/%d", year, month)
 	default:
@@ -62,10 +67,12 @@ type Product struct {
 	slug         string
 	thumbnailURL string
 	rank         int
+	rating       float64 // review rating (0 if unknown)
+	offline      bool    // product is marked "no longer online"
 }
 
 // NewProduct creates a new Product with the given fields
-func NewProduct(name, tagline string, categories []string, voteCount, commentCount int, slug, thumbnailURL string, rank int) Product {
+func NewProduct(name, tagline string, categories []string, voteCount, commentCount int, slug, thumbnailURL string, rank int, rating float64, offline bool) Product {
 	return Product{
 		name:         name,
 		tagline:      tagline,
@@ -75,6 +82,8 @@ func NewProduct(name, tagline string, categories []string, voteCount, commentCou
 		slug:         slug,
 		thumbnailURL: thumbnailURL,
 		rank:         rank,
+		rating:       rating,
+		offline:      offline,
 	}
 }
 
@@ -87,6 +96,8 @@ func (p Product) CommentCount() int    { return p.commentCount }
 func (p Product) Slug() string         { return p.slug }
 func (p Product) ThumbnailURL() string { return p.thumbnailURL }
 func (p Product) Rank() int            { return p.rank }
+func (p Product) Rating() float64      { return p.rating }
+func (p Product) Offline() bool        { return p.offline }
 
 // list.Item interface implementation
 func (p Product) Title() string       { return p.name }
@@ -124,48 +135,142 @@ type ProductDetail struct {
 	websiteURL      string
 	categories      []string
 	socialLinks     []string
-	launchDate      time.Time
+	launchDate      time.Time // first launch
+	latestLaunch    time.Time
 	makerName       string
 	makerProfileURL string
 	proConTags      []ProConTag
 	pricingInfo     string
+	pricingRaw      []string  // pricing text as scraped, before parsing
+	ratingDist      [5]int    // review counts by star, one star first
+	related         []Product // other launches by the same makers
+	links           map[string][]string
+	topics          []CategoryLink // topics linked from the page, with slugs
+	galleryImages   []string       // screenshots and video posters, in gallery order
+}
+
+// Link categories used as keys in ProductDetail.Links.
+const (
+	LinkWebsite = "website"
+	LinkGitHub  = "github"
+	LinkDiscord = "discord"
+	LinkSocial  = "social"
+)
+
+// ProductDetailOptions holds the fields of a ProductDetail; unset fields
+// are left empty.
+type ProductDetailOptions struct {
+	// Product carries the name, tagline and current vote and comment
+	// counts of the latest launch.
+	Product         Product
+	Description     string
+	Rating          float64
+	ReviewCount     int
+	FollowerCount   int
+	MakerComment    string
+	WebsiteURL      string
+	Categories      []string
+	SocialLinks     []string
+	FirstLaunch     time.Time
+	LatestLaunch    time.Time
+	MakerName       string
+	MakerProfileURL string
+	ProConTags      []ProConTag
+	PricingInfo     string
+	// PricingRaw is the pricing text as scraped, before parsing.
+	PricingRaw []string
+	// RatingDistribution counts reviews by star, one star first.
+	RatingDistribution [5]int
+	// Related lists other launches by the same makers.
+	Related []Product
+	Links   map[string][]string
+	// Topics are the topics linked from the page, with slugs.
+	Topics []CategoryLink
+	// GalleryImages are screenshots and video posters, in gallery order.
+	GalleryImages []string
 }
 
 // NewProductDetail creates a new ProductDetail
-func NewProductDetail(product Product, description string, rating float64, reviewCount, followerCount int, makerComment, websiteURL string, categories, socialLinks []string, launchDate time.Time, makerName, makerProfileURL string, proConTags []ProConTag, pricingInfo string) ProductDetail {
+func NewProductDetail(opts ProductDetailOptions) ProductDetail {
 	return ProductDetail{
-		product:         product,
-		description:     description,
-		rating:          rating,
-		reviewCount:     reviewCount,
-		followerCount:   followerCount,
-		makerComment:    makerComment,
-		websiteURL:      websiteURL,
-		categories:      categories,
-		socialLinks:     socialLinks,
-		launchDate:      launchDate,
-		makerName:       makerName,
-		makerProfileURL: makerProfileURL,
-		proConTags:      proConTags,
-		pricingInfo:     pricingInfo,
+		product:         opts.Product,
+		description:     opts.Description,
+		rating:          opts.Rating,
+		reviewCount:     opts.ReviewCount,
+		followerCount:   opts.FollowerCount,
+		makerComment:    opts.MakerComment,
+		websiteURL:      opts.WebsiteURL,
+		categories:      opts.Categories,
+		socialLinks:     opts.SocialLinks,
+		launchDate:      opts.FirstLaunch,
+		latestLaunch:    opts.LatestLaunch,
+		makerName:       opts.MakerName,
+		makerProfileURL: opts.MakerProfileURL,
+		proConTags:      opts.ProConTags,
+		pricingInfo:     opts.PricingInfo,
+		pricingRaw:      opts.PricingRaw,
+		ratingDist:      opts.RatingDistribution,
+		related:         opts.Related,
+		links:           opts.Links,
+		topics:          opts.Topics,
+		galleryImages:   opts.GalleryImages,
 	}
 }
 
 // Getters for ProductDetail fields
-func (pd ProductDetail) Product() Product        { return pd.product }
-func (pd ProductDetail) Description() string     { return pd.description }
-func (pd ProductDetail) Rating() float64         { return pd.rating }
-func (pd ProductDetail) ReviewCount() int        { return pd.reviewCount }
-func (pd ProductDetail) FollowerCount() int      { return pd.followerCount }
-func (pd ProductDetail) MakerComment() string    { return pd.makerComment }
-func (pd ProductDetail) WebsiteURL() string      { return pd.websiteURL }
-func (pd ProductDetail) Categories() []string    { return pd.categories }
-func (pd ProductDetail) SocialLinks() []string   { return pd.socialLinks }
-func (pd ProductDetail) LaunchDate() time.Time   { return pd.launchDate }
-func (pd ProductDetail) MakerName() string       { return pd.makerName }
-func (pd ProductDetail) MakerProfileURL() string { return pd.makerProfileURL }
-func (pd ProductDetail) ProConTags() []ProConTag  { return pd.proConTags }
-func (pd ProductDetail) PricingInfo() string     { return pd.pricingInfo }
+func (pd ProductDetail) Product() Product            { return pd.product }
+func (pd ProductDetail) Description() string         { return pd.description }
+func (pd ProductDetail) Rating() float64             { return pd.rating }
+func (pd ProductDetail) ReviewCount() int            { return pd.reviewCount }
+func (pd ProductDetail) FollowerCount() int          { return pd.followerCount }
+func (pd ProductDetail) VoteCount() int              { return pd.product.VoteCount() }
+func (pd ProductDetail) CommentCount() int           { return pd.product.CommentCount() }
+func (pd ProductDetail) MakerComment() string        { return pd.makerComment }
+func (pd ProductDetail) WebsiteURL() string          { return pd.websiteURL }
+func (pd ProductDetail) Categories() []string        { return pd.categories }
+func (pd ProductDetail) SocialLinks() []string       { return pd.socialLinks }
+func (pd ProductDetail) LaunchDate() time.Time       { return pd.launchDate }
+func (pd ProductDetail) FirstLaunchDate() time.Time  { return pd.launchDate }
+func (pd ProductDetail) LatestLaunchDate() time.Time { return pd.latestLaunch }
+func (pd ProductDetail) MakerName() string           { return pd.makerName }
+func (pd ProductDetail) MakerProfileURL() string     { return pd.makerProfileURL }
+func (pd ProductDetail) ProConTags() []ProConTag     { return pd.proConTags }
+func (pd ProductDetail) PricingInfo() string         { return pd.pricingInfo }
+func (pd ProductDetail) PricingRaw() []string        { return pd.pricingRaw }
+func (pd ProductDetail) Links() map[string][]string  { return pd.links }
+
+// RatingDistribution returns the number of reviews at each star rating:
+// index 0 holds one-star reviews and index 4 five-star ones. It is all zeros
+// when the page doesn't publish a histogram.
+func (pd ProductDetail) RatingDistribution() [5]int { return pd.ratingDist }
+
+// HasRatingDistribution reports whether any review counts were scraped.
+func (pd ProductDetail) HasRatingDistribution() bool { return pd.ratingDist != [5]int{} }
+
+// RelatedLaunches returns the other products the page lists as launched by
+// the same makers. Only their names, slugs, taglines and thumbnails are
+// known.
+func (pd ProductDetail) RelatedLaunches() []Product { return pd.related }
+
+// Topics returns the topics linked from the product page, each with the slug
+// of its /topics/{slug} page. Categories holds the same topics by name.
+func (pd ProductDetail) Topics() []CategoryLink { return pd.topics }
+
+// GalleryImages returns the image URLs of the page's media gallery:
+// screenshots and the posters of its videos, without size parameters.
+func (pd ProductDetail) GalleryImages() []string { return pd.galleryImages }
+
+// RepoOrWebsite returns the product's first GitHub link, falling back to its
+// website.
+func (pd ProductDetail) RepoOrWebsite() string {
+	if repos := pd.links[LinkGitHub]; len(repos) > 0 {
+		return repos[0]
+	}
+	if sites := pd.links[LinkWebsite]; len(sites) > 0 {
+		return sites[0]
+	}
+	return pd.websiteURL
+}
 
 type LeaderboardEntry = Product
 
@@ -184,6 +289,47 @@ func NewCategoryLink(name, slug string) CategoryLink {
 func (c CategoryLink) Name() string { return c.name }
 func (c CategoryLink) Slug() string { return c.slug }
 
+// RankPoint is a product's daily leaderboard rank on one launch day.
+type RankPoint struct {
+	date time.Time
+	rank int
+}
+
+// NewRankPoint creates a new RankPoint
+func NewRankPoint(date time.Time, rank int) RankPoint {
+	return RankPoint{date: date, rank: rank}
+}
+
+// Getters for RankPoint fields
+func (r RankPoint) Date() time.Time { return r.date }
+func (r RankPoint) Rank() int       { return r.rank }
+
+// CategoryTree describes a category's place in the Product Hunt taxonomy.
+// When the page exposes no hierarchy, children holds the related categories
+// as a flat list and Hierarchical reports false.
+type CategoryTree struct {
+	category     CategoryLink
+	parent       *CategoryLink
+	children     []CategoryLink
+	hierarchical bool
+}
+
+// NewCategoryTree creates a new CategoryTree. parent may be nil for top-level
+// categories.
+func NewCategoryTree(category CategoryLink, parent *CategoryLink, children []CategoryLink, hierarchical bool) CategoryTree {
+	return CategoryTree{category: category, parent: parent, children: children, hierarchical: hierarchical}
+}
+
+// Getters for CategoryTree fields
+func (t CategoryTree) Category() CategoryLink   { return t.category }
+func (t CategoryTree) Parent() *CategoryLink    { return t.parent }
+func (t CategoryTree) Children() []CategoryLink { return t.children }
+func (t CategoryTree) Hierarchical() bool       { return t.hierarchical }
+
+// ErrProductUnavailable is returned by ProductSource.GetProductDetail for
+// products Product Hunt has hidden or taken offline.
+var ErrProductUnavailable = errors.New("product is hidden or no longer available on Product Hunt")
+
 // ProductSource is the core abstraction for data access.
 // Sync methods only — no bubbletea dependency.
 // Future: MCP server, CLI can call these directly.
@@ -192,3 +338,230 @@ type ProductSource interface {
 	GetProductDetail(slug string) (ProductDetail, error)
 	GetCategoryProducts(slug string) ([]Product, []CategoryLink, error)
 }
+
+// ContextProductSource is a ProductSource whose fetches stop when ctx is
+// done. Its plain methods behave like the Context ones with
+// context.Background().
+type ContextProductSource interface {
+	ProductSource
+	GetLeaderboardContext(ctx context.Context, period Period, date time.Time) ([]Product, error)
+	GetProductDetailContext(ctx context.Context, slug string) (ProductDetail, error)
+	GetCategoryProductsContext(ctx context.Context, slug string) ([]Product, []CategoryLink, error)
+}
+
+// SearchSource is implemented by sources with paged product search.
+type SearchSource interface {
+	SearchProductsPage(query string, page int) ([]Product, int, bool, bool, int, error)
+}
+
+// ContextSearchSource is a SearchSource whose searches stop when ctx is
+// done.
+type ContextSearchSource interface {
+	SearchSource
+	SearchProductsPageContext(ctx context.Context, query string, page int) ([]Product, int, bool, bool, int, error)
+}
+
+// CategoryPageSource is implemented by sources that page through category
+// listings.
+type CategoryPageSource interface {
+	GetCategoryProductsPage(slug string, page int) ([]Product, []CategoryLink, bool, int, error)
+}
+
+// ContextCategoryPageSource is a CategoryPageSource whose fetches stop when
+// ctx is done.
+type ContextCategoryPageSource interface {
+	CategoryPageSource
+	GetCategoryProductsPageContext(ctx context.Context, slug string, page int) ([]Product, []CategoryLink, bool, int, error)
+}
+
+// LeaderboardInvalidator is implemented by sources that cache leaderboards.
+// InvalidateLeaderboard drops the cached copy for period and date, so the
+// next fetch goes upstream.
+type LeaderboardInvalidator interface {
+	InvalidateLeaderboard(period Period, date time.Time)
+}
+
+// RefreshLeaderboard is FetchLeaderboard that skips source's cached copy
+// when source implements LeaderboardInvalidator.
+func RefreshLeaderboard(ctx context.Context, source ProductSource, period Period, date time.Time) ([]Product, error) {
+	if s, ok := source.(LeaderboardInvalidator); ok {
+		s.InvalidateLeaderboard(period, date)
+	}
+	return FetchLeaderboard(ctx, source, period, date)
+}
+
+// FetchLeaderboard calls source.GetLeaderboardContext when source supports
+// it. Other sources can't be interrupted, so ctx is only checked before
+// the call.
+func FetchLeaderboard(ctx context.Context, source ProductSource, period Period, date time.Time) ([]Product, error) {
+	if s, ok := source.(ContextProductSource); ok {
+		return s.GetLeaderboardContext(ctx, period, date)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return source.GetLeaderboard(period, date)
+}
+
+// FetchProductDetail is FetchLeaderboard for GetProductDetail.
+func FetchProductDetail(ctx context.Context, source ProductSource, slug string) (ProductDetail, error) {
+	if s, ok := source.(ContextProductSource); ok {
+		return s.GetProductDetailContext(ctx, slug)
+	}
+	if err := ctx.Err(); err != nil {
+		return ProductDetail{}, err
+	}
+	return source.GetProductDetail(slug)
+}
+
+// FetchCategoryProducts is FetchLeaderboard for GetCategoryProducts.
+func FetchCategoryProducts(ctx context.Context, source ProductSource, slug string) ([]Product, []CategoryLink, error) {
+	if s, ok := source.(ContextProductSource); ok {
+		return s.GetCategoryProductsContext(ctx, slug)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return source.GetCategoryProducts(slug)
+}
+
+// FetchSearchProductsPage is FetchLeaderboard for SearchProductsPage.
+func FetchSearchProductsPage(ctx context.Context, source SearchSource, query string, page int) ([]Product, int, bool, bool, int, error) {
+	if s, ok := source.(ContextSearchSource); ok {
+		return s.SearchProductsPageContext(ctx, query, page)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, page, false, false, 0, err
+	}
+	return source.SearchProductsPage(query, page)
+}
+
+// FetchCategoryProductsPage is FetchLeaderboard for GetCategoryProductsPage.
+func FetchCategoryProductsPage(ctx context.Context, source CategoryPageSource, slug string, page int) ([]Product, []CategoryLink, bool, int, error) {
+	if s, ok := source.(ContextCategoryPageSource); ok {
+		return s.GetCategoryProductsPageContext(ctx, slug, page)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, false, 0, err
+	}
+	return source.GetCategoryProductsPage(slug, page)
+}
+
+// CategoryIndexSource is implemented by sources that can list every
+// category live.
+type CategoryIndexSource interface {
+	GetAllCategories() ([]CategoryLink, error)
+}
+
+// ContextCategoryIndexSource is a CategoryIndexSource whose fetch stops
+// when ctx is done.
+type ContextCategoryIndexSource interface {
+	CategoryIndexSource
+	GetAllCategoriesContext(ctx context.Context) ([]CategoryLink, error)
+}
+
+// UpcomingSource is implemented by sources with a coming-soon listing.
+type UpcomingSource interface {
+	GetUpcomingProducts() ([]Product, error)
+}
+
+// ContextUpcomingSource is an UpcomingSource whose fetch stops when ctx is
+// done.
+type ContextUpcomingSource interface {
+	UpcomingSource
+	GetUpcomingProductsContext(ctx context.Context) ([]Product, error)
+}
+
+// CategoryTreeSource is implemented by sources that know a category's
+// parent and subcategories.
+type CategoryTreeSource interface {
+	GetCategoryTree(slug string) (CategoryTree, error)
+}
+
+// ContextCategoryTreeSource is a CategoryTreeSource whose fetch stops when
+// ctx is done.
+type ContextCategoryTreeSource interface {
+	CategoryTreeSource
+	GetCategoryTreeContext(ctx context.Context, slug string) (CategoryTree, error)
+}
+
+// FeaturedTimesSource is implemented by sources that know when each
+// leaderboard product was featured.
+type FeaturedTimesSource interface {
+	GetFeaturedTimes(period Period, date time.Time) (map[string]time.Time, error)
+}
+
+// ContextFeaturedTimesSource is a FeaturedTimesSource whose fetch stops
+// when ctx is done.
+type ContextFeaturedTimesSource interface {
+	FeaturedTimesSource
+	GetFeaturedTimesContext(ctx context.Context, period Period, date time.Time) (map[string]time.Time, error)
+}
+
+// RankHistorySource is implemented by sources that know a product's rank
+// on each of its launches.
+type RankHistorySource interface {
+	GetRankHistory(slug string) ([]RankPoint, error)
+}
+
+// ContextRankHistorySource is a RankHistorySource whose fetch stops when
+// ctx is done.
+type ContextRankHistorySource interface {
+	RankHistorySource
+	GetRankHistoryContext(ctx context.Context, slug string) ([]RankPoint, error)
+}
+
+// FetchAllCategories is FetchLeaderboard for GetAllCategories.
+func FetchAllCategories(ctx context.Context, source CategoryIndexSource) ([]CategoryLink, error) {
+	if s, ok := source.(ContextCategoryIndexSource); ok {
+		return s.GetAllCategoriesContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return source.GetAllCategories()
+}
+
+// FetchUpcomingProducts is FetchLeaderboard for GetUpcomingProducts.
+func FetchUpcomingProducts(ctx context.Context, source UpcomingSource) ([]Product, error) {
+	if s, ok := source.(ContextUpcomingSource); ok {
+		return s.GetUpcomingProductsContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return source.GetUpcomingProducts()
+}
+
+// FetchCategoryTree is FetchLeaderboard for GetCategoryTree.
+func FetchCategoryTree(ctx context.Context, source CategoryTreeSource, slug string) (CategoryTree, error) {
+	if s, ok := source.(ContextCategoryTreeSource); ok {
+		return s.GetCategoryTreeContext(ctx, slug)
+	}
+	if err := ctx.Err(); err != nil {
+		return CategoryTree{}, err
+	}
+	return source.GetCategoryTree(slug)
+}
+
+// FetchFeaturedTimes is FetchLeaderboard for GetFeaturedTimes.
+func FetchFeaturedTimes(ctx context.Context, source FeaturedTimesSource, period Period, date time.Time) (map[string]time.Time, error) {
+	if s, ok := source.(ContextFeaturedTimesSource); ok {
+		return s.GetFeaturedTimesContext(ctx, period, date)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return source.GetFeaturedTimes(period, date)
+}
+
+// FetchRankHistory is FetchLeaderboard for GetRankHistory.
+func FetchRankHistory(ctx context.Context, source RankHistorySource, slug string) ([]RankPoint, error) {
+	if s, ok := source.(ContextRankHistorySource); ok {
+		return s.GetRankHistoryContext(ctx, slug)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return source.GetRankHistory(slug)
+}
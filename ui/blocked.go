@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/scraper"
+)
+
+// searchBlockedMessage replaces the list when Product Hunt answers a search
+// with a Cloudflare challenge.
+const searchBlockedMessage = "Search is temporarily blocked by Cloudflare. Try again shortly, or set PHTUI_COOKIE to the cookies of a logged-in browser session."
+
+// searchBlockedPanelWidth caps the width of the blocked-search panel.
+const searchBlockedPanelWidth = 60
+
+// searchBlocked reports whether the last search hit a Cloudflare challenge
+// and nothing has loaded since.
+func (m Model) searchBlocked() bool {
+	return m.blockedSearch && errors.Is(m.err, scraper.ErrCloudflareChallenge)
+}
+
+// renderSearchBlocked renders the blocked-search panel with its retry hint,
+// centered in height lines.
+func (m Model) renderSearchBlocked(height int) string {
+	hint := fmt.Sprintf("Press %s to retry", m.keys.Refresh.Help().Key)
+	if m.blockedQuery != "" {
+		hint += fmt.Sprintf(" \"%s\"", m.blockedQuery)
+	}
+	hint += fmt.Sprintf(", %s to dismiss", m.keys.Back.Help().Key)
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(DraculaOrange).
+		Padding(0, 1).
+		Width(min(searchBlockedPanelWidth, max(m.width-4, 1))).
+		Render(searchBlockedMessage + "\n\n" + StatusBarStyle.Render(hint))
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, panel)
+}
+
+// retryBlockedSearch runs the search Cloudflare blocked again.
+func (m *Model) retryBlockedSearch() (tea.Model, tea.Cmd) {
+	if m.source == nil {
+		return *m, nil
+	}
+	m.loading = true
+	m.statusMsg = "Retrying search..."
+	m.requestID++
+	return *m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, m.blockedQuery, max(m.blockedPage, 1), m.requestID))
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+func TestSearchBlockedByCloudflare(t *testing.T) {
+	src := &fakeSource{search: []types.Product{testProduct("Notes", "notes", 1)}}
+	m := newTestModel(src)
+	blocked := fmt.Errorf("unexpected status code: 403: %w", scraper.ErrCloudflareChallenge)
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 1, err: blocked})
+
+	view := m.View()
+	if !strings.Contains(view, "Search is temporarily blocked by Cloudflare.") {
+		t.Fatalf("view is missing the blocked-search panel:\n%s", view)
+	}
+	if !strings.Contains(view, `Press r to retry "notes"`) {
+		t.Errorf("view is missing the retry hint:\n%s", view)
+	}
+	if strings.Contains(view, "Error:") {
+		t.Error("raw error shown alongside the panel")
+	}
+
+	m, cmd := update(t, m, keyRunes("r"))
+	if !m.loading || cmd == nil {
+		t.Fatal("r did not retry the search")
+	}
+	m, _ = update(t, m, fetchSearchResults(m.requestCtx(), src, m.blockedQuery, m.blockedPage, m.requestID)())
+	if m.searchBlocked() || !m.searchResults || len(m.products) != 1 {
+		t.Fatalf("retry did not show the results: blocked=%v products=%v", m.searchBlocked(), slugsOf(m.products))
+	}
+
+	// Esc dismisses the panel.
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 1, err: scraper.ErrCloudflareChallenge})
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.searchBlocked() || m.err != nil {
+		t.Errorf("esc left the panel up: blocked=%v err=%v", m.searchBlocked(), m.err)
+	}
+}
+
+func TestSearchFailureNotBlocked(t *testing.T) {
+	m := newTestModel(&fakeSource{})
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 1, err: errors.New("connection reset")})
+	if m.searchBlocked() || m.statusMsg != "Search failed: connection reset" {
+		t.Fatalf("blocked=%v status=%q", m.searchBlocked(), m.statusMsg)
+	}
+	if strings.Contains(m.View(), "Cloudflare") {
+		t.Error("other search failures should not show the Cloudflare panel")
+	}
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/types"
+)
+
+// changeHighlightDuration is how long change-feed badges stay visible.
+const changeHighlightDuration = 5 * time.Second
+
+// Change-feed badge for a product that wasn't on the previous snapshot.
+const changeBadgeNew = "NEW"
+
+// clearChangesMsg clears change-feed badges set by the leaderboard load with
+// the same sequence number.
+type clearChangesMsg struct {
+	seq int
+}
+
+// clearChangesAfter returns a tea.Cmd that clears the badges for seq once
+// changeHighlightDuration has passed.
+func clearChangesAfter(seq int) tea.Cmd {
+	return tea.Tick(changeHighlightDuration, func(time.Time) tea.Msg {
+		return clearChangesMsg{seq: seq}
+	})
+}
+
+// leaderboardKey identifies a leaderboard snapshot by period and date.
+func leaderboardKey(period types.Period, date time.Time) string {
+	return period.URLPath(date)
+}
+
+// diffLeaderboard compares current against the previous snapshot and returns
+// a badge per changed slug: "NEW" for entries that weren't there before, and
+// "▲n"/"▼n" for entries that moved up or down n ranks.
+func diffLeaderboard(previous, current []types.Product) map[string]string {
+	prevRanks := make(map[string]int, len(previous))
+	for _, p := range previous {
+		prevRanks[p.Slug()] = p.Rank()
+	}
+	badges := make(map[string]string)
+	for _, p := range current {
+		prev, ok := prevRanks[p.Slug()]
+		switch {
+		case !ok:
+			badges[p.Slug()] = changeBadgeNew
+		case prev > p.Rank():
+			badges[p.Slug()] = fmt.Sprintf("▲%d", prev-p.Rank())
+		case prev < p.Rank():
+			badges[p.Slug()] = fmt.Sprintf("▼%d", p.Rank()-prev)
+		}
+	}
+	return badges
+}
+
+// snapshotLeaderboard records products as the latest snapshot for the
+// current period and date.
+func (m *Model) snapshotLeaderboard(products []types.Product) {
+	if m.leaderboardSnapshots == nil {
+		m.leaderboardSnapshots = make(map[string][]types.Product)
+	}
+	m.leaderboardSnapshots[leaderboardKey(m.period, m.date)] = products
+}
+
+// recordLeaderboardChanges diffs products against the previous snapshot of
+// the same leaderboard, stores them as the new snapshot, and returns a
+// command that clears the resulting badges after a few seconds.
+func (m *Model) recordLeaderboardChanges(products []types.Product) tea.Cmd {
+	previous, ok := m.leaderboardSnapshots[leaderboardKey(m.period, m.date)]
+	m.snapshotLeaderboard(products)
+	m.changeBadges = nil
+	if !ok {
+		return nil
+	}
+	badges := diffLeaderboard(previous, products)
+	if len(badges) == 0 {
+		return nil
+	}
+	m.changeBadges = badges
+	m.changeSeq++
+	return clearChangesAfter(m.changeSeq)
+}
+
+// changeBadge returns the change-feed badge for p on the leaderboard view,
+// or its ranks per leaderboard on the combined view. While source markers
+// are on, the leaderboard shows those instead.
+func (m Model) changeBadge(p types.Product) string {
+	if m.searchResults || m.categoryMode {
+		return ""
+	}
+	if m.showingSources() {
+		return m.sourceBadge(p)
+	}
+	if m.combinedMode {
+		return m.combinedLabels[p.Slug()]
+	}
+	return m.changeBadges[p.Slug()]
+}
+
+// changeBadgeStyle colors NEW and upward moves green and downward moves red.
+func changeBadgeStyle(badge string) lipgloss.Style {
+	if badge == sourceBadgeHydration {
+		return SourceMarkerStyle
+	}
+	if strings.HasPrefix(badge, "▼") || badge == compareBadgeDropped {
+		return lipgloss.NewStyle().Foreground(DraculaRed).Bold(true)
+	}
+	return lipgloss.NewStyle().Foreground(DraculaGreen).Bold(true)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// Citation formats, chosen with PHTUI_CITATION_FORMAT.
+const (
+	citationPlain    = "plain"
+	citationMarkdown = "markdown"
+)
+
+// parseCitationFormat returns the citation format named by raw, defaulting
+// to plain text.
+func parseCitationFormat(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case citationMarkdown, "md":
+		return citationMarkdown
+	}
+	return citationPlain
+}
+
+// formatCitation cites p as a single line: its name, tagline, Product Hunt
+// URL and the day it was accessed, in the configured zone. For example:
+//
+//	plain:    Alpha: Notes that write themselves. Product Hunt. https://www.producthunt.com/products/alpha (accessed February 18, 2026).
+//	markdown: [Alpha](https://www.producthunt.com/products/alpha): Notes that write themselves. Product Hunt, accessed February 18, 2026.
+func formatCitation(p types.Product, format string, accessed time.Time) string {
+	url := scraper.ProductURL(p.Slug())
+	day := accessed.In(types.Timezone()).Format("January 2, 2006")
+	tagline := strings.TrimRight(strings.TrimSpace(p.Tagline()), ".")
+	if format == citationMarkdown {
+		title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(p.Name())
+		if tagline == "" {
+			return fmt.Sprintf("[%s](%s). Product Hunt, accessed %s.", title, url, day)
+		}
+		return fmt.Sprintf("[%s](%s): %s. Product Hunt, accessed %s.", title, url, tagline, day)
+	}
+	if tagline == "" {
+		return fmt.Sprintf("%s. Product Hunt. %s (accessed %s).", p.Name(), url, day)
+	}
+	return fmt.Sprintf("%s: %s. Product Hunt. %s (accessed %s).", p.Name(), tagline, url, day)
+}
+
+// citedProduct is the product a citation refers to: the one open in the
+// detail view, or the one under the cursor.
+func (m Model) citedProduct() (types.Product, bool) {
+	switch {
+	case m.state == DetailView:
+		p := m.detail.Product()
+		return p, p.Slug() != ""
+	case m.categorySelectMode:
+		if m.splitFocus != 1 || m.splitSelected < 0 || m.splitSelected >= len(m.splitProducts) {
+			return types.Product{}, false
+		}
+		return m.splitProducts[m.splitSelected], true
+	}
+	p, ok := m.selectedProduct()
+	return p, ok && p.Slug() != ""
+}
+
+// copyCitation copies a citation for the current product to the clipboard.
+func (m *Model) copyCitation() {
+	p, ok := m.citedProduct()
+	if !ok {
+		return
+	}
+	citation := formatCitation(p, m.citationFormat, time.Now())
+	if err := copyToClipboard(citation); err != nil {
+		m.statusMsg = "Copy failed: " + err.Error()
+		return
+	}
+	m.statusMsg = "Copied citation for " + p.Name()
+}
+
+func citationFormatFromEnv() string {
+	return parseCitationFormat(os.Getenv("PHTUI_CITATION_FORMAT"))
+}
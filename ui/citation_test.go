@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestFormatCitation(t *testing.T) {
+	accessed := time.Date(2026, 2, 18, 20, 0, 0, 0, types.Timezone())
+	p := types.NewProduct("Alpha [beta]", "Notes that write themselves.", nil, 120, 4, "alpha", "", 1, 0, false)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{citationPlain, "Alpha [beta]: Notes that write themselves. Product Hunt. https://www.producthunt.com/products/alpha (accessed February 18, 2026)."},
+		{citationMarkdown, `[Alpha \[beta\]](https://www.producthunt.com/products/alpha): Notes that write themselves. Product Hunt, accessed February 18, 2026.`},
+	}
+	for _, tt := range tests {
+		if got := formatCitation(p, tt.format, accessed); got != tt.want {
+			t.Errorf("%s citation:\n got %s\nwant %s", tt.format, got, tt.want)
+		}
+	}
+
+	untagged := types.NewProduct("Beta", "", nil, 0, 0, "beta", "", 2, 0, false)
+	if got := formatCitation(untagged, citationPlain, accessed); got != "Beta. Product Hunt. https://www.producthunt.com/products/beta (accessed February 18, 2026)." {
+		t.Errorf("citation without a tagline = %s", got)
+	}
+
+	if parseCitationFormat(" Markdown ") != citationMarkdown || parseCitationFormat("apa") != citationPlain {
+		t.Error("unknown formats should fall back to plain")
+	}
+}
+
+func TestCopyCitationKey(t *testing.T) {
+	var copied string
+	prev := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = prev }()
+
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}
+	m := newTestModel(&fakeSource{leaderboard: products})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+	m, _ = update(t, m, keyRunes("j"))
+	m, _ = update(t, m, keyRunes("Y"))
+
+	today := time.Now().In(types.Timezone()).Format("January 2, 2006")
+	for _, want := range []string{"Beta", "https://www.producthunt.com/products/beta", "accessed " + today} {
+		if !strings.Contains(copied, want) {
+			t.Errorf("citation %q is missing %q", copied, want)
+		}
+	}
+	if m.statusMsg != "Copied citation for Beta" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
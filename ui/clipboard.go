@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// copyToClipboard writes text to the system clipboard using the OSC 52
+// terminal escape, which also works over SSH. Tests replace it.
+var copyToClipboard = func(text string) error {
+	_, err := osc52.New(text).WriteTo(os.Stderr)
+	return err
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/types"
+)
+
+// combinedFetchConcurrency caps the leaderboard fetches a combined view runs
+// at once.
+const combinedFetchConcurrency = 2
+
+// combinedPeriods are the leaderboards a combined view merges, in the order
+// their labels are shown.
+var combinedPeriods = []types.Period{types.Daily, types.Weekly, types.Monthly}
+
+// combinedMsg carries the merged daily, weekly and monthly leaderboards.
+type combinedMsg struct {
+	requestID int
+	products  []types.Product
+	labels    map[string]string // slug -> e.g. "D3 W7"
+	failed    []types.Period
+	elapsed   time.Duration // source call time for the status bar; zero if not measured
+	err       error
+}
+
+// fetchCombined fetches the three leaderboards containing date and merges
+// them. It fails only when none can be fetched.
+func fetchCombined(ctx context.Context, source types.ProductSource, date time.Time, requestID int) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		boards := make([][]types.Product, len(combinedPeriods))
+		errs := make([]error, len(combinedPeriods))
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, combinedFetchConcurrency)
+		for i, period := range combinedPeriods {
+			wg.Add(1)
+			go func(i int, period types.Period) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				boards[i], errs[i] = types.FetchLeaderboard(ctx, source, period, date)
+			}(i, period)
+		}
+		wg.Wait()
+
+		msg := combinedMsg{requestID: requestID, elapsed: time.Since(start)}
+		byPeriod := make(map[types.Period][]types.Product, len(combinedPeriods))
+		for i, period := range combinedPeriods {
+			if errs[i] != nil {
+				msg.failed = append(msg.failed, period)
+				continue
+			}
+			byPeriod[period] = boards[i]
+		}
+		if len(byPeriod) == 0 {
+			msg.err = errors.Join(errs...)
+			return msg
+		}
+		msg.products, msg.labels = mergeLeaderboards(byPeriod)
+		return msg
+	}
+}
+
+// mergeLeaderboards merges leaderboards into one list with each product
+// once, ranked by its best rank on any of them. Products on more
+// leaderboards come first, so consistently strong launches lead. Each
+// product is labeled with its rank per leaderboard, e.g. "D3 W7".
+func mergeLeaderboards(byPeriod map[types.Period][]types.Product) ([]types.Product, map[string]string) {
+	type entry struct {
+		product types.Product
+		ranks   map[types.Period]int
+		best    int
+		votes   int
+	}
+	entries := make(map[string]*entry)
+	var order []string
+	for _, period := range combinedPeriods {
+		for _, p := range byPeriod[period] {
+			e, ok := entries[p.Slug()]
+			if !ok {
+				e = &entry{product: p, ranks: make(map[types.Period]int), best: p.Rank()}
+				entries[p.Slug()] = e
+				order = append(order, p.Slug())
+			}
+			e.ranks[period] = p.Rank()
+			e.best = min(e.best, p.Rank())
+			// Longer periods count more votes; show the highest.
+			e.votes = max(e.votes, p.VoteCount())
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if len(a.ranks) != len(b.ranks) {
+			return len(a.ranks) > len(b.ranks)
+		}
+		return a.best < b.best
+	})
+
+	products := make([]types.Product, 0, len(order))
+	labels := make(map[string]string, len(order))
+	for _, slug := range order {
+		e := entries[slug]
+		p := e.product
+		products = append(products, types.NewProduct(p.Name(), p.Tagline(), p.Categories(), e.votes, p.CommentCount(), p.Slug(), p.ThumbnailURL(), e.best, p.Rating(), p.Offline()))
+		var parts []string
+		for _, period := range combinedPeriods {
+			if rank, ok := e.ranks[period]; ok {
+				parts = append(parts, fmt.Sprintf("%s%d", strings.ToUpper(period.String()[:1]), rank))
+			}
+		}
+		labels[slug] = strings.Join(parts, " ")
+	}
+	return products, labels
+}
+
+// toggleCombined switches between the current period's leaderboard and the
+// combined view of all three for the same date.
+func (m *Model) toggleCombined() (tea.Model, tea.Cmd) {
+	if m.searchResults || m.categoryMode || m.categorySelectMode || m.upcomingMode || m.compareMode {
+		return *m, nil
+	}
+	m.combinedMode = !m.combinedMode
+	m.combinedLabels = nil
+	m.state = ListView
+	if m.source == nil {
+		return *m, nil
+	}
+	m.loading = true
+	m.statusMsg = "Loading..."
+	m.requestID++
+	return *m, tea.Batch(m.spinner.Tick, m.fetchLeaderboardView())
+}
+
+// invalidateLeaderboardView drops the source's cached copies of the
+// leaderboards on screen, so a refresh fetches them again.
+func (m Model) invalidateLeaderboardView() {
+	src, ok := m.source.(types.LeaderboardInvalidator)
+	if !ok {
+		return
+	}
+	switch {
+	case m.compareMode:
+		for _, date := range m.compareDates() {
+			src.InvalidateLeaderboard(m.period, date)
+		}
+	case m.combinedMode:
+		for _, period := range combinedPeriods {
+			src.InvalidateLeaderboard(period, m.date)
+		}
+	default:
+		src.InvalidateLeaderboard(m.period, m.date)
+	}
+}
+
+// fetchLeaderboardView fetches the leaderboard on screen: the combined view
+// or both columns of a comparison when one is on, otherwise the current
+// period's.
+func (m Model) fetchLeaderboardView() tea.Cmd {
+	if m.compareMode {
+		return fetchCompare(m.requestCtx(), m.source, m.period, m.compareDates(), m.requestID)
+	}
+	if m.combinedMode {
+		return fetchCombined(m.requestCtx(), m.source, m.date, m.requestID)
+	}
+	return fetchLeaderboard(m.requestCtx(), m.source, m.period, m.date, m.requestID)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// periodSource serves a different leaderboard per period.
+type periodSource struct {
+	fakeSource
+	boards map[types.Period][]types.Product
+}
+
+func (s *periodSource) GetLeaderboard(period types.Period, _ time.Time) ([]types.Product, error) {
+	board, ok := s.boards[period]
+	if !ok {
+		return nil, errors.New("no leaderboard")
+	}
+	return board, nil
+}
+
+func TestCombinedView(t *testing.T) {
+	src := &periodSource{boards: map[types.Period][]types.Product{
+		types.Daily:   {votedProduct("Alpha", "alpha", 1, 300), votedProduct("Beta", "beta", 2, 250)},
+		types.Weekly:  {votedProduct("Gamma", "gamma", 1, 900), votedProduct("Beta", "beta", 4, 400)},
+		types.Monthly: {votedProduct("Beta", "beta", 9, 700), votedProduct("Gamma", "gamma", 3, 1500)},
+	}}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: src.boards[types.Daily]})
+
+	m, cmd := update(t, m, keyRunes("a"))
+	if cmd == nil || !m.combinedMode || !m.loading {
+		t.Fatal("a should start loading the combined view")
+	}
+	m, _ = update(t, m, fetchCombined(context.Background(), src, m.date, m.requestID)())
+
+	// Beta is on all three, Gamma on two; ties go to the best rank.
+	var got []string
+	for _, p := range m.products {
+		got = append(got, p.Slug()+":"+m.changeBadge(p))
+	}
+	want := []string{"beta:D2 W4 M9", "gamma:W1 M3", "alpha:D1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("combined = %v, want %v", got, want)
+	}
+	if beta := m.products[0]; beta.Rank() != 2 || beta.VoteCount() != 700 {
+		t.Errorf("beta rank %d votes %d, want best rank 2 and the most votes 700", beta.Rank(), beta.VoteCount())
+	}
+	if !strings.Contains(m.View(), "D2 W4 M9") {
+		t.Error("labels not rendered")
+	}
+
+	// A stale response is ignored, and a missing leaderboard is reported.
+	delete(src.boards, types.Monthly)
+	msg := fetchCombined(context.Background(), src, m.date, m.requestID)().(combinedMsg)
+	m, _ = update(t, m, combinedMsg{requestID: m.requestID - 1})
+	if len(m.products) != 3 {
+		t.Fatal("stale combined response replaced the list")
+	}
+	m, _ = update(t, m, msg)
+	if !strings.Contains(m.statusMsg, "couldn't fetch monthly") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	// Toggling again, or picking a period, leaves the combined view.
+	m, _ = update(t, m, keyRunes("a"))
+	if m.combinedMode {
+		t.Error("a should turn the combined view off")
+	}
+	m, _ = update(t, m, keyRunes("a"))
+	m, _ = update(t, m, keyRunes("1"))
+	if m.combinedMode {
+		t.Error("1 should switch back to the daily leaderboard")
+	}
+}
+
+func TestLeaderboardViewsWithoutSource(t *testing.T) {
+	for _, k := range []string{"a", "b"} {
+		m := newTestModel(nil)
+		m, cmd := update(t, m, keyRunes(k))
+		if cmd != nil || m.loading {
+			t.Errorf("%s without a source: loading %v, cmd %v", k, m.loading, cmd != nil)
+		}
+	}
+}
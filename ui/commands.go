@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/dto"
 	"github.com/qyinm/phtui/types"
 )
 
@@ -13,12 +16,18 @@ import (
 type leaderboardMsg struct {
 	requestID int
 	products  []types.Product
+	featured  map[string]time.Time // slug -> when it was featured, if known
+	elapsed   time.Duration        // source call time for the status bar; zero if not measured
 	err       error
+	// Slugs the source's parser found only in the page's hydration data,
+	// if it tracks them
+	hydrationOnly map[string]bool
 }
 
 type productDetailMsg struct {
 	requestID int
 	detail    types.ProductDetail
+	elapsed   time.Duration // source call time for the status bar; zero if not measured
 	err       error
 }
 
@@ -30,32 +39,48 @@ type searchResultsMsg struct {
 	hasNext   bool
 	pages     int
 	products  []types.Product
+	elapsed   time.Duration // source call time for the status bar; zero if not measured
 	err       error
 }
 
+// formatElapsed renders a load time for the status bar: milliseconds under
+// a second ("340ms"), tenths of a second above ("2.4s").
+func formatElapsed(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
 // fetchLeaderboard returns a tea.Cmd that fetches the leaderboard asynchronously
-func fetchLeaderboard(source types.ProductSource, period types.Period, date time.Time, requestID int) tea.Cmd {
+func fetchLeaderboard(ctx context.Context, source types.ProductSource, period types.Period, date time.Time, requestID int) tea.Cmd {
 	return func() tea.Msg {
-		products, err := source.GetLeaderboard(period, date)
-		return leaderboardMsg{requestID: requestID, products: products, err: err}
+		start := time.Now()
+		products, err := types.FetchLeaderboard(ctx, source, period, date)
+		msg := leaderboardMsg{requestID: requestID, products: products, elapsed: time.Since(start), err: err}
+		if timed, ok := source.(types.FeaturedTimesSource); ok && err == nil {
+			// Best effort: without times, launch-time sort keeps rank order.
+			msg.featured, _ = types.FetchFeaturedTimes(ctx, timed, period, date)
+		}
+		if parsed, ok := source.(hydrationOnlySource); ok && err == nil {
+			msg.hydrationOnly, _ = parsed.GetHydrationOnlyContext(ctx, period, date)
+		}
+		return msg
 	}
 }
 
 // fetchProductDetail returns a tea.Cmd that fetches product detail asynchronously
-func fetchProductDetail(source types.ProductSource, slug string, requestID int) tea.Cmd {
+func fetchProductDetail(ctx context.Context, source types.ProductSource, slug string, requestID int) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := source.GetProductDetail(slug)
-		return productDetailMsg{requestID: requestID, detail: detail, err: err}
+		start := time.Now()
+		detail, err := types.FetchProductDetail(ctx, source, slug)
+		return productDetailMsg{requestID: requestID, detail: detail, elapsed: time.Since(start), err: err}
 	}
 }
 
-type searchableSource interface {
-	SearchProductsPage(query string, page int) ([]types.Product, int, bool, bool, int, error)
-}
-
-func fetchSearchResults(source types.ProductSource, query string, page int, requestID int) tea.Cmd {
+func fetchSearchResults(ctx context.Context, source types.ProductSource, query string, page int, requestID int) tea.Cmd {
 	return func() tea.Msg {
-		searchable, ok := source.(searchableSource)
+		searchable, ok := source.(types.SearchSource)
 		if !ok {
 			return searchResultsMsg{
 				requestID: requestID,
@@ -64,7 +89,8 @@ func fetchSearchResults(source types.ProductSource, query string, page int, requ
 				err:       fmt.Errorf("search not supported by source"),
 			}
 		}
-		products, currentPage, hasPrev, hasNext, pagesCount, err := searchable.SearchProductsPage(query, page)
+		start := time.Now()
+		products, currentPage, hasPrev, hasNext, pagesCount, err := types.FetchSearchProductsPage(ctx, searchable, query, page)
 		return searchResultsMsg{
 			requestID: requestID,
 			query:     query,
@@ -73,11 +99,22 @@ func fetchSearchResults(source types.ProductSource, query string, page int, requ
 			hasNext:   hasNext,
 			pages:     pagesCount,
 			products:  products,
+			elapsed:   time.Since(start),
 			err:       err,
 		}
 	}
 }
 
+// categoryDebounce is how long the split-pane cursor must rest on a category
+// before its products are fetched.
+var categoryDebounce = 150 * time.Millisecond
+
+// categoryDebounceMsg ends the pause started by a split-pane cursor move.
+type categoryDebounceMsg struct {
+	requestID int
+	slug      string
+}
+
 type categoryProductsMsg struct {
 	requestID  int
 	slug       string
@@ -86,9 +123,9 @@ type categoryProductsMsg struct {
 	err        error
 }
 
-func fetchCategoryProducts(source types.ProductSource, slug string, requestID int) tea.Cmd {
+func fetchCategoryProducts(ctx context.Context, source types.ProductSource, slug string, requestID int) tea.Cmd {
 	return func() tea.Msg {
-		products, categories, err := source.GetCategoryProducts(slug)
+		products, categories, err := types.FetchCategoryProducts(ctx, source, slug)
 		return categoryProductsMsg{
 			requestID:  requestID,
 			slug:       slug,
@@ -98,3 +135,81 @@ func fetchCategoryProducts(source types.ProductSource, slug string, requestID in
 		}
 	}
 }
+
+type categoryIndexMsg struct {
+	categories []types.CategoryLink
+	err        error
+}
+
+// fetchCategoryIndex fetches the live category list for sources that support it.
+func fetchCategoryIndex(ctx context.Context, source types.ProductSource) tea.Cmd {
+	return func() tea.Msg {
+		indexed, ok := source.(types.CategoryIndexSource)
+		if !ok {
+			return categoryIndexMsg{err: fmt.Errorf("category reload is not supported by this source")}
+		}
+		categories, err := types.FetchAllCategories(ctx, indexed)
+		return categoryIndexMsg{categories: categories, err: err}
+	}
+}
+
+type upcomingMsg struct {
+	requestID int
+	products  []types.Product
+	err       error
+}
+
+// fetchUpcoming fetches the coming-soon listing for sources that support it.
+func fetchUpcoming(ctx context.Context, source types.ProductSource, requestID int) tea.Cmd {
+	return func() tea.Msg {
+		upcoming, ok := source.(types.UpcomingSource)
+		if !ok {
+			return upcomingMsg{requestID: requestID, err: fmt.Errorf("upcoming products are not supported by this source")}
+		}
+		products, err := types.FetchUpcomingProducts(ctx, upcoming)
+		return upcomingMsg{requestID: requestID, products: products, err: err}
+	}
+}
+
+type pricingMsg struct {
+	requestID int
+	pricing   map[string]string // slug -> "free", "paid", "unknown" or ""
+}
+
+const pricingFetchConcurrency = 4
+
+// maxPricingLookups caps the detail pages one pricing filter fetches, so a
+// long list doesn't fan out into dozens of requests. Products past the cap
+// stay unchecked until the filter is applied again.
+const maxPricingLookups = 20
+
+// fetchPricingTypes resolves pricing for products via their detail pages.
+// Failed lookups are recorded as unknown ("").
+func fetchPricingTypes(ctx context.Context, source types.ProductSource, products []types.Product, requestID int) tea.Cmd {
+	return func() tea.Msg {
+		pricing := make(map[string]string, len(products))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, pricingFetchConcurrency)
+		for _, p := range products {
+			if p.Slug() == "" {
+				continue
+			}
+			wg.Add(1)
+			go func(slug string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				pricingType := ""
+				if detail, err := types.FetchProductDetail(ctx, source, slug); err == nil {
+					pricingType = dto.PricingType(detail.PricingInfo())
+				}
+				mu.Lock()
+				pricing[slug] = pricingType
+				mu.Unlock()
+			}(p.Slug())
+		}
+		wg.Wait()
+		return pricingMsg{requestID: requestID, pricing: pricing}
+	}
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/types"
+)
+
+// compareBadgeDropped marks products in the earlier column that are missing
+// from the later one.
+const compareBadgeDropped = "OUT"
+
+// compareMsg carries one column of a comparison. Both columns are fetched
+// under the same requestID; the view updates once both have arrived.
+type compareMsg struct {
+	requestID int
+	side      int // 0: the earlier (left) column, 1: the later (right) one
+	date      time.Time
+	products  []types.Product
+	err       error
+}
+
+// compareSide is one loaded column of the comparison.
+type compareSide struct {
+	requestID int
+	date      time.Time
+	products  []types.Product
+}
+
+// compareDiff classifies how a leaderboard changed between two dates.
+type compareDiff struct {
+	badges  map[string]string // later column: NEW, ▲n or ▼n by slug
+	dropped map[string]bool   // earlier column slugs missing from the later one
+}
+
+// compareLeaderboards diffs the later leaderboard against the earlier one,
+// as the change feed does between reloads, and also marks the products that
+// dropped off.
+func compareLeaderboards(earlier, later []types.Product) compareDiff {
+	diff := compareDiff{badges: diffLeaderboard(earlier, later), dropped: make(map[string]bool)}
+	onLater := make(map[string]bool, len(later))
+	for _, p := range later {
+		onLater[p.Slug()] = true
+	}
+	for _, p := range earlier {
+		if !onLater[p.Slug()] {
+			diff.dropped[p.Slug()] = true
+		}
+	}
+	return diff
+}
+
+// counts returns how many products entered, dropped off and changed rank.
+func (d compareDiff) counts() (entered, dropped, moved int) {
+	for _, badge := range d.badges {
+		if badge == changeBadgeNew {
+			entered++
+		} else {
+			moved++
+		}
+	}
+	return entered, len(d.dropped), moved
+}
+
+// fetchCompare fetches both columns' leaderboards concurrently.
+func fetchCompare(ctx context.Context, source types.ProductSource, period types.Period, dates [2]time.Time, requestID int) tea.Cmd {
+	fetch := func(side int) tea.Cmd {
+		return func() tea.Msg {
+			products, err := types.FetchLeaderboard(ctx, source, period, dates[side])
+			return compareMsg{requestID: requestID, side: side, date: dates[side], products: products, err: err}
+		}
+	}
+	return tea.Batch(fetch(0), fetch(1))
+}
+
+// compareDates returns the earlier and later columns' dates. The focused
+// column's date is m.date, so the date bar and h/l move it; the other is
+// compareOther.
+func (m Model) compareDates() [2]time.Time {
+	if m.compareFocus == 0 {
+		return [2]time.Time{m.date, m.compareOther}
+	}
+	return [2]time.Time{m.compareOther, m.date}
+}
+
+// stepPeriod returns date moved n periods, e.g. -1 for the previous day on
+// the daily leaderboard.
+func stepPeriod(period types.Period, date time.Time, n int) time.Time {
+	switch period {
+	case types.Weekly:
+		return date.AddDate(0, 0, 7*n)
+	case types.Monthly:
+		return date.AddDate(0, n, 0)
+	}
+	return date.AddDate(0, 0, n)
+}
+
+// toggleCompare switches between the current leaderboard and a comparison
+// of it with the previous period's. The earlier column starts focused.
+func (m *Model) toggleCompare() (tea.Model, tea.Cmd) {
+	if m.searchResults || m.categoryMode || m.categorySelectMode || m.upcomingMode || m.combinedMode {
+		return *m, nil
+	}
+	m.compareMode = !m.compareMode
+	m.compareSides = [2]compareSide{}
+	m.compareDiff = compareDiff{}
+	if m.compareMode {
+		m.compareFocus = 0
+		m.compareOther = m.date
+		m.date = stepPeriod(m.period, m.date, -1)
+	} else {
+		if m.compareFocus == 0 {
+			m.date = m.compareOther
+		}
+		m.compareFocus = 0
+	}
+	m.state = ListView
+	if m.source == nil {
+		return *m, nil
+	}
+	m.loading = true
+	m.statusMsg = "Loading..."
+	m.requestID++
+	return *m, tea.Batch(m.spinner.Tick, m.fetchLeaderboardView())
+}
+
+// switchCompareFocus hands the date bar to the other column.
+func (m *Model) switchCompareFocus() {
+	m.date, m.compareOther = m.compareOther, m.date
+	m.compareFocus = 1 - m.compareFocus
+	m.statusMsg = "Date bar moves the " + m.compareColumnName(m.compareFocus) + " column"
+}
+
+func (m Model) compareColumnName(side int) string {
+	if side == 0 {
+		return "left"
+	}
+	return "right"
+}
+
+// applyCompare diffs the two loaded columns. The later column becomes the
+// product list, so the cursor and detail view work on it.
+func (m *Model) applyCompare() {
+	earlier, later := m.compareSides[0], m.compareSides[1]
+	m.compareDiff = compareLeaderboards(earlier.products, later.products)
+	m.products = later.products
+	m.baseProducts = later.products
+	m.selected = 0
+	m.err = nil
+	entered, dropped, moved := m.compareDiff.counts()
+	m.statusMsg = fmt.Sprintf("%s → %s: %d entered, %d dropped, %d moved (tab switches the column h/l move)",
+		m.compareDateLabel(earlier.date), m.compareDateLabel(later.date), entered, dropped, moved)
+}
+
+func (m Model) compareDateLabel(date time.Time) string {
+	switch m.period {
+	case types.Weekly:
+		return "week of " + date.Format("Jan 2, 2006")
+	case types.Monthly:
+		return date.Format("January 2006")
+	}
+	return date.Format("Jan 2, 2006")
+}
+
+// renderCompare renders the earlier leaderboard on the left, with dropped
+// products marked, and the later one on the right with NEW and rank-change
+// badges. Both columns scroll together with the cursor on the right.
+func (m Model) renderCompare() string {
+	height := m.listHeight()
+	leftWidth := (m.width - 1) / 2
+	rightWidth := m.width - 1 - leftWidth
+
+	visibleCount := max((height-1)/3, 1)
+	start := 0
+	if m.selected >= visibleCount {
+		start = m.selected - visibleCount + 1
+	}
+
+	column := func(side, width int) string {
+		title := m.compareDateLabel(m.compareSides[side].date)
+		style := InactiveTabStyle
+		if side == m.compareFocus {
+			style = ActiveTabStyle
+		}
+		lines := []string{style.Render(m.format.truncate(title, max(width-2, 1)))}
+		products := m.compareSides[side].products
+		for i := start; i < len(products) && i < start+visibleCount; i++ {
+			p := products[i]
+			badge := m.compareDiff.badges[p.Slug()]
+			if side == 0 {
+				badge = ""
+				if m.compareDiff.dropped[p.Slug()] {
+					badge = compareBadgeDropped
+				}
+			}
+			lines = append(lines, renderProductItem(p, side == 1 && i == m.selected, width-1, badge, "", false, m.nameColumn, m.format))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return joinPanes(column(0, leftWidth), column(1, rightWidth), leftWidth, height)
+}
+
+// receiveCompare stores one column of the current comparison and reports
+// whether both have now arrived and been diffed. Columns from an earlier
+// request, such as before the date bar moved, are dropped.
+func (m *Model) receiveCompare(msg compareMsg) bool {
+	if msg.requestID != m.requestID || !m.compareMode {
+		return false
+	}
+	if msg.err != nil {
+		m.loading = false
+		m.err = msg.err
+		m.statusMsg = "Failed to fetch: " + msg.err.Error()
+		return false
+	}
+	m.compareSides[msg.side] = compareSide{requestID: msg.requestID, date: msg.date, products: msg.products}
+	for _, side := range m.compareSides {
+		if side.requestID != m.requestID {
+			return false
+		}
+	}
+	m.loading = false
+	m.applyCompare()
+	return true
+}
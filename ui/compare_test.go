@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/types"
+)
+
+func TestCompareLeaderboards(t *testing.T) {
+	earlier := []types.Product{testProduct("A", "a", 1), testProduct("B", "b", 2), testProduct("C", "c", 3)}
+	later := []types.Product{testProduct("B", "b", 1), testProduct("D", "d", 2), testProduct("A", "a", 3)}
+
+	diff := compareLeaderboards(earlier, later)
+	if want := map[string]string{"b": "▲1", "d": changeBadgeNew, "a": "▼2"}; !reflect.DeepEqual(diff.badges, want) {
+		t.Errorf("badges = %v, want %v", diff.badges, want)
+	}
+	if want := map[string]bool{"c": true}; !reflect.DeepEqual(diff.dropped, want) {
+		t.Errorf("dropped = %v, want %v", diff.dropped, want)
+	}
+	if entered, dropped, moved := diff.counts(); entered != 1 || dropped != 1 || moved != 2 {
+		t.Errorf("counts = %d entered, %d dropped, %d moved; want 1, 1, 2", entered, dropped, moved)
+	}
+}
+
+func TestCompareWaitsForBothColumns(t *testing.T) {
+	earlier := []types.Product{testProduct("A", "a", 1), testProduct("B", "b", 2)}
+	later := []types.Product{testProduct("B", "b", 1), testProduct("C", "c", 2)}
+	m := newTestModel(&fakeSource{leaderboard: earlier})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: earlier})
+	today := m.date
+
+	m, cmd := update(t, m, keyRunes("b"))
+	if !m.compareMode || !m.loading || cmd == nil {
+		t.Fatalf("b didn't start a comparison: compare %v, loading %v", m.compareMode, m.loading)
+	}
+	dates := m.compareDates()
+	if !dates[1].Equal(today) || !dates[0].Equal(today.AddDate(0, 0, -1)) {
+		t.Fatalf("compare dates = %v, want the day before %v and that day", dates, today)
+	}
+
+	id := m.requestID
+	m, _ = update(t, m, compareMsg{requestID: id, side: 1, date: dates[1], products: later})
+	if !m.loading {
+		t.Fatal("one column finished the comparison")
+	}
+	m, _ = update(t, m, compareMsg{requestID: id - 1, side: 0, date: dates[0], products: later})
+	if !m.loading {
+		t.Fatal("a stale column finished the comparison")
+	}
+	m, _ = update(t, m, compareMsg{requestID: id, side: 0, date: dates[0], products: earlier})
+	if m.loading {
+		t.Fatal("both columns arrived but still loading")
+	}
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("list = %v, want the later column", got)
+	}
+	if !m.compareDiff.dropped["a"] || m.compareDiff.badges["c"] != changeBadgeNew {
+		t.Errorf("diff = %+v", m.compareDiff)
+	}
+	if !strings.Contains(m.statusMsg, "1 entered, 1 dropped, 1 moved") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if view := m.View(); !strings.Contains(view, compareBadgeDropped) {
+		t.Error("dropped badge not rendered")
+	}
+
+	// Tab hands the date bar to the other column; moving it refetches both.
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.compareFocus != 1 || !m.date.Equal(today) {
+		t.Fatalf("tab focus = %d, date %v", m.compareFocus, m.date)
+	}
+	m, _ = update(t, m, keyRunes("h"))
+	if got := m.compareDates(); !got[0].Equal(dates[0]) || !got[1].Equal(today.AddDate(0, 0, -1)) || m.requestID == id {
+		t.Errorf("h moved dates to %v, request %d", got, m.requestID)
+	}
+	got := m.compareDates()
+	m, _ = update(t, m, compareMsg{requestID: m.requestID, side: 0, date: got[0], products: earlier})
+	m, _ = update(t, m, compareMsg{requestID: m.requestID, side: 1, date: got[1], products: earlier})
+	if m.loading || len(m.compareDiff.badges) != 0 {
+		t.Fatalf("same leaderboard on both dates: loading %v, badges %v", m.loading, m.compareDiff.badges)
+	}
+
+	m, _ = update(t, m, keyRunes("b"))
+	if m.compareMode || !m.date.Equal(today.AddDate(0, 0, -1)) {
+		t.Errorf("leaving compare: mode %v, date %v", m.compareMode, m.date)
+	}
+}
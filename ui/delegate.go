@@ -3,6 +3,9 @@ package ui
 import (
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -44,13 +47,44 @@ func (d ProductDelegate) Render(w io.Writer, m list.Model, index int, item list.
 	}
 
 	isSelected := index == m.Index()
-	output := renderProductItem(product, isSelected, m.Width())
+	output := renderProductItem(product, isSelected, m.Width(), "", "", false, defaultNameColumn, defaultTextFormat)
 	fmt.Fprint(w, output)
 }
 
-// formatVoteCount formats vote count with K/M suffixes
-// 1000 -> "1.0K", 1422 -> "1.4K", 1000000 -> "1.0M"
-func formatVoteCount(count int) string {
+// textFormat is how counts and cut text are shown on list rows, headers,
+// panes and the detail view.
+type textFormat struct {
+	// fullCounts shows vote and follower counts in full with thousands
+	// separators instead of abbreviated.
+	fullCounts bool
+	// marker ends text cut short to fit its column; empty means
+	// defaultTruncationMarker.
+	marker string
+}
+
+var defaultTextFormat = textFormat{marker: defaultTruncationMarker}
+
+// textFormatFromEnv reads PHTUI_FULL_COUNTS and PHTUI_ELLIPSIS.
+func textFormatFromEnv() textFormat {
+	return textFormat{
+		fullCounts: strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_FULL_COUNTS")), "true"),
+		marker:     truncationMarkerFromEnv(),
+	}
+}
+
+// count formats a vote or follower count, abbreviated or in full as
+// fullCounts says.
+func (f textFormat) count(count int) string {
+	return formatCount(count, f.fullCounts)
+}
+
+// formatCount formats count with K/M suffixes, or in full with thousands
+// separators when full is set:
+// 1422 -> "1.4K" or "1,422", 1000000 -> "1.0M" or "1,000,000"
+func formatCount(count int, full bool) string {
+	if full {
+		return groupThousands(count)
+	}
 	if count >= 1000000 {
 		return fmt.Sprintf("%.1fM", float64(count)/1000000)
 	}
@@ -59,3 +93,20 @@ func formatVoteCount(count int) string {
 	}
 	return fmt.Sprintf("%d", count)
 }
+
+// groupThousands writes n with a comma between each group of three digits.
+func groupThousands(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		count int
+		short string
+		full  string
+	}{
+		{0, "0", "0"},
+		{7, "7", "7"},
+		{999, "999", "999"},
+		{1000, "1.0K", "1,000"},
+		{1422, "1.4K", "1,422"},
+		{65536, "65.5K", "65,536"},
+		{543210, "543.2K", "543,210"},
+		{1000000, "1.0M", "1,000,000"},
+		{12345678, "12.3M", "12,345,678"},
+	}
+	for _, tt := range tests {
+		if got := formatCount(tt.count, false); got != tt.short {
+			t.Errorf("formatCount(%d, short) = %q, want %q", tt.count, got, tt.short)
+		}
+		if got := formatCount(tt.count, true); got != tt.full {
+			t.Errorf("formatCount(%d, full) = %q, want %q", tt.count, got, tt.full)
+		}
+	}
+}
+
+func TestProductRowFullCounts(t *testing.T) {
+	p := types.NewProduct("Alpha", "Alpha tagline", nil, 12345, 3, "alpha", "", 1, 0, false)
+	row := renderProductItem(p, false, 80, "", "", false, defaultNameColumn, textFormat{fullCounts: true})
+	if !strings.Contains(row, "▲ 12,345") {
+		t.Errorf("row %q is missing the full vote count", row)
+	}
+}
+
+func TestTextFormatFromEnv(t *testing.T) {
+	t.Setenv("PHTUI_ELLIPSIS", "")
+	t.Setenv("PHTUI_FULL_COUNTS", "TRUE")
+	if got := NewModel(nil).format; !got.fullCounts {
+		t.Errorf("format = %+v, want full counts", got)
+	}
+	t.Setenv("PHTUI_FULL_COUNTS", "")
+	if got := NewModel(nil).format; got != defaultTextFormat {
+		t.Errorf("format = %+v, want the default", got)
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"encoding/json"
+
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+// detailJSON encodes detail as the item the MCP product_get_detail tool
+// returns, indented unless compact is set.
+func detailJSON(detail types.ProductDetail, compact bool) (string, error) {
+	d := dto.FromProductDetail(detail)
+	var (
+		data []byte
+		err  error
+	)
+	if compact {
+		data, err = json.Marshal(d)
+	} else {
+		data, err = json.MarshalIndent(d, "", "  ")
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// copyDetailJSON copies the open product's detail as JSON to the clipboard.
+func (m *Model) copyDetailJSON() {
+	p := m.detail.Product()
+	if p.Slug() == "" {
+		m.statusMsg = "No product detail to copy"
+		return
+	}
+	text, err := detailJSON(m.detail, m.compactJSON)
+	if err == nil {
+		err = copyToClipboard(text)
+	}
+	if err != nil {
+		m.statusMsg = "Copy failed: " + err.Error()
+		return
+	}
+	m.statusMsg = "Copied detail JSON for " + p.Name()
+}
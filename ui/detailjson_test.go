@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/dto"
+	"github.com/qyinm/phtui/types"
+)
+
+func TestCopyDetailJSONKey(t *testing.T) {
+	var copied string
+	prev := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = prev }()
+
+	products := []types.Product{testProduct("Alpha", "alpha", 1)}
+	m := newTestModel(&fakeSource{leaderboard: products})
+
+	// Nothing is open yet.
+	m.state = DetailView
+	m, _ = update(t, m, keyRunes("J"))
+	if copied != "" || m.statusMsg != "No product detail to copy" {
+		t.Fatalf("empty detail: copied %q, status %q", copied, m.statusMsg)
+	}
+
+	launched := time.Date(2026, 2, 18, 0, 0, 0, 0, types.Timezone())
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:            products[0],
+		Description:        "Notes that write themselves",
+		Rating:             4.5,
+		ReviewCount:        12,
+		FollowerCount:      300,
+		MakerComment:       "Hi!",
+		WebsiteURL:         "https://alpha.example",
+		Categories:         []string{"Productivity"},
+		FirstLaunch:        launched,
+		LatestLaunch:       launched,
+		MakerName:          "Ada",
+		MakerProfileURL:    "https://www.producthunt.com/@ada",
+		ProConTags:         []types.ProConTag{types.NewProConTag("Fast", "Positive", 3)},
+		PricingInfo:        "Free Options",
+		RatingDistribution: [5]int{1, 0, 0, 2, 9},
+	})
+	m, _ = update(t, m, productDetailMsg{requestID: m.requestID, detail: detail})
+	m, _ = update(t, m, keyRunes("J"))
+
+	if m.statusMsg != "Copied detail JSON for Alpha" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if !strings.Contains(copied, "\n  \"") {
+		t.Errorf("copied JSON is not indented:\n%s", copied)
+	}
+	var got dto.ProductDetail
+	if err := json.Unmarshal([]byte(copied), &got); err != nil {
+		t.Fatalf("copied JSON does not decode: %v\n%s", err, copied)
+	}
+	if want := dto.FromProductDetail(detail); !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded detail:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDetailJSONCompact(t *testing.T) {
+	detail := types.NewProductDetail(types.ProductDetailOptions{Product: testProduct("Alpha", "alpha", 1)})
+	text, err := detailJSON(detail, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(text, "\n") || !json.Valid([]byte(text)) {
+		t.Errorf("compact JSON = %s", text)
+	}
+}
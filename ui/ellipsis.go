@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// defaultTruncationMarker ends text cut short to fit its column unless
+// PHTUI_ELLIPSIS sets another.
+const defaultTruncationMarker = "…"
+
+// truncationMarkerFromEnv reads PHTUI_ELLIPSIS, e.g. "..." or ">" for
+// terminals that draw "…" poorly. Unset or blank keeps the default.
+func truncationMarkerFromEnv() string {
+	if marker := strings.TrimSpace(os.Getenv("PHTUI_ELLIPSIS")); marker != "" {
+		return marker
+	}
+	return defaultTruncationMarker
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/types"
+)
+
+func TestTruncateToWidthMarker(t *testing.T) {
+	for _, tt := range []struct {
+		marker string
+		s      string
+		width  int
+		want   string
+	}{
+		{"…", "Product Hunt", 20, "Product Hunt"},
+		{"…", "Product Hunt", 8, "Product…"},
+		{"…", "Product Hunt", 1, "…"},
+		{">", "Product Hunt", 8, "Product>"},
+		{"...", "Product Hunt", 8, "Produ..."},
+		{"...", "Product Hunt", 3, "..."},
+		{"...", "Product Hunt", 2, ".."},
+		{"...", "Product Hunt", 0, ""},
+		{"...", "제품사냥꾼", 6, "제..."},
+		{"...", "제품사냥꾼", 7, "제품..."},
+	} {
+		got := textFormat{marker: tt.marker}.truncate(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("marker %q: truncate(%q, %d) = %q, want %q", tt.marker, tt.s, tt.width, got, tt.want)
+		}
+		if w := lipgloss.Width(got); w > tt.width {
+			t.Errorf("marker %q: truncate(%q, %d) is %d wide", tt.marker, tt.s, tt.width, w)
+		}
+	}
+}
+
+func TestPadOrTruncateMarkerWidth(t *testing.T) {
+	for _, marker := range []string{"…", ">", "...", "→→"} {
+		f := textFormat{marker: marker}
+		for _, s := range []string{"Hunt", "Product Hunt", "제품사냥꾼"} {
+			for width := 0; width <= 14; width++ {
+				if got := lipgloss.Width(f.padOrTruncate(s, width)); got != width {
+					t.Errorf("marker %q: padOrTruncate(%q, %d) is %d wide", marker, s, width, got)
+				}
+			}
+		}
+	}
+}
+
+func TestTruncationMarkerFromEnv(t *testing.T) {
+	t.Setenv("PHTUI_ELLIPSIS", "")
+	if got := truncationMarkerFromEnv(); got != "…" {
+		t.Errorf("unset = %q, want the default", got)
+	}
+	t.Setenv("PHTUI_ELLIPSIS", " ... ")
+	if got := truncationMarkerFromEnv(); got != "..." {
+		t.Errorf("PHTUI_ELLIPSIS=... = %q", got)
+	}
+	if got := NewModel(nil).format.marker; got != "..." {
+		t.Errorf("model marker = %q, want %q", got, "...")
+	}
+}
+
+func TestProductRowFitsWithMarker(t *testing.T) {
+	p := types.NewProduct("Supercalifragilistic", "A very long tagline for a product", nil, 1234, 0, "super", "", 10, 0, false)
+	for _, width := range []int{14, 20, 30, 60} {
+		line := firstLine(renderProductItem(p, false, width, "", "", false, defaultNameColumn, textFormat{marker: "..."}))
+		if got := lipgloss.Width(strings.TrimPrefix(line, "│ ")); got > width {
+			t.Errorf("width %d: row is %d wide: %q", width, got, line)
+		}
+		if width < 30 && !strings.Contains(line, "...") {
+			t.Errorf("width %d: name cut without the marker: %q", width, line)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// featuredResults drops search results that never launched or are off
+// Product Hunt while the featured-only filter is on, returning how many it
+// hid. Other listings are returned unchanged.
+func (m Model) featuredResults(products []types.Product) ([]types.Product, int) {
+	if !m.searchResults || !m.featuredOnly {
+		return products, 0
+	}
+	return types.FilterFeatured(products)
+}
+
+// toggleFeaturedOnly hides or shows search results without reviews or a
+// rating, or marked offline. The filter stays on for later searches.
+func (m *Model) toggleFeaturedOnly() {
+	if !m.searchResults {
+		return
+	}
+	m.featuredOnly = !m.featuredOnly
+	m.applyPricingFilter()
+}
+
+// featuredFilterStatus describes the featured-only filter for the status bar.
+func featuredFilterStatus(hidden int) string {
+	return fmt.Sprintf("featured only, %d hidden (F to change)", hidden)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyActions lists the remappable actions by their config name, in the order
+// they are reported.
+var keyActions = []struct {
+	name    string
+	binding func(k *keyMap) *key.Binding
+}{
+	{"up", func(k *keyMap) *key.Binding { return &k.Up }},
+	{"down", func(k *keyMap) *key.Binding { return &k.Down }},
+	{"search", func(k *keyMap) *key.Binding { return &k.Search }},
+	{"enter", func(k *keyMap) *key.Binding { return &k.Enter }},
+	{"back", func(k *keyMap) *key.Binding { return &k.Back }},
+	{"tab", func(k *keyMap) *key.Binding { return &k.Tab }},
+	{"daily", func(k *keyMap) *key.Binding { return &k.Daily }},
+	{"weekly", func(k *keyMap) *key.Binding { return &k.Weekly }},
+	{"monthly", func(k *keyMap) *key.Binding { return &k.Monthly }},
+	{"categories", func(k *keyMap) *key.Binding { return &k.Categories }},
+	{"upcoming", func(k *keyMap) *key.Binding { return &k.Upcoming }},
+	{"prev_date", func(k *keyMap) *key.Binding { return &k.PrevDate }},
+	{"next_date", func(k *keyMap) *key.Binding { return &k.NextDate }},
+	{"open", func(k *keyMap) *key.Binding { return &k.Open }},
+	{"open_repo", func(k *keyMap) *key.Binding { return &k.OpenRepo }},
+	{"jump_pros", func(k *keyMap) *key.Binding { return &k.JumpPros }},
+	{"jump_cons", func(k *keyMap) *key.Binding { return &k.JumpCons }},
+	{"jump_maker", func(k *keyMap) *key.Binding { return &k.JumpMaker }},
+	{"copy_url", func(k *keyMap) *key.Binding { return &k.CopyURL }},
+	{"cite", func(k *keyMap) *key.Binding { return &k.Cite }},
+	{"copy_json", func(k *keyMap) *key.Binding { return &k.CopyJSON }},
+	{"snapshot", func(k *keyMap) *key.Binding { return &k.Snapshot }},
+	{"upload", func(k *keyMap) *key.Binding { return &k.Upload }},
+	{"refresh", func(k *keyMap) *key.Binding { return &k.Refresh }},
+	{"pricing", func(k *keyMap) *key.Binding { return &k.Pricing }},
+	{"min_reviews", func(k *keyMap) *key.Binding { return &k.MinReviews }},
+	{"featured_only", func(k *keyMap) *key.Binding { return &k.Featured }},
+	{"sort", func(k *keyMap) *key.Binding { return &k.Sort }},
+	{"changes", func(k *keyMap) *key.Binding { return &k.Changes }},
+	{"preview", func(k *keyMap) *key.Binding { return &k.Preview }},
+	{"combined", func(k *keyMap) *key.Binding { return &k.Combined }},
+	{"compare", func(k *keyMap) *key.Binding { return &k.Compare }},
+	{"focus", func(k *keyMap) *key.Binding { return &k.Focus }},
+	{"save_search", func(k *keyMap) *key.Binding { return &k.SaveSearch }},
+	{"saved_searches", func(k *keyMap) *key.Binding { return &k.SavedList }},
+	{"remove", func(k *keyMap) *key.Binding { return &k.Remove }},
+	{"debug_sources", func(k *keyMap) *key.Binding { return &k.Sources }},
+	{"help", func(k *keyMap) *key.Binding { return &k.Help }},
+	{"quit", func(k *keyMap) *key.Binding { return &k.Quit }},
+}
+
+// keyConfigPath returns the keybinding config file: PHTUI_KEYS_FILE when set,
+// otherwise phtui/keys.json under the user config directory.
+func keyConfigPath() string {
+	if path := strings.TrimSpace(os.Getenv("PHTUI_KEYS_FILE")); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "phtui", "keys.json")
+}
+
+// loadKeyMap returns the default bindings merged with the config file at
+// path. A missing file yields the defaults; an invalid one yields the
+// defaults and an error describing the problem.
+func loadKeyMap(path string) (keyMap, error) {
+	if path == "" {
+		return keys, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return keys, nil
+	}
+	if err != nil {
+		return keys, fmt.Errorf("read %s: %w", path, err)
+	}
+	overrides, err := parseKeyConfig(data)
+	if err != nil {
+		return keys, fmt.Errorf("%s: %w", path, err)
+	}
+	merged, err := applyKeyConfig(keys, overrides)
+	if err != nil {
+		return keys, fmt.Errorf("%s: %w", path, err)
+	}
+	return merged, nil
+}
+
+// parseKeyConfig reads a JSON object mapping action names to the keys that
+// trigger them, e.g. {"up": ["k", "up"], "quit": ["x"]}.
+func parseKeyConfig(data []byte) (map[string][]string, error) {
+	var raw map[string][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse key config: %w", err)
+	}
+	known := make(map[string]bool, len(keyActions))
+	for _, a := range keyActions {
+		known[a.name] = true
+	}
+	overrides := make(map[string][]string, len(raw))
+	for name, list := range raw {
+		action := strings.ToLower(strings.TrimSpace(name))
+		if !known[action] {
+			return nil, fmt.Errorf("unknown action %q", name)
+		}
+		var cleaned []string
+		for _, k := range list {
+			if k = strings.TrimSpace(k); k != "" {
+				cleaned = append(cleaned, k)
+			}
+		}
+		if len(cleaned) == 0 {
+			return nil, fmt.Errorf("action %q has no keys", name)
+		}
+		overrides[action] = cleaned
+	}
+	return overrides, nil
+}
+
+// applyKeyConfig returns base with the overridden actions rebound. Help text
+// for a rebound action lists its new keys. It fails if the result binds one
+// key to more than one action.
+func applyKeyConfig(base keyMap, overrides map[string][]string) (keyMap, error) {
+	merged := base
+	for _, a := range keyActions {
+		list, ok := overrides[a.name]
+		if !ok {
+			continue
+		}
+		b := a.binding(&merged)
+		desc := b.Help().Desc
+		*b = key.NewBinding(key.WithKeys(list...))
+		if desc != "" {
+			b.SetHelp(strings.Join(list, "/"), desc)
+		}
+	}
+
+	owners := make(map[string][]string)
+	for _, a := range keyActions {
+		for _, k := range a.binding(&merged).Keys() {
+			owners[k] = append(owners[k], a.name)
+		}
+	}
+	var conflicts []string
+	for k, actions := range owners {
+		if len(actions) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%q is bound to %s", k, strings.Join(actions, " and ")))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return base, fmt.Errorf("conflicting keys: %s", strings.Join(conflicts, "; "))
+	}
+	return merged, nil
+}
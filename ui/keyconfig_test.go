@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func TestParseKeyConfig(t *testing.T) {
+	got, err := parseKeyConfig([]byte(`{"Up": ["w", " "], "quit": ["x"]}`))
+	if err != nil {
+		t.Fatalf("parseKeyConfig: %v", err)
+	}
+	if len(got) != 2 || strings.Join(got["up"], ",") != "w" || strings.Join(got["quit"], ",") != "x" {
+		t.Fatalf("parseKeyConfig = %v", got)
+	}
+
+	for _, bad := range []string{`{"fly": ["f"]}`, `{"up": []}`, `{"up": "w"}`, `not json`} {
+		if _, err := parseKeyConfig([]byte(bad)); err == nil {
+			t.Errorf("parseKeyConfig(%s) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestApplyKeyConfigOverride(t *testing.T) {
+	// Swapping two actions' keys is fine: conflicts are checked after merging.
+	km, err := applyKeyConfig(keys, map[string][]string{"up": {"j"}, "down": {"k"}, "daily": {"d"}})
+	if err != nil {
+		t.Fatalf("applyKeyConfig: %v", err)
+	}
+	if got := strings.Join(km.Up.Keys(), ","); got != "j" {
+		t.Errorf("Up keys = %s, want j", got)
+	}
+	if h := km.Daily.Help(); h.Key != "d" || h.Desc != "daily" {
+		t.Errorf("Daily help = %+v, want d/daily", h)
+	}
+	if got := strings.Join(km.Quit.Keys(), ","); got != "q,ctrl+c" {
+		t.Errorf("untouched Quit keys = %s", got)
+	}
+	if got := strings.Join(keys.Up.Keys(), ","); got != "up,k" {
+		t.Errorf("defaults modified: Up keys = %s", got)
+	}
+
+	// NewModel picks up the config file and the help shows the new key.
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, []byte(`{"refresh": ["R"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PHTUI_KEYS_FILE", path)
+	m := NewModel(nil)
+	if !key.Matches(keyRunes("R"), m.keys.Refresh) || key.Matches(keyRunes("r"), m.keys.Refresh) {
+		t.Error("model keymap did not rebind refresh to R")
+	}
+	m.help.ShowAll = true
+	if view := m.help.View(m.keys); !strings.Contains(view, "R") {
+		t.Errorf("help does not show the rebound key:\n%s", view)
+	}
+}
+
+func TestApplyKeyConfigConflict(t *testing.T) {
+	if _, err := applyKeyConfig(keys, nil); err != nil {
+		t.Fatalf("default bindings conflict: %v", err)
+	}
+
+	_, err := applyKeyConfig(keys, map[string][]string{"quit": {"j"}})
+	if err == nil || !strings.Contains(err.Error(), `"j" is bound to down and quit`) {
+		t.Fatalf("applyKeyConfig conflict err = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	os.WriteFile(path, []byte(`{"open": ["r"]}`), 0o644)
+	t.Setenv("PHTUI_KEYS_FILE", path)
+	m := NewModel(nil)
+	if !strings.HasPrefix(m.statusMsg, "Key config ignored:") {
+		t.Errorf("status = %q, want key config warning", m.statusMsg)
+	}
+	if !key.Matches(keyRunes("o"), m.keys.Open) {
+		t.Error("conflicting config did not fall back to defaults")
+	}
+}
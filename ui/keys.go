@@ -13,31 +13,77 @@ type keyMap struct {
 	Weekly     key.Binding
 	Monthly    key.Binding
 	Categories key.Binding
+	Upcoming   key.Binding
 	PrevDate   key.Binding
 	NextDate   key.Binding
 	Open       key.Binding
+	OpenRepo   key.Binding
+	JumpPros   key.Binding
+	JumpCons   key.Binding
+	JumpMaker  key.Binding
+	CopyURL    key.Binding
+	Cite       key.Binding
+	CopyJSON   key.Binding
+	Snapshot   key.Binding
+	Upload     key.Binding
 	Refresh    key.Binding
+	Pricing    key.Binding
+	MinReviews key.Binding
+	Featured   key.Binding
+	Sort       key.Binding
+	Changes    key.Binding
+	Preview    key.Binding
+	Combined   key.Binding
+	Compare    key.Binding
+	Focus      key.Binding
+	SaveSearch key.Binding
+	SavedList  key.Binding
+	Remove     key.Binding
+	Sources    key.Binding
 	Help       key.Binding
 	Quit       key.Binding
 }
 
 var keys = keyMap{
-	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
-	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
-	Search:   key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-	Enter:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "detail")),
-	Back:     key.NewBinding(key.WithKeys("esc", "backspace"), key.WithHelp("esc", "back")),
-	Tab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "period")),
-	Daily:    key.NewBinding(key.WithKeys("1")),
-	Weekly:   key.NewBinding(key.WithKeys("2")),
-	Monthly:    key.NewBinding(key.WithKeys("3")),
-	Categories: key.NewBinding(key.WithKeys("4")),
+	Up:         key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:       key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Search:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+	Enter:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "detail")),
+	Back:       key.NewBinding(key.WithKeys("esc", "backspace"), key.WithHelp("esc", "back")),
+	Tab:        key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "period")),
+	Daily:      key.NewBinding(key.WithKeys("1"), key.WithHelp("1", "daily")),
+	Weekly:     key.NewBinding(key.WithKeys("2"), key.WithHelp("2", "weekly")),
+	Monthly:    key.NewBinding(key.WithKeys("3"), key.WithHelp("3", "monthly")),
+	Categories: key.NewBinding(key.WithKeys("4"), key.WithHelp("4", "categories")),
+	Upcoming:   key.NewBinding(key.WithKeys("5"), key.WithHelp("5", "upcoming")),
 	PrevDate:   key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("h/←", "prev")),
-	NextDate: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("l/→", "next")),
-	Open:     key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open")),
-	Refresh:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
-	Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
-	Quit:     key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	NextDate:   key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("l/→", "next")),
+	Open:       key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open")),
+	OpenRepo:   key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "repo/site")),
+	JumpPros:   key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "pros")),
+	JumpCons:   key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "cons")),
+	JumpMaker:  key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "maker comment")),
+	CopyURL:    key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy url")),
+	Cite:       key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy citation")),
+	CopyJSON:   key.NewBinding(key.WithKeys("J"), key.WithHelp("J", "copy json")),
+	Snapshot:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "snapshot")),
+	Upload:     key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "upload")),
+	Refresh:    key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Pricing:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pricing")),
+	MinReviews: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "min reviews")),
+	Featured:   key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "featured only")),
+	Sort:       key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort")),
+	Changes:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "changes")),
+	Preview:    key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "preview")),
+	Combined:   key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "all periods")),
+	Compare:    key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "compare dates")),
+	Focus:      key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "focus")),
+	SaveSearch: key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save search")),
+	SavedList:  key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "saved searches")),
+	Remove:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "delete saved")),
+	Sources:    key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "parse sources")),
+	Help:       key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+	Quit:       key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
 }
 
 // ShortHelp returns short help key bindings (for help.Model)
@@ -49,8 +95,10 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Search, k.Enter, k.Back},
-		{k.Tab, k.Daily, k.Weekly, k.Monthly, k.Categories},
-		{k.PrevDate, k.NextDate, k.Open, k.Refresh},
-		{k.Help, k.Quit},
+		{k.Tab, k.Daily, k.Weekly, k.Monthly, k.Categories, k.Upcoming},
+		{k.PrevDate, k.NextDate, k.Open, k.Refresh, k.Pricing, k.Sort},
+		{k.OpenRepo, k.CopyURL, k.Cite, k.CopyJSON, k.Snapshot, k.Changes, k.Help, k.Quit},
+		{k.JumpPros, k.JumpCons, k.JumpMaker, k.Upload, k.Preview, k.Combined, k.Compare, k.MinReviews, k.Featured, k.Focus},
+		{k.SaveSearch, k.SavedList, k.Remove, k.Sources},
 	}
 }
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"github.com/qyinm/phtui/types"
+)
+
+// leaderboardOrder returns products in the leaderboard's current order:
+// newest featured first when launch-time sort is on, otherwise rank order.
+func (m Model) leaderboardOrder(products []types.Product) []types.Product {
+	if !m.launchSort {
+		return products
+	}
+	return types.SortByLaunchTime(products, m.featuredAt)
+}
+
+// toggleLaunchSort switches the leaderboard between rank order and launch
+// time order. Without featured times the leaderboard stays in rank order.
+func (m *Model) toggleLaunchSort() {
+	if m.categoryMode || m.categorySelectMode || m.upcomingMode || m.combinedMode || m.compareMode {
+		return
+	}
+	m.launchSort = !m.launchSort
+	m.selected = 0
+	m.products = m.leaderboardOrder(m.baseProducts)
+	switch {
+	case !m.launchSort:
+		m.statusMsg = "Sorted by rank"
+	case len(m.featuredAt) == 0:
+		m.statusMsg = "Launch times unavailable; sorted by rank"
+	default:
+		m.statusMsg = "Sorted by launch time, newest first (s to change)"
+	}
+}
@@ -1,9 +1,15 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -14,6 +20,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/scraper"
 	"github.com/qyinm/phtui/types"
 )
 
@@ -30,6 +37,7 @@ type tabRegion struct {
 	xStart, xEnd int
 	period       types.Period
 	isCategory   bool // true if this region is the Categories tab
+	isUpcoming   bool // true if this region is the Upcoming tab
 }
 
 // dateRegion represents a clickable region in the date bar.
@@ -39,11 +47,40 @@ type dateRegion struct {
 	date         time.Time // target date when action is "goto"
 }
 
+// clickRegions holds the tab and date bar click regions from a model's last
+// render. View has a value receiver, so the model keeps a pointer that its
+// copies share; the mutex lets a render and a click on different goroutines
+// meet safely.
+type clickRegions struct {
+	mu    sync.Mutex
+	tabs  []tabRegion
+	dates []dateRegion
+}
+
+func (r *clickRegions) set(tabs []tabRegion, dates []dateRegion) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tabs, r.dates = tabs, dates
+}
+
+func (r *clickRegions) get() ([]tabRegion, []dateRegion) {
+	if r == nil {
+		return nil, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tabs, r.dates
+}
+
 // Model is the main TUI model
 type Model struct {
 	source         types.ProductSource
 	list           list.Model
 	products       []types.Product
+	baseProducts   []types.Product // unfiltered results behind products
 	selected       int
 	viewport       viewport.Model
 	spinner        spinner.Model
@@ -58,8 +95,10 @@ type Model struct {
 	err            error
 	statusMsg      string
 	detail         types.ProductDetail
+	detailAnchors  map[detailSection]int // viewport line of each detail section header
 	requestID      int
-	dateBarRegions []dateRegion
+	requests       *requestContexts // cancels fetches superseded by a newer requestID
+	lastBarRegions *clickRegions
 	searchMode     bool
 	searchQuery    string
 	searchResults  bool
@@ -67,6 +106,61 @@ type Model struct {
 	searchHasPrev  bool
 	searchHasNext  bool
 	searchPages    int
+	// Search Cloudflare blocked, kept to retry while its panel is shown
+	blockedSearch bool
+	blockedQuery  string
+	blockedPage   int
+	// Saved searches, read from savedPath the first time they're needed;
+	// savedMode shows their list, and savingSearch prompts for a name
+	savedPath      string
+	savedSearches  []savedSearch
+	savedLoaded    bool
+	savedMode      bool
+	savedIdx       int
+	savingSearch   bool
+	saveName       string
+	pendingPricing string // pricing filter to apply to the next search results
+	// Pricing filter for search/category results ("", "free", "paid")
+	pricingFilter string
+	pricingBySlug map[string]string
+	// Review filter for category results: the active minimum review count
+	// (0 when off) and the one the filter key turns on (PHTUI_MIN_REVIEWS)
+	minReviews      int
+	reviewThreshold int
+	// Client-side sort for search results
+	searchSort types.SortOrder
+	// Featured-only filter for search results: hides products without
+	// reviews or a rating, or marked offline
+	featuredOnly bool
+	// Launch-time order for the leaderboard, with each product's featured
+	// time by slug from the last load
+	launchSort bool
+	featuredAt map[string]time.Time
+	// Change feed: badges for products that are new or moved since the
+	// previous load of the same leaderboard
+	changeFeed           bool
+	leaderboardSnapshots map[string][]types.Product
+	changeBadges         map[string]string
+	changeSeq            int
+	// Vote velocity: each leaderboard's vote counts at its last load, and
+	// the slugs gaining votes fastest since the load before
+	voteSnapshots map[string]voteSnapshot
+	trending      map[string]bool
+	// Rank sparklines by slug, from rank history already in the source's cache
+	sparklines map[string]string
+	// Upcoming (coming-soon) listing
+	upcomingMode bool
+	// Combined view: daily, weekly and monthly leaderboards merged, with
+	// each product's rank per leaderboard by slug
+	combinedMode   bool
+	combinedLabels map[string]string
+	// Compare view: two dates of the current period side by side. The
+	// focused column's date is date; the other's is compareOther
+	compareMode  bool
+	compareFocus int // 0=left (earlier), 1=right (later)
+	compareOther time.Time
+	compareSides [2]compareSide
+	compareDiff  compareDiff
 	// Category browsing
 	categoryMode bool
 	categorySlug string
@@ -84,6 +178,81 @@ type Model struct {
 	splitLoading       bool            // right pane loading
 	splitSlug          string          // slug of loaded category in right pane
 	splitRequestID     int             // request id for in-flight split-pane category fetch
+	catReloading       bool            // left pane is reloading categories from the live site
+	// Cancels split-pane category fetches superseded by a newer splitRequestID
+	splitRequests *requestContexts
+	// Product name layout on list rows (PHTUI_NAME_MIN_WIDTH, PHTUI_NAME_ALIGN)
+	nameColumn nameColumn
+	// Count formatting and the truncation marker (PHTUI_FULL_COUNTS, PHTUI_ELLIPSIS)
+	format textFormat
+	// Minimum terminal size before the "too small" message (PHTUI_MIN_SIZE)
+	minWidth  int
+	minHeight int
+	// Reset the date to today when switching periods (PHTUI_PERIOD_SWITCH_DATE)
+	resetDateOnSwitch bool
+	// Category the split pane opens on when no category is being viewed (PHTUI_DEFAULT_CATEGORY)
+	initialCategory string
+	// Focus mode: tabs, date bar, context header and help hidden so the
+	// list gets every line but the status bar
+	focusMode bool
+	// Preview pane beside the list with the selected product's detail
+	previewPane      bool
+	previewSlug      string // product the preview shows or is loading
+	previewReady     bool   // previewDetail/previewErr hold previewSlug's result
+	previewDetail    types.ProductDetail
+	previewErr       error
+	previewRequestID int
+	previewRequests  *requestContexts
+	// Session file saved on quit and periodically; empty when resuming is
+	// off (PHTUI_RESUME)
+	sessionPath string
+	// Listing restored from the session, and the selection to restore once
+	// it loads; resumeView is cleared after the first load
+	resumeView     string
+	resumeSelected int
+	// Citation format for the cite key (PHTUI_CITATION_FORMAT)
+	citationFormat string
+	// Copy detail JSON on one line instead of indented (PHTUI_COPY_JSON_COMPACT)
+	compactJSON bool
+	// Paste service for list uploads; empty disables them (PHTUI_PASTE_URL)
+	pasteURL string
+	// Copy the paste URL to the clipboard after an upload (PHTUI_PASTE_COPY)
+	pasteCopy bool
+	// Source markers: the leaderboard's products its parser found only in
+	// the page's hydration data, marked while showSources is on. The key
+	// is only enabled with PHTUI_DEBUG_SOURCES
+	showSources   bool
+	hydrationOnly map[string]bool
+}
+
+const (
+	defaultMinWidth  = 60
+	defaultMinHeight = 15
+)
+
+// parseMinSize parses a "WIDTHxHEIGHT" override such as "40x10". Invalid or
+// non-positive values fall back to the defaults.
+func parseMinSize(raw string) (int, int) {
+	w, h, ok := strings.Cut(strings.ToLower(strings.TrimSpace(raw)), "x")
+	if !ok {
+		return defaultMinWidth, defaultMinHeight
+	}
+	width, err := strconv.Atoi(strings.TrimSpace(w))
+	if err != nil || width <= 0 {
+		return defaultMinWidth, defaultMinHeight
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(h))
+	if err != nil || height <= 0 {
+		return defaultMinWidth, defaultMinHeight
+	}
+	return width, height
+}
+
+// parseResetDateOnSwitch reports whether period switches should reset the
+// date to today. "today" resets; anything else, including the default
+// "keep", preserves the current date.
+func parseResetDateOnSwitch(raw string) bool {
+	return strings.EqualFold(strings.TrimSpace(raw), "today")
 }
 
 // NewModel creates a new Model with the given ProductSource
@@ -104,22 +273,61 @@ func NewModel(source types.ProductSource) Model {
 	h.Styles.ShortSeparator = lipgloss.NewStyle().Foreground(DraculaComment)
 	h.Styles.FullSeparator = lipgloss.NewStyle().Foreground(DraculaComment)
 
-	return Model{
-		source:    source,
-		list:      l,
-		products:  nil,
-		selected:  0,
-		viewport:  vp,
-		spinner:   s,
-		help:      h,
-		keys:      keys,
-		state:     ListView,
-		period:    types.Daily,
-		date:      time.Now(),
-		loading:   source != nil,
-		requestID: 1,
-		statusMsg: "Ready",
-	}
+	minWidth, minHeight := parseMinSize(os.Getenv("PHTUI_MIN_SIZE"))
+
+	// A bad key config keeps the default bindings; say why in the status bar.
+	statusMsg := "Ready"
+	km, err := loadKeyMap(keyConfigPath())
+	if err != nil {
+		statusMsg = "Key config ignored: " + err.Error()
+	}
+
+	m := Model{
+		source:            source,
+		list:              l,
+		products:          nil,
+		selected:          0,
+		viewport:          vp,
+		spinner:           s,
+		help:              h,
+		keys:              km,
+		state:             ListView,
+		period:            types.Daily,
+		date:              types.Today(),
+		loading:           source != nil,
+		requestID:         1,
+		requests:          &requestContexts{},
+		splitRequests:     &requestContexts{},
+		previewRequests:   &requestContexts{},
+		lastBarRegions:    &clickRegions{},
+		statusMsg:         statusMsg,
+		minWidth:          minWidth,
+		minHeight:         minHeight,
+		resetDateOnSwitch: parseResetDateOnSwitch(os.Getenv("PHTUI_PERIOD_SWITCH_DATE")),
+		initialCategory:   strings.ToLower(strings.TrimSpace(os.Getenv("PHTUI_DEFAULT_CATEGORY"))),
+		pasteURL:          strings.TrimSpace(os.Getenv("PHTUI_PASTE_URL")),
+		pasteCopy:         strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_PASTE_COPY")), "true"),
+		compactJSON:       strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_COPY_JSON_COMPACT")), "true"),
+		sessionPath:       sessionPath(),
+		citationFormat:    citationFormatFromEnv(),
+		reviewThreshold:   reviewThresholdFromEnv(),
+		nameColumn:        nameColumnFromEnv(),
+		format:            textFormatFromEnv(),
+		savedPath:         savedSearchesPath(),
+	}
+	m.keys.Sources.SetEnabled(debugSourcesFromEnv())
+	if m.sessionPath != "" {
+		saved, err := loadSession(m.sessionPath)
+		if err == nil {
+			err = m.resumeSession(saved, types.Today())
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			m.statusMsg = "Session not resumed: " + err.Error()
+		} else if err == nil && m.statusMsg == "Ready" {
+			m.statusMsg = "Resuming last session"
+		}
+	}
+	return m
 }
 
 func newProductListModel(items []list.Item, width, height int) list.Model {
@@ -145,11 +353,29 @@ func (m Model) Init() tea.Cmd {
 	if m.source == nil {
 		return nil
 	}
-	return tea.Batch(m.spinner.Tick, fetchLeaderboard(m.source, m.period, m.date, m.requestID))
+	var saveTick tea.Cmd
+	if m.sessionPath != "" {
+		saveTick = sessionSaveTick()
+	}
+	return tea.Batch(m.spinner.Tick, m.initialFetch(), saveTick)
 }
 
 // Update handles all messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.handleMsg(msg)
+	next, ok := updated.(Model)
+	if !ok {
+		return updated, cmd
+	}
+	next.applyResumedSelection()
+	// Whatever moved the selection, the preview pane follows it.
+	if previewCmd := next.syncPreview(); previewCmd != nil {
+		cmd = tea.Batch(cmd, previewCmd)
+	}
+	return next, cmd
+}
+
+func (m Model) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case leaderboardMsg:
@@ -160,9 +386,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.err = msg.err
 			m.statusMsg = "Failed to fetch: " + msg.err.Error()
+			if errors.Is(msg.err, scraper.ErrParseEmpty) {
+				m.statusMsg = "Couldn't read any products from this leaderboard; Product Hunt's layout may have changed"
+			}
 			return m, nil
 		}
-		m.products = msg.products
+		m.baseProducts = msg.products
+		m.featuredAt = msg.featured
+		m.hydrationOnly = msg.hydrationOnly
+		m.products = m.leaderboardOrder(msg.products)
+		m.refreshSparklines()
+		m.recordVoteVelocity(msg.products, time.Now())
+		var changeCmd tea.Cmd
+		if m.changeFeed {
+			changeCmd = m.recordLeaderboardChanges(msg.products)
+		} else {
+			m.changeBadges = nil
+			m.snapshotLeaderboard(msg.products)
+		}
+		m.pricingFilter = ""
 		m.searchResults = false
 		m.searchPage = 0
 		m.searchHasPrev = false
@@ -171,11 +413,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.categoryMode = false
 		m.categorySlug = ""
 		m.categoryName = ""
+		m.upcomingMode = false
+		m.combinedMode = false
+		m.compareMode = false
+		m.combinedLabels = nil
 		m.selected = 0
-		listHeight := m.height - 4
-		if listHeight < 1 {
-			listHeight = 1
-		}
+		listHeight := m.listHeight()
 		items := make([]list.Item, len(m.products))
 		for i, p := range m.products {
 			items[i] = p
@@ -188,13 +431,162 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(m.products) == 0 {
 			m.statusMsg = "No products found for this period"
 		} else {
-			firstRank := m.products[0].Rank()
-			lastRank := m.products[len(m.products)-1].Rank()
+			firstRank := m.baseProducts[0].Rank()
+			lastRank := m.baseProducts[len(m.baseProducts)-1].Rank()
 			selectedRank := firstRank
 			if p, ok := m.selectedProduct(); ok {
 				selectedRank = p.Rank()
 			}
-			m.statusMsg = fmt.Sprintf("Loaded %d products (ranks %d-%d, selected #%d)", len(m.products), firstRank, lastRank, selectedRank)
+			timing := ""
+			if msg.elapsed > 0 {
+				timing = " in " + formatElapsed(msg.elapsed)
+			}
+			m.statusMsg = fmt.Sprintf("Loaded %d products%s (ranks %d-%d, selected #%d)", len(m.products), timing, firstRank, lastRank, selectedRank)
+			if m.launchSort && len(m.featuredAt) > 0 {
+				m.statusMsg += " · newest launches first"
+			}
+			if len(m.changeBadges) > 0 {
+				m.statusMsg += fmt.Sprintf(" · %d changed since last load", len(m.changeBadges))
+			}
+		}
+		return m, changeCmd
+
+	case categoryIndexMsg:
+		if !m.catReloading {
+			return m, nil
+		}
+		m.catReloading = false
+		if errors.Is(msg.err, context.Canceled) {
+			// A newer request superseded the reload's context.
+			m.statusMsg = "Category reload cancelled"
+			return m, nil
+		}
+		if msg.err != nil || len(msg.categories) == 0 {
+			reason := "no categories found"
+			if msg.err != nil {
+				reason = msg.err.Error()
+			}
+			m.statusMsg = fmt.Sprintf("Category reload failed (%s); keeping %d categories", reason, len(types.AllCategories))
+			return m, nil
+		}
+		m.applyCategoryIndex(msg.categories)
+		return m, nil
+
+	case upcomingMsg:
+		if msg.requestID != m.requestID {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			m.statusMsg = "Failed to fetch upcoming: " + msg.err.Error()
+			return m, nil
+		}
+		m.upcomingMode = true
+		m.combinedMode = false
+		m.compareMode = false
+		m.changeBadges = nil
+		m.products = msg.products
+		m.baseProducts = msg.products
+		m.pricingFilter = ""
+		m.searchResults = false
+		m.searchPage = 0
+		m.searchHasPrev = false
+		m.searchHasNext = false
+		m.searchPages = 0
+		m.categoryMode = false
+		m.categorySlug = ""
+		m.categoryName = ""
+		m.selected = 0
+		listHeight := m.listHeight()
+		items := make([]list.Item, len(m.products))
+		for i, p := range m.products {
+			items[i] = p
+		}
+		m.list = newProductListModel(items, m.width, listHeight)
+		m.list.Paginator.Page = 0
+		m.list.Select(0)
+		m.list.ResetSelected()
+		m.err = nil
+		if len(m.products) == 0 {
+			m.statusMsg = "No upcoming products found"
+		} else {
+			m.statusMsg = fmt.Sprintf("Loaded %d upcoming products", len(m.products))
+		}
+		return m, nil
+
+	case compareMsg:
+		if !m.receiveCompare(msg) {
+			return m, nil
+		}
+		items := make([]list.Item, len(m.products))
+		for i, p := range m.products {
+			items[i] = p
+		}
+		m.list = newProductListModel(items, m.width, m.listHeight())
+		m.list.Paginator.Page = 0
+		m.list.Select(0)
+		m.list.ResetSelected()
+		return m, nil
+
+	case combinedMsg:
+		if msg.requestID != m.requestID {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			m.statusMsg = "Failed to fetch: " + msg.err.Error()
+			return m, nil
+		}
+		m.combinedMode = true
+		m.combinedLabels = msg.labels
+		m.changeBadges = nil
+		m.products = msg.products
+		m.baseProducts = msg.products
+		m.refreshSparklines()
+		m.pricingFilter = ""
+		m.selected = 0
+		items := make([]list.Item, len(m.products))
+		for i, p := range m.products {
+			items[i] = p
+		}
+		m.list = newProductListModel(items, m.width, m.listHeight())
+		m.list.Paginator.Page = 0
+		m.list.Select(0)
+		m.list.ResetSelected()
+		m.err = nil
+		m.statusMsg = fmt.Sprintf("Combined %d products from the daily, weekly and monthly leaderboards in %s", len(m.products), formatElapsed(msg.elapsed))
+		if len(msg.failed) > 0 {
+			failed := make([]string, len(msg.failed))
+			for i, p := range msg.failed {
+				failed[i] = p.String()
+			}
+			m.statusMsg += " (couldn't fetch " + strings.Join(failed, ", ") + ")"
+		}
+		return m, nil
+
+	case sessionSaveMsg:
+		m.saveCurrentSession()
+		return m, sessionSaveTick()
+
+	case pasteUploadMsg:
+		m.finishPasteUpload(msg)
+		return m, nil
+
+	case previewDebounceMsg:
+		if msg.requestID != m.previewRequestID || m.source == nil {
+			return m, nil // the cursor moved on before the pause ended
+		}
+		return m, fetchPreviewDetail(m.previewRequests.forRequest(msg.requestID), m.source, msg.slug, msg.requestID)
+
+	case previewDetailMsg:
+		m.finishPreview(msg)
+		return m, nil
+
+	case clearChangesMsg:
+		if msg.seq == m.changeSeq {
+			m.changeBadges = nil
 		}
 		return m, nil
 
@@ -206,14 +598,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.err = msg.err
 			m.statusMsg = "Failed to fetch: " + msg.err.Error()
+			if errors.Is(msg.err, types.ErrProductUnavailable) {
+				m.statusMsg = "This product is hidden or no longer available on Product Hunt"
+			}
 			return m, nil
 		}
 		m.detail = msg.detail
-		m.viewport.SetContent(m.renderDetailContent())
+		m.changeBadges = nil
+		m.refreshSparklines() // the detail fetch may have cached rank history
+		content, anchors := m.renderDetailContent()
+		m.detailAnchors = anchors
+		m.viewport.SetContent(content)
 		m.viewport.GotoTop()
 		m.state = DetailView
 		m.err = nil
 		m.statusMsg = m.detail.Product().Name()
+		if msg.elapsed > 0 {
+			m.statusMsg += " • loaded in " + formatElapsed(msg.elapsed)
+		}
 		return m, nil
 
 	case searchResultsMsg:
@@ -224,22 +626,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.err = msg.err
 			m.statusMsg = "Search failed: " + msg.err.Error()
+			m.pendingPricing = ""
+			m.blockedSearch = errors.Is(msg.err, scraper.ErrCloudflareChallenge)
+			if m.blockedSearch {
+				m.blockedQuery, m.blockedPage = msg.query, msg.page
+				m.statusMsg = "Search blocked by Cloudflare"
+			}
 			return m, nil
 		}
+		m.blockedSearch = false
 		m.searchQuery = msg.query
 		m.searchMode = false
+		m.changeBadges = nil
+		m.upcomingMode = false
+		m.combinedMode = false
+		m.compareMode = false
 		m.searchResults = true
 		m.searchPage = msg.page
 		m.searchHasPrev = msg.hasPrev
 		m.searchHasNext = msg.hasNext
 		m.searchPages = msg.pages
-		m.products = msg.products
+		m.baseProducts = msg.products
+		products, hidden := m.featuredResults(msg.products)
+		m.products = m.sortedSearchResults(products)
+		m.refreshSparklines()
+		m.pricingFilter = ""
 		m.selected = 0
 
-		listHeight := m.height - 4
-		if listHeight < 1 {
-			listHeight = 1
-		}
+		listHeight := m.listHeight()
 		items := make([]list.Item, len(m.products))
 		for i, p := range m.products {
 			items[i] = p
@@ -250,8 +664,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.ResetSelected()
 		m.err = nil
 		m.statusMsg = m.searchStatus()
+		if m.featuredOnly {
+			m.statusMsg += " • " + featuredFilterStatus(hidden)
+		}
+		if msg.elapsed > 0 {
+			m.statusMsg += " • loaded in " + formatElapsed(msg.elapsed)
+		}
+		if m.pendingPricing != "" {
+			m.pricingFilter, m.pendingPricing = m.pendingPricing, ""
+			return m, m.loadPricingFilter()
+		}
 		return m, nil
 
+	case categoryDebounceMsg:
+		if !m.categorySelectMode || msg.requestID != m.splitRequestID || m.source == nil {
+			return m, nil // the cursor moved on before the pause ended
+		}
+		return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.splitRequests.forRequest(msg.requestID), m.source, msg.slug, msg.requestID))
+
 	case categoryProductsMsg:
 		if m.categorySelectMode {
 			if msg.requestID != m.splitRequestID {
@@ -269,6 +699,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.splitSelected = 0
 			m.splitSlug = msg.slug
 			m.err = nil
+			added := m.mergeRelatedCategories(msg.categories)
 			// Derive display name for status
 			catName := slugToDisplayName(msg.slug)
 			idx := types.CategoryIndexBySlug(msg.slug)
@@ -280,6 +711,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.statusMsg = fmt.Sprintf("%d products in %s", len(m.splitProducts), catName)
 			}
+			if added > 0 {
+				m.statusMsg += fmt.Sprintf(" · %d new categories discovered", added)
+			}
 			return m, nil
 		}
 		// Ignore category responses when we are not actively waiting for one.
@@ -297,8 +731,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = "Failed to fetch category: " + msg.err.Error()
 			return m, nil
 		}
+		m.mergeRelatedCategories(msg.categories)
 		m.categoryMode = true
 		m.categorySelectMode = false
+		m.upcomingMode = false
+		m.combinedMode = false
+		m.compareMode = false
 		m.categorySlug = msg.slug
 		m.searchResults = false
 		m.searchPage = 0
@@ -314,13 +752,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.categoryName = slugToDisplayName(msg.slug)
 		}
-		m.products = msg.products
+		var hidden int
+		m.products, hidden = types.FilterByMinReviews(msg.products, m.minReviews)
+		m.baseProducts = msg.products
+		m.refreshSparklines()
+		m.pricingFilter = ""
 		m.selected = 0
 
-		listHeight := m.height - 4
-		if listHeight < 1 {
-			listHeight = 1
-		}
+		listHeight := m.listHeight()
 		items := make([]list.Item, len(m.products))
 		for i, p := range m.products {
 			items[i] = p
@@ -335,6 +774,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.statusMsg = fmt.Sprintf("%d products in %s", len(m.products), m.categoryName)
 		}
+		if m.minReviews > 0 {
+			m.statusMsg += " • " + reviewFilterStatus(m.minReviews, hidden)
+		}
+		return m, nil
+
+	case pricingMsg:
+		if msg.requestID != m.requestID {
+			return m, nil
+		}
+		m.loading = false
+		if m.pricingBySlug == nil {
+			m.pricingBySlug = make(map[string]string, len(msg.pricing))
+		}
+		for slug, pricingType := range msg.pricing {
+			m.pricingBySlug[slug] = pricingType
+		}
+		m.applyPricingFilter()
 		return m, nil
 
 	case spinner.TickMsg:
@@ -346,7 +802,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// Ahead of the quit key, so a saved search's name can contain q;
+		// ctrl+c still quits.
+		if m.savingSearch && msg.Type != tea.KeyCtrlC {
+			m.updateSaveName(msg)
+			return m, nil
+		}
 		if key.Matches(msg, m.keys.Quit) {
+			m.saveCurrentSession()
+			m.cancelRequests()
 			return m, tea.Quit
 		}
 
@@ -355,6 +819,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == ListView && m.savedMode {
+			return m.updateSavedList(msg)
+		}
+
 		if m.state == ListView && m.searchMode {
 			switch msg.Type {
 			case tea.KeyEsc:
@@ -362,19 +830,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.statusMsg = m.searchStatus()
 				return m, nil
 			case tea.KeyEnter:
+				// An empty query is sent too: sources with the trending
+				// fallback answer it with today's leaderboard.
 				query := strings.TrimSpace(m.searchQuery)
 				m.searchMode = false
-				if query == "" {
-					m.statusMsg = m.searchStatus()
-					return m, nil
-				}
 				if m.source == nil {
 					return m, nil
 				}
 				m.loading = true
 				m.statusMsg = "Searching..."
+				if query == "" {
+					m.statusMsg = "Loading trending..."
+				}
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.source, query, 1, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, query, 1, m.requestID))
 			case tea.KeyCtrlU:
 				m.searchQuery = ""
 				m.statusMsg = m.searchStatus()
@@ -467,6 +936,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if key.Matches(msg, m.keys.CopyURL) {
+			m.copyPageURL()
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Cite) {
+			m.copyCitation()
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Snapshot) {
+			m.saveSnapshot()
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Upload) {
+			return m, m.startPasteUpload()
+		}
+
 		// Split pane mode — right pane focused (product list)
 		if m.categorySelectMode && !m.catFilterMode && m.splitFocus == 1 {
 			switch {
@@ -498,14 +983,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.loading = true
 					m.statusMsg = "Loading detail..."
 					m.requestID++
-					return m, tea.Batch(m.spinner.Tick, fetchProductDetail(m.source, p.Slug(), m.requestID))
+					return m, tea.Batch(m.spinner.Tick, fetchProductDetail(m.requestCtx(), m.source, p.Slug(), m.requestID))
 				}
 				return m, nil
 			case key.Matches(msg, m.keys.Open):
 				if m.splitSelected >= 0 && m.splitSelected < len(m.splitProducts) {
 					p := m.splitProducts[m.splitSelected]
 					if p.Slug() != "" {
-						_ = exec.Command("open", "https://www.producthunt.com/products/"+p.Slug()).Start()
+						_ = exec.Command("open", scraper.ProductURL(p.Slug())).Start()
 					}
 				}
 				return m, nil
@@ -528,6 +1013,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.statusMsg = fmt.Sprintf("%d products", len(m.products))
 				}
 				return m, nil
+			case key.Matches(msg, m.keys.Refresh):
+				// r → reload the category list from the live site
+				if m.catReloading || m.source == nil {
+					return m, nil
+				}
+				m.catReloading = true
+				m.statusMsg = "Reloading categories..."
+				return m, tea.Batch(m.spinner.Tick, fetchCategoryIndex(m.requestCtx(), m.source))
 			case key.Matches(msg, m.keys.Search):
 				// / → enter filter mode
 				m.catFilterMode = true
@@ -552,13 +1045,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				visible := m.catVisibleList()
 				if m.catSelectIdx < len(visible)-1 {
 					m.catSelectIdx++
-					return m, m.loadSelectedCategory()
+					return m, m.debounceSelectedCategory()
 				}
 				return m, nil
 			case key.Matches(msg, m.keys.Up):
 				if m.catSelectIdx > 0 {
 					m.catSelectIdx--
-					return m, m.loadSelectedCategory()
+					return m, m.debounceSelectedCategory()
 				}
 				return m, nil
 			}
@@ -577,17 +1070,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = m.searchStatus()
 			return m, nil
 
+		case m.compareMode && !m.categorySelectMode && m.state == ListView && key.Matches(msg, m.keys.Tab):
+			m.switchCompareFocus()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Tab):
-			if m.categoryMode || m.categorySelectMode {
-				// From categories/category-select → Daily leaderboard
+			if m.upcomingMode {
+				// From upcoming → Daily leaderboard
 				return m.switchToLeaderboard(types.Daily)
 			}
+			if m.categoryMode || m.categorySelectMode {
+				// From categories/category-select → Upcoming
+				return m.switchToUpcoming()
+			}
 			switch m.period {
 			case types.Daily:
 				return m.switchToLeaderboard(types.Weekly)
 			case types.Weekly:
 				return m.switchToLeaderboard(types.Monthly)
 			case types.Monthly:
+				if !categoriesAvailable() {
+					// Categories tab is disabled; skip to Upcoming
+					return m.switchToUpcoming()
+				}
 				// Monthly → Category split pane
 				m.state = ListView
 				cmd := m.enterCategorySelectMode()
@@ -595,19 +1100,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, m.keys.Daily):
-			if m.period == types.Daily && !m.categoryMode && !m.categorySelectMode {
+			if m.period == types.Daily && !m.categoryMode && !m.categorySelectMode && !m.upcomingMode && !m.combinedMode && !m.compareMode {
 				return m, nil
 			}
 			return m.switchToLeaderboard(types.Daily)
 
 		case key.Matches(msg, m.keys.Weekly):
-			if m.period == types.Weekly && !m.categoryMode && !m.categorySelectMode {
+			if m.period == types.Weekly && !m.categoryMode && !m.categorySelectMode && !m.upcomingMode && !m.combinedMode && !m.compareMode {
 				return m, nil
 			}
 			return m.switchToLeaderboard(types.Weekly)
 
 		case key.Matches(msg, m.keys.Monthly):
-			if m.period == types.Monthly && !m.categoryMode && !m.categorySelectMode {
+			if m.period == types.Monthly && !m.categoryMode && !m.categorySelectMode && !m.upcomingMode && !m.combinedMode && !m.compareMode {
 				return m, nil
 			}
 			return m.switchToLeaderboard(types.Monthly)
@@ -620,6 +1125,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd := m.enterCategorySelectMode()
 			return m, cmd
 
+		case key.Matches(msg, m.keys.Upcoming):
+			if m.upcomingMode && !m.categorySelectMode {
+				return m, nil
+			}
+			return m.switchToUpcoming()
+
 		case key.Matches(msg, m.keys.PrevDate):
 			if m.searchResults {
 				if !m.searchHasPrev || m.searchPage <= 1 {
@@ -631,7 +1142,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.statusMsg = "Loading search page..."
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.source, m.searchQuery, m.searchPage-1, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, m.searchQuery, m.searchPage-1, m.requestID))
+			}
+			if m.upcomingMode {
+				// The coming-soon listing has no dates to page through
+				return m, nil
 			}
 			if m.categoryMode {
 				// Navigate to previous category in AllCategories
@@ -654,9 +1169,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.statusMsg = "Loading category..."
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.source, slug, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.requestCtx(), m.source, slug, m.requestID))
 			}
-			switch m.period {
+			period := m.period
+			if m.combinedMode {
+				period = types.Daily // the combined view steps a day at a time
+			}
+			switch period {
 			case types.Daily:
 				m.date = m.date.AddDate(0, 0, -1)
 			case types.Weekly:
@@ -671,7 +1190,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.requestID++
-			return m, tea.Batch(m.spinner.Tick, fetchLeaderboard(m.source, m.period, m.date, m.requestID))
+			return m, tea.Batch(m.spinner.Tick, m.fetchLeaderboardView())
 
 		case key.Matches(msg, m.keys.NextDate):
 			if m.searchResults {
@@ -684,7 +1203,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.statusMsg = "Loading search page..."
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.source, m.searchQuery, m.searchPage+1, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, m.searchQuery, m.searchPage+1, m.requestID))
+			}
+			if m.upcomingMode {
+				return m, nil
 			}
 			if m.categoryMode {
 				// Navigate to next category in AllCategories
@@ -707,10 +1229,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.statusMsg = "Loading category..."
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.source, slug, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.requestCtx(), m.source, slug, m.requestID))
 			}
 			var next time.Time
-			switch m.period {
+			period := m.period
+			if m.combinedMode {
+				period = types.Daily
+			}
+			switch period {
 			case types.Daily:
 				next = m.date.AddDate(0, 0, 1)
 			case types.Weekly:
@@ -729,9 +1255,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.requestID++
-			return m, tea.Batch(m.spinner.Tick, fetchLeaderboard(m.source, m.period, m.date, m.requestID))
+			return m, tea.Batch(m.spinner.Tick, m.fetchLeaderboardView())
+
+		case m.searchBlocked() && key.Matches(msg, m.keys.Back):
+			// Dismiss the blocked-search panel, back to the list behind it.
+			m.blockedSearch = false
+			m.err = nil
+			m.statusMsg = m.searchStatus()
+			return m, nil
 
 		case key.Matches(msg, m.keys.Refresh):
+			if m.searchBlocked() {
+				return m.retryBlockedSearch()
+			}
 			if m.searchResults {
 				if m.source == nil {
 					return m, nil
@@ -743,7 +1279,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if page <= 0 {
 					page = 1
 				}
-				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.source, m.searchQuery, page, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, m.searchQuery, page, m.requestID))
 			}
 			if m.categoryMode && m.categorySlug != "" {
 				if m.source == nil {
@@ -752,7 +1288,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.statusMsg = "Refreshing category..."
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.source, m.categorySlug, m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.requestCtx(), m.source, m.categorySlug, m.requestID))
+			}
+			if m.upcomingMode {
+				if m.source == nil {
+					return m, nil
+				}
+				m.loading = true
+				m.statusMsg = "Refreshing upcoming..."
+				m.requestID++
+				return m, tea.Batch(m.spinner.Tick, fetchUpcoming(m.requestCtx(), m.source, m.requestID))
 			}
 			m.state = ListView
 			m.loading = true
@@ -760,25 +1305,115 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.source == nil {
 				return m, nil
 			}
+			m.invalidateLeaderboardView()
 			m.requestID++
-			return m, tea.Batch(m.spinner.Tick, fetchLeaderboard(m.source, m.period, m.date, m.requestID))
+			return m, tea.Batch(m.spinner.Tick, m.fetchLeaderboardView())
+
+		case m.state == ListView && key.Matches(msg, m.keys.Pricing):
+			if !m.searchResults && !m.categoryMode {
+				return m, nil
+			}
+			switch m.pricingFilter {
+			case "":
+				m.pricingFilter = "free"
+			case "free":
+				m.pricingFilter = "paid"
+			default:
+				m.pricingFilter = ""
+			}
+			return m, m.loadPricingFilter()
+
+		case m.state == ListView && key.Matches(msg, m.keys.Sort):
+			if !m.searchResults {
+				m.toggleLaunchSort()
+				return m, nil
+			}
+			m.searchSort = m.searchSort.Next()
+			m.applyPricingFilter()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.SaveSearch):
+			m.startSaveSearch()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.SavedList):
+			m.toggleSavedSearches()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.MinReviews):
+			m.toggleReviewFilter()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.Featured):
+			m.toggleFeaturedOnly()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.Sources):
+			m.toggleSources()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.Preview):
+			m.togglePreview()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Focus):
+			m.toggleFocus()
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.Changes):
+			m.changeFeed = !m.changeFeed
+			m.changeBadges = nil
+			if m.changeFeed {
+				m.statusMsg = "Change feed on: refresh (r) to compare with the last load"
+			} else {
+				m.statusMsg = "Change feed off"
+			}
+			return m, nil
+
+		case m.state == ListView && key.Matches(msg, m.keys.Combined):
+			return m.toggleCombined()
+
+		case m.state == ListView && key.Matches(msg, m.keys.Compare):
+			return m.toggleCompare()
 
 		case key.Matches(msg, m.keys.Open):
 			var url string
 			switch m.state {
 			case ListView:
 				if p, ok := m.selectedProduct(); ok && p.Slug() != "" {
-					url = "https://www.producthunt.com/products/" + p.Slug()
+					url = scraper.ProductURL(p.Slug())
 				}
 			case DetailView:
 				if m.detail.Product().Slug() != "" {
-					url = "https://www.producthunt.com/products/" + m.detail.Product().Slug()
+					url = scraper.ProductURL(m.detail.Product().Slug())
 				}
 			}
 			if url != "" {
 				_ = exec.Command("open", url).Start()
 			}
 			return m, nil
+
+		case m.state == DetailView && key.Matches(msg, m.keys.OpenRepo):
+			if url := m.detail.RepoOrWebsite(); url != "" {
+				_ = exec.Command("open", url).Start()
+			}
+			return m, nil
+
+		case m.state == DetailView && key.Matches(msg, m.keys.JumpPros):
+			m.jumpToSection(sectionPros)
+			return m, nil
+
+		case m.state == DetailView && key.Matches(msg, m.keys.JumpCons):
+			m.jumpToSection(sectionCons)
+			return m, nil
+
+		case m.state == DetailView && key.Matches(msg, m.keys.JumpMaker):
+			m.jumpToSection(sectionMakerComment)
+			return m, nil
+
+		case m.state == DetailView && key.Matches(msg, m.keys.CopyJSON):
+			m.copyDetailJSON()
+			return m, nil
 		}
 
 		switch m.state {
@@ -794,7 +1429,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.statusMsg = "Loading detail..."
 				m.requestID++
-				return m, tea.Batch(m.spinner.Tick, fetchProductDetail(m.source, p.Slug(), m.requestID))
+				return m, tea.Batch(m.spinner.Tick, fetchProductDetail(m.requestCtx(), m.source, p.Slug(), m.requestID))
 			}
 			if key.Matches(msg, m.keys.Up) {
 				if m.selected > 0 {
@@ -835,10 +1470,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.loading {
 			return m, nil
 		}
-		if msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionRelease && m.state == ListView {
-			// Row 0: period tab bar (Daily / Weekly / Monthly / Categories)
+		// Focus mode hides the tab and date bars, so their rows aren't clickable.
+		if msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionRelease && m.state == ListView && !m.focusMode {
+			tabRegions, dateRegions := m.lastBarRegions.get()
+			// Row 0: period tab bar (Daily / Weekly / Monthly / Categories / Upcoming)
 			if msg.Y == 0 {
-				for _, r := range lastTabBarRegions {
+				for _, r := range tabRegions {
 					if msg.X >= r.xStart && msg.X < r.xEnd {
 						if r.isCategory {
 							if !m.categorySelectMode {
@@ -846,8 +1483,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								cmd := m.enterCategorySelectMode()
 								return m, cmd
 							}
+						} else if r.isUpcoming {
+							if !m.upcomingMode || m.categorySelectMode {
+								return m.switchToUpcoming()
+							}
 						} else {
-							if m.categoryMode || m.categorySelectMode || r.period != m.period {
+							if m.categoryMode || m.categorySelectMode || m.upcomingMode || m.combinedMode || m.compareMode || r.period != m.period {
 								return m.switchToLeaderboard(r.period)
 							}
 						}
@@ -857,7 +1498,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// Row 1: date selector bar
 			if msg.Y == 1 {
-				for _, r := range lastDateBarRegions {
+				for _, r := range dateRegions {
 					if msg.X >= r.xStart && msg.X < r.xEnd {
 						return m.handleDateBarClick(r)
 					}
@@ -883,35 +1524,38 @@ func (m Model) View() string {
 	}
 
 	// Check if terminal is too small
-	if m.width < 60 || m.height < 15 {
+	if m.width < m.minWidth || m.height < m.minHeight {
 		return lipgloss.NewStyle().
 			Foreground(DraculaOrange).
-			Render("Terminal too small. Resize to at least 60x15.")
+			Render(fmt.Sprintf("Terminal too small. Resize to at least %dx%d.", m.minWidth, m.minHeight))
 	}
 
 	var sections []string
 
-	if m.state == ListView || m.loading {
+	if (m.state == ListView || m.loading) && !m.focusMode {
 		sections = append(sections, m.renderTabBar())
 	}
 
 	if m.loading {
-		available := m.height - 4 // tab + status + help
-		if available < 1 {
-			available = 1
-		}
+		available := m.contentHeight(4) // tab + status + help
 		spin := m.spinner.View() + " Loading..."
 		sections = append(sections, lipgloss.Place(m.width, available, lipgloss.Center, lipgloss.Center, spin))
 	} else {
 		switch m.state {
 		case ListView:
-			if m.categorySelectMode {
+			if m.savedMode {
+				sections = append(sections, m.renderSavedSearches(m.contentHeight(4)))
+			} else if m.categorySelectMode {
 				sections = append(sections, m.renderSplitPane())
-			} else if len(m.products) == 0 {
-				available := m.height - 4 // tab + status + help
-				if available < 1 {
-					available = 1
+			} else if m.searchBlocked() {
+				sections = append(sections, m.renderSearchBlocked(m.contentHeight(4)))
+			} else if m.compareMode {
+				if !m.focusMode {
+					sections = append(sections, ContextHeaderStyle.Render(m.format.truncate(m.contextHeader(), m.width)))
 				}
+				sections = append(sections, m.renderCompare())
+			} else if len(m.products) == 0 {
+				available := m.contentHeight(4) // tab + status + help
 				emptyText := "No products found for this period"
 				if m.searchResults {
 					emptyText = fmt.Sprintf("No results for \"%s\"", m.searchQuery)
@@ -919,37 +1563,49 @@ func (m Model) View() string {
 				msg := lipgloss.NewStyle().Foreground(DraculaComment).Render(emptyText)
 				sections = append(sections, lipgloss.Place(m.width, available, lipgloss.Center, lipgloss.Center, msg))
 			} else {
-				sections = append(sections, m.renderProductList())
+				if !m.focusMode {
+					sections = append(sections, ContextHeaderStyle.Render(m.format.truncate(m.contextHeader(), m.width)))
+				}
+				if m.previewActive() {
+					// The selected row's marker takes one column past listWidth.
+					sections = append(sections, joinPanes(m.renderProductList(), m.renderPreviewPane(), m.listWidth()+1, m.listHeight()))
+				} else {
+					sections = append(sections, m.renderProductList())
+				}
 			}
 		case DetailView:
 			sections = append(sections, m.viewport.View())
 		}
 	}
 
-	if m.err != nil {
+	if m.err != nil && !m.searchBlocked() {
 		sections = append(sections, ErrorStyle.Render("Error: "+m.err.Error()))
 	} else {
 		sections = append(sections, StatusBarStyle.Render(m.statusMsg))
 	}
 
-	sections = append(sections, m.help.View(m.keys))
+	if !m.focusMode {
+		sections = append(sections, m.help.View(m.keys))
+	}
 
 	return strings.Join(sections, "\n")
 }
 
 // renderTabBar builds the period tab bar (line 1) and date selector bar (line 2).
 func (m Model) renderTabBar() string {
-	// Line 1: period tabs + Categories
+	// Line 1: period tabs + Categories + Upcoming
 	type tabDef struct {
 		label      string
 		period     types.Period
 		isCategory bool
+		isUpcoming bool
 	}
 	tabs := []tabDef{
-		{"Daily", types.Daily, false},
-		{"Weekly", types.Weekly, false},
-		{"Monthly", types.Monthly, false},
-		{"Categories", 0, true},
+		{"Daily", types.Daily, false, false},
+		{"Weekly", types.Weekly, false, false},
+		{"Monthly", types.Monthly, false, false},
+		{"Categories", 0, true, false},
+		{"Upcoming", 0, false, true},
 	}
 
 	var parts []string
@@ -957,13 +1613,22 @@ func (m Model) renderTabBar() string {
 	x := 0
 	for _, t := range tabs {
 		rendered := lipgloss.Width(t.label) + 2 // Padding(0,1) = 1 left + 1 right
-		tabRegs = append(tabRegs, tabRegion{xStart: x, xEnd: x + rendered, period: t.period, isCategory: t.isCategory})
+		if t.isCategory && !categoriesAvailable() {
+			// Disabled: dimmed and not clickable
+			parts = append(parts, InactiveTabStyle.Faint(true).Render(t.label))
+			x += rendered
+			continue
+		}
+		tabRegs = append(tabRegs, tabRegion{xStart: x, xEnd: x + rendered, period: t.period, isCategory: t.isCategory, isUpcoming: t.isUpcoming})
 
 		isActive := false
-		if t.isCategory {
+		switch {
+		case t.isCategory:
 			isActive = m.categoryMode || m.categorySelectMode
-		} else {
-			isActive = !m.categoryMode && !m.categorySelectMode && t.period == m.period
+		case t.isUpcoming:
+			isActive = m.upcomingMode && !m.categorySelectMode
+		default:
+			isActive = !m.categoryMode && !m.categorySelectMode && !m.upcomingMode && !m.combinedMode && t.period == m.period
 		}
 
 		if isActive {
@@ -974,27 +1639,20 @@ func (m Model) renderTabBar() string {
 		x += rendered
 	}
 	line1 := strings.Join(parts, "")
-	lastTabBarRegions = tabRegs
 
 	// In split pane mode, skip the date bar to maximize content space
 	if m.categorySelectMode {
-		lastDateBarRegions = nil
+		m.lastBarRegions.set(tabRegs, nil)
 		return line1
 	}
 
 	// Line 2: date selector bar
-	line2, regions := m.buildDateBar()
-	lastDateBarRegions = regions
+	line2, dateRegs := m.buildDateBar()
+	m.lastBarRegions.set(tabRegs, dateRegs)
 
 	return line1 + "\n" + line2
 }
 
-// lastTabBarRegions stores click regions from the last render (single-threaded TUI).
-var lastTabBarRegions []tabRegion
-
-// lastDateBarRegions stores click regions from the last render (single-threaded TUI).
-var lastDateBarRegions []dateRegion
-
 // buildDateBar builds the date selector bar and returns the rendered string and click regions.
 func (m Model) buildDateBar() (string, []dateRegion) {
 	if m.categorySelectMode {
@@ -1006,6 +1664,12 @@ func (m Model) buildDateBar() (string, []dateRegion) {
 	if m.categoryMode {
 		return m.buildCategoryDateBar()
 	}
+	if m.upcomingMode {
+		return m.buildUpcomingDateBar()
+	}
+	if m.combinedMode {
+		return m.buildDailyDateBar()
+	}
 
 	switch m.period {
 	case types.Daily:
@@ -1068,6 +1732,13 @@ func (m Model) buildCategorySelectDateBar() (string, []dateRegion) {
 	return b.String(), nil
 }
 
+func (m Model) buildUpcomingDateBar() (string, []dateRegion) {
+	var b strings.Builder
+	b.WriteString(DateItemActiveStyle.Render(" Coming soon "))
+	b.WriteString(DateItemStyle.Render(fmt.Sprintf(" (%d upcoming products) ", len(m.products))))
+	return b.String(), nil
+}
+
 func (m Model) buildCategoryDateBar() (string, []dateRegion) {
 	var regions []dateRegion
 	var b strings.Builder
@@ -1216,7 +1887,6 @@ func (m Model) buildWeeklyDateBar() (string, []dateRegion) {
 
 	year, month, _ := m.date.Date()
 	loc := m.date.Location()
-	_, currentWeek := m.date.ISOWeek()
 
 	// Find weeks that overlap with this month
 	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, loc)
@@ -1231,7 +1901,6 @@ func (m Model) buildWeeklyDateBar() (string, []dateRegion) {
 	today := time.Now()
 	for ws := weekStart; !ws.After(lastOfMonth); ws = ws.AddDate(0, 0, 7) {
 		we := ws.AddDate(0, 0, 6) // week end (Sunday)
-		_, thisWeek := ws.ISOWeek()
 
 		// Format: "M/D-D" or "M/D-M/D" if crossing month boundary
 		var label string
@@ -1244,7 +1913,7 @@ func (m Model) buildWeeklyDateBar() (string, []dateRegion) {
 
 		isFuture := ws.After(today)
 		var styled string
-		if thisWeek == currentWeek {
+		if sameISOWeek(ws, m.date) {
 			styled = DateItemActiveStyle.Render(padded)
 		} else if isFuture {
 			styled = DateItemDimStyle.Render(padded)
@@ -1255,7 +1924,13 @@ func (m Model) buildWeeklyDateBar() (string, []dateRegion) {
 
 		cellWidth := lipgloss.Width(padded)
 		if !isFuture {
-			regions = append(regions, dateRegion{xStart: x, xEnd: x + cellWidth, action: "goto", date: ws})
+			// A week starting last month is opened from this month's first
+			// day, which is in the same ISO week, so the bar stays put.
+			target := ws
+			if target.Before(firstOfMonth) {
+				target = firstOfMonth
+			}
+			regions = append(regions, dateRegion{xStart: x, xEnd: x + cellWidth, action: "goto", date: target})
 		}
 		x += cellWidth
 	}
@@ -1268,6 +1943,16 @@ func (m Model) buildWeeklyDateBar() (string, []dateRegion) {
 	return b.String(), regions
 }
 
+// sameISOWeek reports whether a and b fall in the same ISO week. It compares
+// (year, week) pairs: around New Year a week number alone is ambiguous, since
+// a January date can be in the previous ISO year's last week and a December
+// date in the next year's first.
+func sameISOWeek(a, b time.Time) bool {
+	ay, aw := a.ISOWeek()
+	by, bw := b.ISOWeek()
+	return ay == by && aw == bw
+}
+
 func (m Model) buildMonthlyDateBar() (string, []dateRegion) {
 	var regions []dateRegion
 	var b strings.Builder
@@ -1297,8 +1982,8 @@ func (m Model) formatDate() string {
 	case types.Daily:
 		return m.date.Format("January 2, 2006")
 	case types.Weekly:
-		_, week := m.date.ISOWeek()
-		return fmt.Sprintf("Week %d, %d", week, m.date.Year())
+		year, week := m.date.ISOWeek()
+		return fmt.Sprintf("Week %d, %d", week, year)
 	case types.Monthly:
 		return m.date.Format("January 2006")
 	default:
@@ -1342,7 +2027,7 @@ func (m Model) handleDateBarClick(r dateRegion) (tea.Model, tea.Cmd) {
 		m.loading = true
 		m.statusMsg = "Loading search page..."
 		m.requestID++
-		return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.source, m.searchQuery, targetPage, m.requestID))
+		return m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, m.searchQuery, targetPage, m.requestID))
 	}
 
 	// Handle category navigation actions
@@ -1388,7 +2073,7 @@ func (m Model) handleDateBarClick(r dateRegion) (tea.Model, tea.Cmd) {
 		m.loading = true
 		m.statusMsg = "Loading category..."
 		m.requestID++
-		return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.source, slug, m.requestID))
+		return m, tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.requestCtx(), m.source, slug, m.requestID))
 	}
 
 	switch r.action {
@@ -1416,7 +2101,7 @@ func (m Model) handleDateBarClick(r dateRegion) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	m.requestID++
-	return m, tea.Batch(m.spinner.Tick, fetchLeaderboard(m.source, m.period, m.date, m.requestID))
+	return m, tea.Batch(m.spinner.Tick, m.fetchLeaderboardView())
 }
 
 func (m Model) searchStatus() string {
@@ -1428,15 +2113,133 @@ func (m Model) searchStatus() string {
 		if page <= 0 {
 			page = 1
 		}
+		sortInfo := ""
+		if m.searchSort != "" && m.searchSort != types.SortRelevance {
+			sortInfo = " • sort: " + string(m.searchSort)
+		}
+		if m.searchQuery == "" {
+			return fmt.Sprintf("Trending today • %d results%s", len(m.products), sortInfo)
+		}
 		pages := m.searchPages
 		if pages > 0 {
-			return fmt.Sprintf("Search \"%s\" • page %d/%d • %d results", m.searchQuery, page, pages, len(m.products))
+			return fmt.Sprintf("Search \"%s\" • page %d/%d • %d results%s", m.searchQuery, page, pages, len(m.products), sortInfo)
 		}
-		return fmt.Sprintf("Search \"%s\" • page %d • %d results", m.searchQuery, page, len(m.products))
+		return fmt.Sprintf("Search \"%s\" • page %d • %d results%s", m.searchQuery, page, len(m.products), sortInfo)
 	}
 	return fmt.Sprintf("%d products", len(m.products))
 }
 
+// applyPricingFilter rebuilds products from baseProducts using the review
+// filter (category results only) and pricingFilter, then applies searchSort
+// to search results. Products with unknown pricing are hidden while a
+// pricing filter is active.
+func (m *Model) applyPricingFilter() {
+	m.selected = 0
+	products, hidden := m.baseProducts, 0
+	if m.categoryMode {
+		products, hidden = types.FilterByMinReviews(products, m.minReviews)
+	}
+	products, featuredHidden := m.featuredResults(products)
+	if m.pricingFilter == "" {
+		m.products = m.sortedSearchResults(products)
+		m.statusMsg = m.searchStatus()
+	} else {
+		filtered := make([]types.Product, 0, len(products))
+		unchecked := 0
+		for _, p := range products {
+			pricing, ok := m.pricingBySlug[p.Slug()]
+			if !ok {
+				unchecked++
+			}
+			if pricing == m.pricingFilter {
+				filtered = append(filtered, p)
+			}
+		}
+		m.products = m.sortedSearchResults(filtered)
+		m.statusMsg = fmt.Sprintf("%d of %d products • pricing: %s (p to change)", len(m.products), len(m.baseProducts), m.pricingFilter)
+		if unchecked > 0 {
+			m.statusMsg += fmt.Sprintf(" • %d not checked yet", unchecked)
+		}
+	}
+	if m.categoryMode && m.minReviews > 0 {
+		m.statusMsg += " • " + reviewFilterStatus(m.minReviews, hidden)
+	}
+	if m.searchResults && m.featuredOnly {
+		m.statusMsg += " • " + featuredFilterStatus(featuredHidden)
+	}
+}
+
+// loadPricingFilter applies pricingFilter, first fetching the pricing of
+// products not yet looked up.
+func (m *Model) loadPricingFilter() tea.Cmd {
+	if m.pricingFilter == "" || m.source == nil {
+		m.applyPricingFilter()
+		return nil
+	}
+	var missing []types.Product
+	for _, p := range m.baseProducts {
+		if _, ok := m.pricingBySlug[p.Slug()]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		m.applyPricingFilter()
+		return nil
+	}
+	missing = missing[:min(len(missing), maxPricingLookups)]
+	m.loading = true
+	m.statusMsg = "Loading pricing..."
+	m.requestID++
+	return tea.Batch(m.spinner.Tick, fetchPricingTypes(m.requestCtx(), m.source, missing, m.requestID))
+}
+
+// sortedSearchResults orders products by searchSort when showing search results.
+func (m Model) sortedSearchResults(products []types.Product) []types.Product {
+	if !m.searchResults {
+		return products
+	}
+	return types.SortProducts(products, m.searchSort)
+}
+
+// pageURL returns the Product Hunt URL the current view was fetched from.
+func (m Model) pageURL() string {
+	switch {
+	case m.state == DetailView:
+		if slug := m.detail.Product().Slug(); slug != "" {
+			return scraper.ProductURL(slug)
+		}
+		return ""
+	case m.categorySelectMode:
+		if m.splitSlug != "" {
+			return scraper.CategoryURL(m.splitSlug)
+		}
+		return ""
+	case m.searchResults && m.searchQuery == "":
+		return scraper.LeaderboardURL(types.Daily, types.Today())
+	case m.searchResults:
+		return scraper.SearchURL(m.searchQuery, m.searchPage)
+	case m.categoryMode:
+		return scraper.CategoryURL(m.categorySlug)
+	case m.upcomingMode:
+		return scraper.UpcomingURL()
+	default:
+		return scraper.LeaderboardURL(m.period, m.date)
+	}
+}
+
+// copyPageURL copies pageURL to the clipboard and reports it in the status bar.
+func (m *Model) copyPageURL() {
+	url := m.pageURL()
+	if url == "" {
+		return
+	}
+	if err := copyToClipboard(url); err != nil {
+		m.statusMsg = "Copy failed: " + err.Error()
+		return
+	}
+	m.statusMsg = "Copied " + url
+}
+
 func (m Model) selectedProduct() (types.Product, bool) {
 	if len(m.products) == 0 {
 		return types.Product{}, false
@@ -1448,10 +2251,7 @@ func (m Model) selectedProduct() (types.Product, bool) {
 }
 
 func (m Model) renderProductList() string {
-	available := m.height - 4 // tab + status + help
-	if available < 1 {
-		available = 1
-	}
+	available := m.listHeight()
 
 	itemHeight := 3
 	visibleCount := available / itemHeight
@@ -1474,7 +2274,7 @@ func (m Model) renderProductList() string {
 
 	var b strings.Builder
 	for i := start; i < end; i++ {
-		b.WriteString(renderProductItem(m.products[i], i == m.selected, m.width))
+		b.WriteString(renderProductItem(m.products[i], i == m.selected, m.listWidth(), m.changeBadge(m.products[i]), m.sparklines[m.products[i].Slug()], m.isTrending(m.products[i]), m.nameColumn, m.format))
 		if i < end-1 {
 			b.WriteString("\n")
 		}
@@ -1483,31 +2283,79 @@ func (m Model) renderProductList() string {
 	return b.String()
 }
 
-func renderProductItem(product types.Product, isSelected bool, width int) string {
-	// Line 1: Rank + Name + Votes
+// offlineMarker flags products Product Hunt lists as no longer online.
+const offlineMarker = " (offline)"
+
+func renderProductItem(product types.Product, isSelected bool, width int, badge, spark string, trending bool, layout nameColumn, format textFormat) string {
+	// Line 1: Rank + Name + Votes (+ change-feed badge and trending marker
+	// after the rank, "(offline)" marker after the name, rank sparkline
+	// before the votes)
 	rankStr := fmt.Sprintf("#%-2d", product.Rank())
 	nameStr := product.Name()
-	voteDisplay := fmt.Sprintf("▲ %s", formatVoteCount(product.VoteCount()))
+	voteDisplay := fmt.Sprintf("▲ %s", format.count(product.VoteCount()))
+	sparkStr := ""
+	if spark != "" {
+		sparkStr = SparklineStyle.Render(spark) + " "
+	}
+	badgeStr := ""
+	if badge != "" {
+		badgeStr = changeBadgeStyle(badge).Render(badge) + " "
+	}
+	if trending {
+		badgeStr += trendingMarker + " "
+	}
+	offlineStr := ""
+	if product.Offline() {
+		offlineStr = offlineMarker
+	}
 
-	rankWidth := lipgloss.Width(rankStr)
+	rankWidth := lipgloss.Width(rankStr) + lipgloss.Width(badgeStr)
+	if badgeStr != "" && width-rankWidth < layout.minWidth {
+		badgeStr = "" // too narrow; the name matters more
+		rankWidth = lipgloss.Width(rankStr)
+	}
 	voteWidth := lipgloss.Width(voteDisplay) + 1
-	availableForName := width - rankWidth - voteWidth
-	if availableForName <= 1 {
-		availableForName = 0
+	if sparkStr != "" && width-rankWidth-voteWidth-lipgloss.Width(sparkStr) > 10 {
+		voteWidth += lipgloss.Width(sparkStr)
+	} else {
+		sparkStr = "" // too narrow; the name matters more
+	}
+	availableForName := layout.nameWidth(width, rankWidth, voteWidth)
+	if width-rankWidth-availableForName < voteWidth {
+		// The name kept its minimum; a cut-off vote count says nothing, so
+		// the name takes its room too
+		voteDisplay = ""
+		availableForName = layout.nameWidth(width, rankWidth, 1)
+	}
+	if offlineStr != "" && availableForName > lipgloss.Width(offlineStr)+1 {
+		nameStr = format.truncate(nameStr, availableForName-lipgloss.Width(offlineStr))
+	} else {
+		nameStr = format.truncate(nameStr, availableForName)
+		offlineStr = ""
+	}
+	gap := max(availableForName-lipgloss.Width(nameStr)-lipgloss.Width(offlineStr), 0)
+	if offlineStr != "" && !layout.alignRight {
+		// The marker takes the name's padding so votes stay aligned
+		offlineStr = layout.pad(offlineStr, gap)
+	} else {
+		nameStr = layout.pad(nameStr, gap)
+	}
+	offlineRendered := ""
+	if offlineStr != "" {
+		offlineRendered = OfflineMarkerStyle.Render(offlineStr)
 	}
-	nameStr = padOrTruncate(nameStr, availableForName)
 
 	var line1 string
 	if isSelected {
 		rankStyle := lipgloss.NewStyle().Foreground(DraculaCyan).Bold(true)
 		nameStyle := lipgloss.NewStyle().Foreground(DraculaPink).Bold(true)
-		voteStyle := lipgloss.NewStyle().Foreground(DraculaGreen).Bold(true)
-		line1 = lipgloss.JoinHorizontal(lipgloss.Left, rankStyle.Render(rankStr), nameStyle.Render(nameStr), voteStyle.Render(voteDisplay))
+		voteStyle := VoteHeat.Style(float64(product.VoteCount())).Bold(true)
+		line1 = lipgloss.JoinHorizontal(lipgloss.Left, rankStyle.Render(rankStr), badgeStr, nameStyle.Render(nameStr), offlineRendered, sparkStr, voteStyle.Render(voteDisplay))
 	} else {
 		rankStyle := lipgloss.NewStyle().Foreground(DraculaComment)
 		nameStyle := lipgloss.NewStyle().Foreground(DraculaCyan)
-		voteStyle := lipgloss.NewStyle().Foreground(DraculaGreen)
-		line1 = lipgloss.JoinHorizontal(lipgloss.Left, rankStyle.Render(rankStr), nameStyle.Render(nameStr), voteStyle.Render(voteDisplay))
+		voteStyle := VoteHeat.Style(float64(product.VoteCount()))
+		line1 = lipgloss.JoinHorizontal(lipgloss.Left, rankStyle.Render(rankStr), badgeStr, nameStyle.Render(nameStr), offlineRendered, sparkStr, voteStyle.Render(voteDisplay))
 	}
 
 	// Line 2: Tagline
@@ -1517,7 +2365,7 @@ func renderProductItem(product types.Product, isSelected bool, width int) string
 	if taglineAvailable < 0 {
 		taglineAvailable = 0
 	}
-	tagline = truncateToWidth(tagline, taglineAvailable)
+	tagline = format.truncate(tagline, taglineAvailable)
 	line2 := taglineIndent + lipgloss.NewStyle().Foreground(DraculaForeground).Render(tagline)
 
 	// Line 3: Categories
@@ -1527,7 +2375,7 @@ func renderProductItem(product types.Product, isSelected bool, width int) string
 	if categoryAvailable < 0 {
 		categoryAvailable = 0
 	}
-	categoryStr = truncateToWidth(categoryStr, categoryAvailable)
+	categoryStr = format.truncate(categoryStr, categoryAvailable)
 	line3 := categoryIndent + lipgloss.NewStyle().Foreground(DraculaComment).Render(categoryStr)
 
 	output := line1 + "\n" + line2 + "\n" + line3
@@ -1537,25 +2385,83 @@ func renderProductItem(product types.Product, isSelected bool, width int) string
 	return output
 }
 
-// renderDetailContent formats ProductDetail for the viewport
-func (m Model) renderDetailContent() string {
-	d := m.detail
+// detailSection identifies a jump target in the detail view.
+type detailSection int
+
+const (
+	sectionMakerComment detailSection = iota
+	sectionPros
+	sectionCons
+)
+
+func (s detailSection) String() string {
+	switch s {
+	case sectionPros:
+		return "Pros"
+	case sectionCons:
+		return "Cons"
+	default:
+		return "Maker Comment"
+	}
+}
+
+// jumpToSection scrolls the detail viewport so section's header is on top.
+func (m *Model) jumpToSection(section detailSection) {
+	line, ok := m.detailAnchors[section]
+	if !ok {
+		m.statusMsg = fmt.Sprintf("No %s section", section)
+		return
+	}
+	m.viewport.SetYOffset(line)
+	m.statusMsg = section.String()
+}
+
+// renderDetailContent formats ProductDetail for the viewport. It also returns
+// the line offset of each section header it rendered, for jumpToSection.
+func (m Model) renderDetailContent() (string, map[detailSection]int) {
+	return renderDetail(m.detail, m.format)
+}
+
+// renderDetail formats d as the detail view shows it; the preview pane
+// reuses it at a narrower width.
+func renderDetail(d types.ProductDetail, format textFormat) (string, map[detailSection]int) {
 	p := d.Product()
 
 	var b strings.Builder
+	anchors := make(map[detailSection]int)
+	// anchor records that the next line written starts section.
+	anchor := func(section detailSection) {
+		anchors[section] = strings.Count(b.String(), "\n")
+	}
 
 	b.WriteString(DetailTitleStyle.Render(p.Name()))
 	b.WriteString("\n")
 	b.WriteString(DetailTaglineStyle.Render(p.Tagline()))
 	b.WriteString("\n\n")
 
-	stats := fmt.Sprintf("⭐ %.1f (%d reviews) • %s followers",
-		d.Rating(), d.ReviewCount(), formatVoteCount(d.FollowerCount()))
+	rating := RatingHeat.Style(d.Rating()).Render(fmt.Sprintf("%.1f", d.Rating()))
+	stats := fmt.Sprintf("⭐ %s (%d reviews) • ", rating, d.ReviewCount())
+	if d.VoteCount() > 0 {
+		stats += fmt.Sprintf("▲ %s upvotes • ", format.count(d.VoteCount()))
+	}
+	if d.CommentCount() > 0 {
+		stats += fmt.Sprintf("%s comments • ", format.count(d.CommentCount()))
+	}
+	stats += format.count(d.FollowerCount()) + " followers"
 	b.WriteString(stats)
 	b.WriteString("\n")
+	if d.HasRatingDistribution() {
+		b.WriteString(renderRatingDistribution(d.RatingDistribution()))
+	}
 
-	if !d.LaunchDate().IsZero() {
-		b.WriteString(fmt.Sprintf("🚀 Launched: %s\n", d.LaunchDate().Format("January 2, 2006")))
+	if first := d.FirstLaunchDate(); !first.IsZero() {
+		launched := first.Format("January 2, 2006")
+		latest := d.LatestLaunchDate()
+		if latestStr := latest.Format("January 2, 2006"); !latest.IsZero() && latestStr != launched {
+			b.WriteString(fmt.Sprintf("🚀 First launched: %s • latest: %s\n", launched, latestStr))
+		} else {
+			b.WriteString(fmt.Sprintf("🚀 Launched: %s\n", launched))
+		}
 	}
 
 	if d.MakerName() != "" {
@@ -1574,6 +2480,14 @@ func (m Model) renderDetailContent() string {
 		b.WriteString(fmt.Sprintf("🌐 %s\n", d.WebsiteURL()))
 	}
 
+	for _, link := range d.Links()[types.LinkGitHub] {
+		b.WriteString(fmt.Sprintf("🐙 %s\n", link))
+	}
+
+	for _, link := range d.Links()[types.LinkDiscord] {
+		b.WriteString(fmt.Sprintf("💬 %s\n", link))
+	}
+
 	b.WriteString("\n")
 
 	if d.Description() != "" {
@@ -1582,7 +2496,9 @@ func (m Model) renderDetailContent() string {
 	}
 
 	if d.MakerComment() != "" {
-		b.WriteString("\n--- Maker Comment ---\n")
+		b.WriteString("\n")
+		anchor(sectionMakerComment)
+		b.WriteString("--- Maker Comment ---\n")
 		b.WriteString(d.MakerComment())
 		b.WriteString("\n")
 	}
@@ -1601,13 +2517,17 @@ func (m Model) renderDetailContent() string {
 			}
 		}
 		if len(pros) > 0 {
-			b.WriteString("\n👍 Pros:\n")
+			b.WriteString("\n")
+			anchor(sectionPros)
+			b.WriteString("👍 Pros:\n")
 			for _, p := range pros {
 				b.WriteString("  + " + p + "\n")
 			}
 		}
 		if len(cons) > 0 {
-			b.WriteString("\n👎 Cons:\n")
+			b.WriteString("\n")
+			anchor(sectionCons)
+			b.WriteString("👎 Cons:\n")
 			for _, c := range cons {
 				b.WriteString("  - " + c + "\n")
 			}
@@ -1620,6 +2540,24 @@ func (m Model) renderDetailContent() string {
 		}
 	}
 
+	if related := d.RelatedLaunches(); len(related) > 0 {
+		b.WriteString("\n🧰 Makers also launched:\n")
+		for _, r := range related {
+			line := "  • " + DetailTitleStyle.Render(r.Name())
+			if r.Tagline() != "" {
+				line += " — " + r.Tagline()
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if gallery := d.GalleryImages(); len(gallery) > 0 {
+		b.WriteString("\n📸 Gallery:\n")
+		for _, image := range gallery {
+			b.WriteString("  • " + image + "\n")
+		}
+	}
+
 	if len(d.Categories()) > 0 {
 		catStyle := lipgloss.NewStyle().Foreground(DraculaCyan).Underline(true)
 		b.WriteString("\nCategories: ")
@@ -1642,24 +2580,135 @@ func (m Model) renderDetailContent() string {
 		}
 	}
 
+	return b.String(), anchors
+}
+
+// ratingBarWidth is the length of the longest bar in the review histogram.
+const ratingBarWidth = 20
+
+// renderRatingDistribution draws review counts per star as a bar chart,
+// five stars first, with bars scaled to the most common rating:
+//
+//	5★ ████████████████████ 12
+//	4★ ██████████ 6
+func renderRatingDistribution(dist [5]int) string {
+	most := slices.Max(dist[:])
+	var b strings.Builder
+	for stars := 5; stars >= 1; stars-- {
+		count := dist[stars-1]
+		width := count * ratingBarWidth / most
+		if count > 0 && width == 0 {
+			width = 1
+		}
+		bar := RatingHeat.Style(float64(stars)).Render(strings.Repeat("█", width))
+		fmt.Fprintf(&b, "  %d★ %s %d\n", stars, bar, count)
+	}
 	return b.String()
 }
 
 // resizePanes adjusts dimensions of list and viewport based on window size
+// listChrome is the number of lines around the product list: tab bar (1) +
+// date bar (1) + context header (1) + status bar (1) + help (1).
+const listChrome = 5
+
+// focusChrome is the number of lines around the product list in focus
+// mode: just the status bar.
+const focusChrome = 1
+
+// contentHeight is the number of lines left for a view's content when
+// chrome lines of tabs, bars and help surround it; in focus mode only the
+// status bar does.
+func (m Model) contentHeight(chrome int) int {
+	if m.focusMode {
+		chrome = focusChrome
+	}
+	if h := m.height - chrome; h > 0 {
+		return h
+	}
+	return 1
+}
+
+// listHeight is the number of lines left for the product list.
+func (m Model) listHeight() int {
+	return m.contentHeight(listChrome)
+}
+
+// contextHeader describes what the list is showing and which row is
+// selected, e.g. "Daily • February 18, 2026 • selected #12 of 30". It sits
+// above the list so the context stays visible on long scrolls.
+func (m Model) contextHeader() string {
+	parts := m.listContext()
+	if m.selected >= 0 && m.selected < len(m.products) {
+		parts = append(parts, fmt.Sprintf("selected #%d of %d", m.selected+1, len(m.products)))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// listContext names the listing on screen, e.g. ["Daily", "February 18,
+// 2026"], followed by the pricing filter when one is set.
+func (m Model) listContext() []string {
+	var parts []string
+	switch {
+	case m.searchResults && m.searchQuery == "":
+		parts = append(parts, "Trending today")
+	case m.searchResults:
+		page := m.searchPage
+		if page <= 0 {
+			page = 1
+		}
+		parts = append(parts, fmt.Sprintf("Search \"%s\"", m.searchQuery), fmt.Sprintf("page %d", page))
+	case m.upcomingMode:
+		parts = append(parts, "Upcoming")
+	case m.combinedMode:
+		parts = append(parts, "Combined", m.date.Format("January 2, 2006"))
+	case m.compareMode:
+		parts = append(parts, m.periodDisplayName()+" compare")
+	case m.categoryMode:
+		parts = append(parts, "Category: "+m.categoryName)
+	default:
+		parts = append(parts, m.periodDisplayName(), m.formatDate())
+	}
+	if m.pricingFilter != "" {
+		parts = append(parts, m.pricingFilter)
+	}
+	if m.categoryMode && m.minReviews > 0 {
+		parts = append(parts, fmt.Sprintf("%d+ reviews", m.minReviews))
+	}
+	return parts
+}
+
+// toggleFocus hides or shows the chrome around the list and resizes the
+// panes to match.
+func (m *Model) toggleFocus() {
+	m.focusMode = !m.focusMode
+	m.resizePanes()
+	if m.focusMode {
+		m.statusMsg = "Focus mode: f to show tabs and help"
+	} else {
+		m.statusMsg = "Focus mode off"
+	}
+}
+
 func (m *Model) resizePanes() {
 	if m.width == 0 {
 		return
 	}
 
-	// Chrome: tab bar (1) + date bar (1) + status bar (1) + help (1) = 4
-	chrome := 4
+	chrome := listChrome
+	if m.focusMode {
+		chrome = focusChrome
+	}
 	listHeight := m.height - chrome
 	if listHeight < 0 {
 		listHeight = 0
 	}
 
-	// Detail view has no tab bar — gets 1 extra line
-	detailHeight := listHeight + 1
+	// Detail view has no tab bar or context header — gets 2 extra lines,
+	// unless focus mode has already hidden them.
+	detailHeight := listHeight
+	if !m.focusMode {
+		detailHeight += 2
+	}
 	if detailHeight > m.height {
 		detailHeight = m.height
 	}
@@ -1669,10 +2718,10 @@ func (m *Model) resizePanes() {
 	m.viewport.Height = detailHeight
 }
 
-// truncateToWidth truncates a string to fit within maxWidth display columns,
-// appending "…" if truncated. Uses rune-aware iteration to avoid cutting
-// multibyte characters (Korean, Japanese, emoji, etc.) mid-sequence.
-func truncateToWidth(s string, maxWidth int) string {
+// truncate truncates a string to fit within maxWidth display columns,
+// appending f.marker if truncated. Uses rune-aware iteration to avoid
+// cutting multibyte characters (Korean, Japanese, emoji, etc.) mid-sequence.
+func (f textFormat) truncate(s string, maxWidth int) string {
 	if maxWidth <= 0 {
 		return ""
 	}
@@ -1680,33 +2729,44 @@ func truncateToWidth(s string, maxWidth int) string {
 	if w <= maxWidth {
 		return s
 	}
-	// Need to truncate — reserve 1 column for "…"
-	target := maxWidth - 1
+	// Need to truncate — reserve the marker's columns; when it doesn't fit
+	// either, show as much of the marker as does.
+	marker := f.marker
+	if marker == "" {
+		marker = defaultTruncationMarker
+	}
+	markerWidth := lipgloss.Width(marker)
+	target := maxWidth - markerWidth
 	if target <= 0 {
-		return "…"
+		return takeWidth(marker, maxWidth)
 	}
+	return takeWidth(s, target) + marker
+}
+
+// takeWidth returns the longest prefix of s that fits within maxWidth
+// display columns.
+func takeWidth(s string, maxWidth int) string {
 	var result strings.Builder
 	currentWidth := 0
 	for _, r := range s {
 		rw := lipgloss.Width(string(r))
-		if currentWidth+rw > target {
+		if currentWidth+rw > maxWidth {
 			break
 		}
 		result.WriteRune(r)
 		currentWidth += rw
 	}
-	result.WriteString("…")
 	return result.String()
 }
 
 // padOrTruncate pads the string with spaces to exactly targetWidth display columns,
-// or truncates with "…" if it exceeds targetWidth.
-func padOrTruncate(s string, targetWidth int) string {
-	w := lipgloss.Width(s)
-	if w > targetWidth {
-		return truncateToWidth(s, targetWidth)
+// or truncates with f.marker if it exceeds targetWidth.
+func (f textFormat) padOrTruncate(s string, targetWidth int) string {
+	if lipgloss.Width(s) > targetWidth {
+		// A wide rune at the cut can leave the result a column short.
+		s = f.truncate(s, targetWidth)
 	}
-	if w < targetWidth {
+	if w := lipgloss.Width(s); w < targetWidth {
 		return s + strings.Repeat(" ", targetWidth-w)
 	}
 	return s
@@ -1715,10 +2775,19 @@ func padOrTruncate(s string, targetWidth int) string {
 // switchToLeaderboard resets category/split-pane state and fetches the leaderboard for the given period.
 func (m *Model) switchToLeaderboard(period types.Period) (tea.Model, tea.Cmd) {
 	m.categoryMode = false
+	m.upcomingMode = false
 	m.categorySelectMode = false
 	m.splitLoading = false
 	m.splitRequestID = 0
+	m.combinedMode = false
+	m.compareMode = false
+	if period != m.period {
+		m.clearTrending()
+	}
 	m.period = period
+	if m.resetDateOnSwitch {
+		m.date = types.Today()
+	}
 	m.state = ListView
 	m.loading = true
 	m.statusMsg = "Loading..."
@@ -1726,7 +2795,23 @@ func (m *Model) switchToLeaderboard(period types.Period) (tea.Model, tea.Cmd) {
 		return *m, nil
 	}
 	m.requestID++
-	return *m, tea.Batch(m.spinner.Tick, fetchLeaderboard(m.source, m.period, m.date, m.requestID))
+	return *m, tea.Batch(m.spinner.Tick, fetchLeaderboard(m.requestCtx(), m.source, m.period, m.date, m.requestID))
+}
+
+// switchToUpcoming resets category/split-pane state and fetches the coming-soon listing.
+func (m *Model) switchToUpcoming() (tea.Model, tea.Cmd) {
+	m.categoryMode = false
+	m.categorySelectMode = false
+	m.splitLoading = false
+	m.splitRequestID = 0
+	m.state = ListView
+	m.loading = true
+	m.statusMsg = "Loading upcoming..."
+	if m.source == nil {
+		return *m, nil
+	}
+	m.requestID++
+	return *m, tea.Batch(m.spinner.Tick, fetchUpcoming(m.requestCtx(), m.source, m.requestID))
 }
 
 // slugToDisplayName converts a category slug like "ai-agents" to "AI Agents".
@@ -1744,6 +2829,10 @@ func slugToDisplayName(slug string) string {
 
 // enterCategorySelectMode switches to the split pane mode and returns a Cmd to load the initial category.
 func (m *Model) enterCategorySelectMode() tea.Cmd {
+	if !categoriesAvailable() {
+		m.statusMsg = noCategoriesMsg
+		return nil
+	}
 	m.categorySelectMode = true
 	m.catFilterMode = false
 	m.catFilterQuery = ""
@@ -1754,45 +2843,80 @@ func (m *Model) enterCategorySelectMode() tea.Cmd {
 	m.splitLoading = false
 	m.splitSlug = ""
 	m.splitRequestID = 0
-	// If we were viewing a category, position cursor there
+	// If we were viewing a category, position cursor there; otherwise open
+	// on the configured default, or the first category if it is unknown.
 	if m.categorySlug != "" {
 		idx := types.CategoryIndexBySlug(m.categorySlug)
 		if idx >= 0 {
 			m.catSelectIdx = idx
 		}
+	} else if m.initialCategory != "" {
+		m.catSelectIdx = max(types.CategoryIndexBySlug(m.initialCategory), 0)
 	}
 	m.statusMsg = fmt.Sprintf("Select a category (%d categories)", len(types.AllCategories))
 	// Trigger initial load for the selected category
 	return m.loadSelectedCategory()
 }
 
-// loadSelectedCategory triggers a fetch for the currently selected category in the left pane.
-func (m *Model) loadSelectedCategory() tea.Cmd {
+// selectedCategorySlug returns the slug under the left-pane cursor.
+func (m *Model) selectedCategorySlug() (string, bool) {
 	visible := m.catVisibleList()
 	if len(visible) == 0 || m.catSelectIdx >= len(visible) || m.source == nil {
+		return "", false
+	}
+	return types.AllCategories[visible[m.catSelectIdx]].Slug(), true
+}
+
+// loadSelectedCategory triggers a fetch for the currently selected category in the left pane.
+func (m *Model) loadSelectedCategory() tea.Cmd {
+	slug, ok := m.selectedCategorySlug()
+	if !ok {
 		return nil
 	}
-	catIdx := visible[m.catSelectIdx]
-	slug := types.AllCategories[catIdx].Slug()
 	if slug == m.splitSlug {
 		return nil // already loaded
 	}
 	m.splitLoading = true
 	m.requestID++
 	m.splitRequestID = m.requestID
-	return tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.source, slug, m.requestID))
+	return tea.Batch(m.spinner.Tick, fetchCategoryProducts(m.splitRequests.forRequest(m.splitRequestID), m.source, slug, m.requestID))
+}
+
+// debounceSelectedCategory is loadSelectedCategory for cursor movement: the
+// fetch waits until the cursor has rested for categoryDebounce, so arrowing
+// through the picker only loads the category it stops on. Each move takes a
+// new splitRequestID, which drops the pending pauses and in-flight fetches
+// of the categories passed over.
+func (m *Model) debounceSelectedCategory() tea.Cmd {
+	slug, ok := m.selectedCategorySlug()
+	if !ok {
+		return nil
+	}
+	m.requestID++
+	m.splitRequestID = m.requestID
+	if slug == m.splitSlug {
+		m.splitLoading = false // back on the category already shown
+		return nil
+	}
+	m.splitLoading = true
+	id := m.requestID
+	return tea.Tick(categoryDebounce, func(time.Time) tea.Msg {
+		return categoryDebounceMsg{requestID: id, slug: slug}
+	})
 }
 
 // catVisibleList returns the list of AllCategories indices to show.
 // allCategoryIndices is a pre-computed slice [0, 1, 2, ..., len(AllCategories)-1]
 // to avoid allocating a new slice on every catVisibleList call.
-var allCategoryIndices = func() []int {
-	indices := make([]int, len(types.AllCategories))
+var allCategoryIndices = categoryIndices(len(types.AllCategories))
+
+func categoryIndices(n int) []int {
+	indices := make([]int, n)
 	for i := range indices {
 		indices[i] = i
 	}
 	return indices
-}()
+}
 
 // catVisibleList returns the list of AllCategories indices to show.
 // When filtering, returns only matching indices; otherwise all indices.
@@ -1803,9 +2927,69 @@ func (m Model) catVisibleList() []int {
 		}
 		return nil
 	}
+	if len(allCategoryIndices) != len(types.AllCategories) {
+		// AllCategories changed since init; don't index past its end.
+		return categoryIndices(len(types.AllCategories))
+	}
 	return allCategoryIndices
 }
 
+// applyCategoryIndex merges a freshly fetched category list into
+// AllCategories for the rest of the session, keeping the cursor on the
+// category it was on.
+func (m *Model) applyCategoryIndex(fetched []types.CategoryLink) {
+	added := m.setAllCategories(types.MergeCategories(types.AllCategories, fetched))
+	m.statusMsg = fmt.Sprintf("Reloaded categories: %d total (%d new)", len(types.AllCategories), added)
+}
+
+// mergeRelatedCategories adds related categories discovered while browsing
+// to AllCategories for the session. Only unseen slugs are added; known
+// categories keep their names. It returns how many were added.
+func (m *Model) mergeRelatedCategories(related []types.CategoryLink) int {
+	var novel []types.CategoryLink
+	for _, c := range related {
+		if types.CategoryIndexBySlug(c.Slug()) < 0 {
+			novel = append(novel, c)
+		}
+	}
+	if len(novel) == 0 {
+		return 0
+	}
+	return m.setAllCategories(types.MergeCategories(types.AllCategories, novel))
+}
+
+// setAllCategories replaces AllCategories, rebuilding the picker's indices
+// and filter while keeping the left-pane cursor on the same category. It
+// returns how many categories were added.
+func (m *Model) setAllCategories(categories []types.CategoryLink) int {
+	selectedSlug := ""
+	if visible := m.catVisibleList(); m.catSelectIdx >= 0 && m.catSelectIdx < len(visible) {
+		selectedSlug = types.AllCategories[visible[m.catSelectIdx]].Slug()
+	}
+	before := len(types.AllCategories)
+	types.AllCategories = categories
+	allCategoryIndices = categoryIndices(len(types.AllCategories))
+	if m.catFilterQuery != "" {
+		m.updateCatFilter()
+	}
+	m.catSelectIdx = 0
+	for i, idx := range m.catVisibleList() {
+		if types.AllCategories[idx].Slug() == selectedSlug {
+			m.catSelectIdx = i
+			break
+		}
+	}
+	return len(types.AllCategories) - before
+}
+
+// noCategoriesMsg is shown when there are no categories to browse.
+const noCategoriesMsg = "No categories available"
+
+// categoriesAvailable reports whether there are any categories to browse.
+func categoriesAvailable() bool {
+	return len(types.AllCategories) > 0
+}
+
 // updateCatFilter updates the filtered category indices based on the query.
 func (m *Model) updateCatFilter() {
 	if m.catFilterQuery == "" {
@@ -1827,10 +3011,7 @@ func (m *Model) updateCatFilter() {
 
 // renderSplitPane renders the left (categories) + right (products) split layout.
 func (m Model) renderSplitPane() string {
-	available := m.height - 3 // tab + status + help (no date bar in split mode)
-	if available < 1 {
-		available = 1
-	}
+	available := m.contentHeight(3) // tab + status + help (no date bar in split mode)
 
 	// Calculate pane widths
 	leftWidth := m.width * 30 / 100
@@ -1848,15 +3029,19 @@ func (m Model) renderSplitPane() string {
 
 	leftContent := m.renderCategoryPane(leftWidth, available)
 	rightContent := m.renderProductPane(rightWidth, available)
+	return joinPanes(leftContent, rightContent, leftWidth, available)
+}
 
-	// Join panes line by line
+// joinPanes lays left and right side by side for height lines, padding the
+// left pane to leftWidth and separating them with a vertical rule.
+func joinPanes(leftContent, rightContent string, leftWidth, height int) string {
 	leftLines := strings.Split(leftContent, "\n")
 	rightLines := strings.Split(rightContent, "\n")
 
 	sepStyle := lipgloss.NewStyle().Foreground(DraculaComment)
 
 	var result strings.Builder
-	for i := 0; i < available; i++ {
+	for i := 0; i < height; i++ {
 		left := ""
 		if i < len(leftLines) {
 			left = leftLines[i]
@@ -1875,7 +3060,7 @@ func (m Model) renderSplitPane() string {
 		result.WriteString(left)
 		result.WriteString(sepStyle.Render("│"))
 		result.WriteString(right)
-		if i < available-1 {
+		if i < height-1 {
 			result.WriteString("\n")
 		}
 	}
@@ -1885,9 +3070,13 @@ func (m Model) renderSplitPane() string {
 
 // renderCategoryPane renders the left pane with the category list.
 func (m Model) renderCategoryPane(width, height int) string {
+	if m.catReloading {
+		msg := m.spinner.View() + lipgloss.NewStyle().Foreground(DraculaComment).Render(" Loading categories...")
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, msg)
+	}
 	visible := m.catVisibleList()
 	if len(visible) == 0 {
-		emptyText := "No categories"
+		emptyText := noCategoriesMsg
 		if m.catFilterQuery != "" {
 			emptyText = "No match"
 		}
@@ -1935,7 +3124,7 @@ func (m Model) renderCategoryPane(width, height int) string {
 		if maxName < 5 {
 			maxName = 5
 		}
-		name = truncateToWidth(name, maxName)
+		name = m.format.truncate(name, maxName)
 
 		if isSelected && isLeftFocused {
 			line := lipgloss.NewStyle().
@@ -2015,7 +3204,7 @@ func (m Model) renderProductPane(width, height int) string {
 	var b strings.Builder
 	for i := start; i < end; i++ {
 		isSelected := i == sel && isRightFocused
-		b.WriteString(renderProductItem(m.splitProducts[i], isSelected, width))
+		b.WriteString(renderProductItem(m.splitProducts[i], isSelected, width, "", "", false, m.nameColumn, m.format))
 		if i < end-1 {
 			b.WriteString("\n")
 		}
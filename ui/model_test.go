@@ -0,0 +1,1279 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+type fakeSource struct {
+	leaderboard []types.Product
+	details     map[string]types.ProductDetail
+	catProducts []types.Product
+	search      []types.Product
+}
+
+func (f *fakeSource) GetLeaderboard(types.Period, time.Time) ([]types.Product, error) {
+	return f.leaderboard, nil
+}
+
+func (f *fakeSource) GetProductDetail(slug string) (types.ProductDetail, error) {
+	if d, ok := f.details[slug]; ok {
+		return d, nil
+	}
+	return types.ProductDetail{}, errors.New("not found")
+}
+
+func (f *fakeSource) GetCategoryProducts(string) ([]types.Product, []types.CategoryLink, error) {
+	return f.catProducts, nil, nil
+}
+
+func (f *fakeSource) SearchProductsPage(_ string, page int) ([]types.Product, int, bool, bool, int, error) {
+	return f.search, page, false, false, 1, nil
+}
+
+func testProduct(name, slug string, rank int) types.Product {
+	return types.NewProduct(name, name+" tagline", []string{"AI"}, 100*rank, rank, slug, "", rank, 0, false)
+}
+
+// newTestModel returns a sized model that is not loading.
+func newTestModel(src types.ProductSource) Model {
+	m := NewModel(src)
+	m.loading = false
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	return updated.(Model)
+}
+
+func update(t *testing.T, m Model, msg tea.Msg) (Model, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func slugsOf(products []types.Product) []string {
+	out := make([]string, 0, len(products))
+	for _, p := range products {
+		out = append(out, p.Slug())
+	}
+	return out
+}
+
+func TestPricingFilterToggle(t *testing.T) {
+	free := testProduct("Free App", "free-app", 1)
+	paid := testProduct("Paid App", "paid-app", 2)
+	unknown := testProduct("Mystery", "mystery", 3)
+	detail := func(p types.Product, pricing string) types.ProductDetail {
+		return types.NewProductDetail(types.ProductDetailOptions{
+			Product:     p,
+			PricingInfo: pricing,
+		})
+	}
+	src := &fakeSource{
+		search: []types.Product{free, paid, unknown},
+		details: map[string]types.ProductDetail{
+			"free-app": detail(free, "Free"),
+			"paid-app": detail(paid, "$9/month"),
+			"mystery":  detail(unknown, ""),
+		},
+	}
+	m := newTestModel(src)
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "app", page: 1, products: src.search})
+
+	m, cmd := update(t, m, keyRunes("p"))
+	if m.pricingFilter != "free" || !m.loading || cmd == nil {
+		t.Fatalf("expected pricing fetch for free filter, got filter=%q loading=%v", m.pricingFilter, m.loading)
+	}
+	var msg tea.Msg = fetchPricingTypes(context.Background(), src, src.search, m.requestID)()
+	m, _ = update(t, m, msg)
+	if got := slugsOf(m.products); len(got) != 1 || got[0] != "free-app" {
+		t.Fatalf("free filter products = %v", got)
+	}
+
+	// Pricing is cached now, so switching to paid applies immediately.
+	m, cmd = update(t, m, keyRunes("p"))
+	if m.loading || cmd != nil {
+		t.Fatalf("expected cached pricing to apply without a fetch")
+	}
+	if got := slugsOf(m.products); len(got) != 1 || got[0] != "paid-app" {
+		t.Fatalf("paid filter products = %v", got)
+	}
+
+	m, _ = update(t, m, keyRunes("p"))
+	if m.pricingFilter != "" || len(m.products) != 3 {
+		t.Fatalf("expected filter cleared with all products, got %q %v", m.pricingFilter, slugsOf(m.products))
+	}
+}
+
+func TestPricingFilterBoundsLookups(t *testing.T) {
+	src := &fakeSource{details: map[string]types.ProductDetail{}}
+	for i := range maxPricingLookups + 10 {
+		p := testProduct(fmt.Sprintf("App %d", i), fmt.Sprintf("app-%d", i), i+1)
+		src.search = append(src.search, p)
+		src.details[p.Slug()] = types.NewProductDetail(types.ProductDetailOptions{Product: p, PricingInfo: "Free"})
+	}
+	m := newTestModel(src)
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "app", page: 1, products: src.search})
+
+	m, cmd := update(t, m, keyRunes("p"))
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(pricingMsg); ok {
+			if len(msg.pricing) != maxPricingLookups {
+				t.Fatalf("looked up %d products, want %d", len(msg.pricing), maxPricingLookups)
+			}
+			m, _ = update(t, m, msg)
+		}
+	}
+	if len(m.products) != maxPricingLookups || !strings.Contains(m.statusMsg, "10 not checked yet") {
+		t.Errorf("%d products, status %q", len(m.products), m.statusMsg)
+	}
+}
+
+func TestPricingFilterIgnoredOnLeaderboard(t *testing.T) {
+	src := &fakeSource{leaderboard: []types.Product{testProduct("A", "a", 1)}}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: src.leaderboard})
+	m, cmd := update(t, m, keyRunes("p"))
+	if m.pricingFilter != "" || cmd != nil {
+		t.Fatalf("pricing filter should not apply to leaderboard view")
+	}
+}
+
+func TestReviewFilterToggle(t *testing.T) {
+	t.Setenv("PHTUI_MIN_REVIEWS", "20")
+	reviewed := func(name, slug string, rank, reviews int) types.Product {
+		return types.NewProduct(name, "", nil, 0, reviews, slug, "", rank, 4.5, false)
+	}
+	products := []types.Product{
+		reviewed("Established", "established", 1, 155),
+		reviewed("Newcomer", "newcomer", 2, 3),
+		reviewed("Steady", "steady", 3, 20),
+	}
+	m := newTestModel(&fakeSource{catProducts: products})
+
+	// The key does nothing outside category results.
+	m, _ = update(t, m, keyRunes("n"))
+	if m.minReviews != 0 {
+		t.Fatalf("review filter turned on outside a category: %d", m.minReviews)
+	}
+
+	m.loading = true
+	m, _ = update(t, m, categoryProductsMsg{requestID: m.requestID, slug: "ai-agents", products: products})
+	m, _ = update(t, m, keyRunes("n"))
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"established", "steady"}) {
+		t.Fatalf("filtered products = %v", got)
+	}
+	if !strings.Contains(m.statusMsg, "20+ reviews, 1 hidden") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	// The filter carries over to the next category loaded.
+	m.loading = true
+	m, _ = update(t, m, categoryProductsMsg{requestID: m.requestID, slug: "ai-agents", products: products})
+	if len(m.products) != 2 || !strings.Contains(m.statusMsg, "1 hidden") {
+		t.Fatalf("reload kept %v, status %q", slugsOf(m.products), m.statusMsg)
+	}
+
+	m, _ = update(t, m, keyRunes("n"))
+	if m.minReviews != 0 || len(m.products) != 3 {
+		t.Fatalf("expected filter off with all products, got %d %v", m.minReviews, slugsOf(m.products))
+	}
+}
+
+func TestFeaturedOnlyToggle(t *testing.T) {
+	products := []types.Product{
+		types.NewProduct("Reviewed", "", nil, 50, 12, "reviewed", "", 1, 0, false),
+		types.NewProduct("Never Launched", "", nil, 0, 0, "never-launched", "", 2, 0, false),
+		types.NewProduct("Gone", "", nil, 300, 40, "gone", "", 3, 4.8, true),
+	}
+	m := newTestModel(&fakeSource{leaderboard: products, search: products})
+
+	// The key does nothing outside search results.
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+	m, _ = update(t, m, keyRunes("F"))
+	if m.featuredOnly || len(m.products) != 3 {
+		t.Fatalf("featured filter applied to a leaderboard: %v", slugsOf(m.products))
+	}
+
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "app", page: 1, products: products})
+	m, _ = update(t, m, keyRunes("F"))
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"reviewed"}) {
+		t.Fatalf("featured only = %v", got)
+	}
+	if !strings.Contains(m.statusMsg, "featured only, 2 hidden") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	// The filter carries over to the next search.
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "tool", page: 1, products: products})
+	if len(m.products) != 1 || !strings.Contains(m.statusMsg, "2 hidden") {
+		t.Fatalf("next search kept %v, status %q", slugsOf(m.products), m.statusMsg)
+	}
+
+	m, _ = update(t, m, keyRunes("F"))
+	if m.featuredOnly || len(m.products) != 3 {
+		t.Fatalf("expected filter off with all products, got %v", slugsOf(m.products))
+	}
+}
+
+func TestCopyPageURL(t *testing.T) {
+	var copied []string
+	prev := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+	defer func() { copyToClipboard = prev }()
+
+	date := time.Date(2026, 2, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		setup func(m *Model)
+		want  string
+	}{
+		{"leaderboard", func(m *Model) { m.period = types.Weekly; m.date = date }, "https://www.producthunt.com/leaderboard/weekly/2026/8"},
+		{"search", func(m *Model) { m.searchResults = true; m.searchQuery = "ai notes"; m.searchPage = 2 }, "https://www.producthunt.com/search?q=ai+notes&page=2"},
+		{"category", func(m *Model) { m.categoryMode = true; m.categorySlug = "ai-agents" }, "https://www.producthunt.com/categories/ai-agents"},
+		{"category split pane", func(m *Model) { m.categorySelectMode = true; m.splitSlug = "llms" }, "https://www.producthunt.com/categories/llms"},
+		{"detail", func(m *Model) {
+			m.state = DetailView
+			m.detail = types.NewProductDetail(types.ProductDetailOptions{Product: testProduct("Demo", "demo", 1)})
+		}, "https://www.producthunt.com/products/demo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copied = nil
+			m := newTestModel(&fakeSource{})
+			tt.setup(&m)
+			m, _ = update(t, m, keyRunes("y"))
+			if len(copied) != 1 || copied[0] != tt.want {
+				t.Fatalf("copied = %v, want [%s]", copied, tt.want)
+			}
+			if m.statusMsg != "Copied "+tt.want {
+				t.Errorf("status = %q", m.statusMsg)
+			}
+		})
+	}
+}
+
+func TestMinTerminalSizeOverride(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       string
+		width     int
+		height    int
+		wantSmall bool
+	}{
+		{"default blocks 50x12", "", 50, 12, true},
+		{"default allows 60x15", "", 60, 15, false},
+		{"override allows 50x12", "40x10", 50, 12, false},
+		{"override still blocks below", "40x10", 39, 12, true},
+		{"invalid override keeps default", "tiny", 50, 12, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PHTUI_MIN_SIZE", tt.env)
+			m := NewModel(&fakeSource{})
+			m.loading = false
+			m, _ = update(t, m, tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+			small := strings.Contains(m.View(), "Terminal too small")
+			if small != tt.wantSmall {
+				t.Errorf("too small = %v, want %v", small, tt.wantSmall)
+			}
+		})
+	}
+}
+
+func TestSearchSortToggle(t *testing.T) {
+	results := []types.Product{
+		types.NewProduct("A", "", nil, 10, 50, "a", "", 1, 4.2, false),
+		types.NewProduct("B", "", nil, 30, 5, "b", "", 2, 4.9, false),
+		types.NewProduct("C", "", nil, 20, 90, "c", "", 3, 3.5, false),
+	}
+	src := &fakeSource{search: results}
+	m := newTestModel(src)
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "app", page: 1, products: results})
+
+	steps := []struct {
+		order types.SortOrder
+		want  string
+	}{
+		{types.SortVotes, "b,c,a"},
+		{types.SortReviews, "c,a,b"},
+		{types.SortRating, "b,a,c"},
+		{types.SortRelevance, "a,b,c"},
+	}
+	for _, step := range steps {
+		m, _ = update(t, m, keyRunes("s"))
+		if m.searchSort != step.order {
+			t.Fatalf("sort = %q, want %q", m.searchSort, step.order)
+		}
+		if got := strings.Join(slugsOf(m.products), ","); got != step.want {
+			t.Fatalf("%s order = %s, want %s", step.order, got, step.want)
+		}
+	}
+
+	// Sorting persists to the next page of results.
+	m, _ = update(t, m, keyRunes("s"))
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "app", page: 2, products: results})
+	if got := strings.Join(slugsOf(m.products), ","); got != "b,c,a" {
+		t.Fatalf("next page order = %s, want b,c,a", got)
+	}
+	if !strings.Contains(m.statusMsg, "sort: votes") {
+		t.Errorf("status = %q, want sort info", m.statusMsg)
+	}
+}
+
+// slowSource delays leaderboard fetches so their timing can be measured.
+type slowSource struct {
+	fakeSource
+	delay time.Duration
+}
+
+func (s *slowSource) GetLeaderboard(period types.Period, date time.Time) ([]types.Product, error) {
+	time.Sleep(s.delay)
+	return s.fakeSource.GetLeaderboard(period, date)
+}
+
+func TestLoadTimingInStatus(t *testing.T) {
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}
+	src := &slowSource{fakeSource: fakeSource{leaderboard: products}, delay: 20 * time.Millisecond}
+	m := newTestModel(src)
+	msg := fetchLeaderboard(context.Background(), src, m.period, m.date, m.requestID)().(leaderboardMsg)
+	if msg.elapsed < src.delay {
+		t.Fatalf("elapsed = %v, want at least %v", msg.elapsed, src.delay)
+	}
+
+	msg.elapsed = 340 * time.Millisecond
+	m, _ = update(t, m, msg)
+	if !strings.HasPrefix(m.statusMsg, "Loaded 2 products in 340ms (") {
+		t.Errorf("leaderboard status = %q", m.statusMsg)
+	}
+
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 1, products: products, elapsed: 2400 * time.Millisecond})
+	if !strings.HasSuffix(m.statusMsg, " • loaded in 2.4s") {
+		t.Errorf("search status = %q", m.statusMsg)
+	}
+
+	detail := types.NewProductDetail(types.ProductDetailOptions{Product: products[0]})
+	m, _ = update(t, m, productDetailMsg{requestID: m.requestID, detail: detail, elapsed: 85 * time.Millisecond})
+	if m.statusMsg != "Alpha • loaded in 85ms" {
+		t.Errorf("detail status = %q", m.statusMsg)
+	}
+}
+
+func TestSearchEmptyQueryShowsTrending(t *testing.T) {
+	trending := []types.Product{testProduct("Top", "top", 1), testProduct("Next", "next", 2)}
+	m := newTestModel(&fakeSource{search: trending})
+
+	m, _ = update(t, m, keyRunes("/"))
+	m, cmd := update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.loading {
+		t.Fatal("Enter on an empty search box did not start a search")
+	}
+	m, _ = update(t, m, fetchSearchResults(m.requestCtx(), m.source, "", 1, m.requestID)())
+	if got := strings.Join(slugsOf(m.products), ","); got != "top,next" {
+		t.Fatalf("products = %s, want top,next", got)
+	}
+	if !strings.HasPrefix(m.statusMsg, "Trending today") {
+		t.Errorf("status = %q, want trending status", m.statusMsg)
+	}
+	if got, want := m.pageURL(), scraper.LeaderboardURL(types.Daily, types.Today()); got != want {
+		t.Errorf("pageURL = %q, want %q", got, want)
+	}
+}
+
+func TestSearchSortIgnoredOnLeaderboard(t *testing.T) {
+	src := &fakeSource{leaderboard: []types.Product{testProduct("A", "a", 1)}}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: src.leaderboard})
+	m, _ = update(t, m, keyRunes("s"))
+	if m.searchSort != "" {
+		t.Fatalf("sort should not apply to leaderboard view, got %q", m.searchSort)
+	}
+}
+
+func TestLaunchTimeSort(t *testing.T) {
+	products := []types.Product{testProduct("Early", "early", 1), testProduct("Untimed", "untimed", 2), testProduct("Late", "late", 3)}
+	at := time.Date(2026, 2, 18, 0, 1, 0, 0, time.UTC)
+	featured := map[string]time.Time{"early": at, "late": at.Add(3 * time.Hour)}
+	m := newTestModel(&fakeSource{leaderboard: products})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products, featured: featured})
+
+	m, _ = update(t, m, keyRunes("s"))
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"late", "early", "untimed"}) {
+		t.Fatalf("launch order = %v", got)
+	}
+	if m.searchSort != "" {
+		t.Errorf("leaderboard sort changed the search sort to %q", m.searchSort)
+	}
+
+	// The order sticks across reloads.
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products, featured: featured})
+	if got := slugsOf(m.products); got[0] != "late" || !strings.Contains(m.statusMsg, "newest launches first") {
+		t.Fatalf("reload order = %v, status %q", got, m.statusMsg)
+	}
+
+	m, _ = update(t, m, keyRunes("s"))
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"early", "untimed", "late"}) {
+		t.Fatalf("rank order = %v", got)
+	}
+}
+
+func TestLaunchTimeSortWithoutTimes(t *testing.T) {
+	products := []types.Product{testProduct("A", "a", 1), testProduct("B", "b", 2)}
+	m := newTestModel(&fakeSource{leaderboard: products})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+
+	m, _ = update(t, m, keyRunes("s"))
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("order without times = %v, want rank order", got)
+	}
+	if m.statusMsg != "Launch times unavailable; sorted by rank" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
+
+func TestOfflineMarker(t *testing.T) {
+	src := &fakeSource{search: []types.Product{
+		types.NewProduct("Live", "", nil, 1, 0, "live", "", 1, 0, false),
+		types.NewProduct("Gone", "", nil, 1, 0, "gone", "", 2, 0, true),
+	}}
+	m := newTestModel(src)
+	m, _ = update(t, m, fetchSearchResults(m.requestCtx(), src, "demo", 1, m.requestID)())
+
+	live := renderProductItem(m.products[0], false, 60, "", "", false, defaultNameColumn, defaultTextFormat)
+	gone := renderProductItem(m.products[1], false, 60, "", "", false, defaultNameColumn, defaultTextFormat)
+	if strings.Contains(live, offlineMarker) {
+		t.Errorf("online product rendered with marker: %q", live)
+	}
+	if !strings.Contains(gone, offlineMarker) {
+		t.Errorf("offline product missing marker: %q", gone)
+	}
+	firstLine := func(s string) string { return strings.SplitN(s, "\n", 2)[0] }
+	if lipgloss.Width(firstLine(live)) != lipgloss.Width(firstLine(gone)) {
+		t.Errorf("marker should not change row width: %d vs %d", lipgloss.Width(firstLine(live)), lipgloss.Width(firstLine(gone)))
+	}
+}
+
+func TestDetailSectionAnchors(t *testing.T) {
+	var tags []types.ProConTag
+	// Enough tags that every section can scroll to the top of the viewport.
+	for i := 0; i < 60; i++ {
+		tags = append(tags, types.NewProConTag(fmt.Sprintf("Pro %d", i), "Positive", i))
+		tags = append(tags, types.NewProConTag(fmt.Sprintf("Con %d", i), "Negative", i))
+	}
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:       testProduct("Demo", "demo", 1),
+		Description:   "Line one\nLine two\nLine three",
+		Rating:        4.5,
+		ReviewCount:   10,
+		FollowerCount: 100,
+		MakerComment:  "Thanks for checking us out!\nMore to come.",
+		WebsiteURL:    "https://demo.dev",
+		ProConTags:    tags,
+	})
+
+	m := newTestModel(&fakeSource{})
+	m, _ = update(t, m, productDetailMsg{requestID: m.requestID, detail: detail})
+
+	content, _ := m.renderDetailContent()
+	lines := strings.Split(content, "\n")
+	for section, prefix := range map[detailSection]string{
+		sectionMakerComment: "--- Maker Comment ---",
+		sectionPros:         "👍 Pros:",
+		sectionCons:         "👎 Cons:",
+	} {
+		line, ok := m.detailAnchors[section]
+		if !ok {
+			t.Fatalf("missing anchor for %s", section)
+		}
+		if !strings.HasPrefix(lines[line], prefix) {
+			t.Errorf("%s anchor line %d = %q, want %q", section, line, lines[line], prefix)
+		}
+	}
+
+	m, _ = update(t, m, keyRunes("-"))
+	if m.viewport.YOffset != m.detailAnchors[sectionCons] {
+		t.Errorf("cons jump: YOffset = %d, want %d", m.viewport.YOffset, m.detailAnchors[sectionCons])
+	}
+	m, _ = update(t, m, keyRunes("m"))
+	if m.viewport.YOffset != m.detailAnchors[sectionMakerComment] {
+		t.Errorf("maker jump: YOffset = %d, want %d", m.viewport.YOffset, m.detailAnchors[sectionMakerComment])
+	}
+
+	// Sections that weren't rendered report it instead of scrolling.
+	bare := types.NewProductDetail(types.ProductDetailOptions{Product: testProduct("Bare", "bare", 1)})
+	m, _ = update(t, m, productDetailMsg{requestID: m.requestID, detail: bare})
+	m, _ = update(t, m, keyRunes("+"))
+	if m.viewport.YOffset != 0 || m.statusMsg != "No Pros section" {
+		t.Errorf("missing pros: YOffset = %d, status = %q", m.viewport.YOffset, m.statusMsg)
+	}
+}
+
+func TestRenderRatingDistribution(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(renderRatingDistribution([5]int{3, 0, 2, 6, 12}), "\n"), "\n")
+	want := []struct {
+		prefix string
+		bars   int
+	}{{"  5★ ", 20}, {"  4★ ", 10}, {"  3★ ", 3}, {"  2★ ", 0}, {"  1★ ", 5}}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), strings.Join(lines, "\n"))
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(lines[i], w.prefix) || strings.Count(lines[i], "█") != w.bars {
+			t.Errorf("line %d = %q, want %q with %d bars", i, lines[i], w.prefix, w.bars)
+		}
+	}
+
+	// Details without a histogram show no chart.
+	bare := types.NewProductDetail(types.ProductDetailOptions{
+		Product:     testProduct("Bare", "bare", 1),
+		Rating:      4.2,
+		ReviewCount: 3,
+	})
+	if content, _ := renderDetail(bare, defaultTextFormat); strings.Contains(content, "★") {
+		t.Errorf("chart rendered without a histogram:\n%s", content)
+	}
+}
+
+func TestRenderRelatedLaunches(t *testing.T) {
+	related := []types.Product{
+		types.NewProduct("Demo Lite", "The smaller demo", nil, 0, 0, "demo-lite", "", 0, 0, false),
+		types.NewProduct("Demo Pro", "", nil, 0, 0, "demo-pro", "", 0, 0, false),
+	}
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product: testProduct("Demo", "demo", 1),
+		Related: related,
+	})
+	content, _ := renderDetail(detail, defaultTextFormat)
+	for _, want := range []string{"Makers also launched:", "Demo Lite", "— The smaller demo", "Demo Pro"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail is missing %q:\n%s", want, content)
+		}
+	}
+
+	bare := types.NewProductDetail(types.ProductDetailOptions{Product: testProduct("Bare", "bare", 1)})
+	if content, _ := renderDetail(bare, defaultTextFormat); strings.Contains(content, "also launched") {
+		t.Errorf("section rendered without related launches:\n%s", content)
+	}
+}
+
+func TestRenderDetailCounts(t *testing.T) {
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:       types.NewProduct("Demo", "", nil, 1422, 272, "demo", "", 1, 0, false),
+		Rating:        4.4,
+		ReviewCount:   11,
+		FollowerCount: 2600,
+	})
+	content, _ := renderDetail(detail, defaultTextFormat)
+	if !strings.Contains(content, "(11 reviews) • ▲ 1.4K upvotes • 272 comments • 2.6K followers") {
+		t.Errorf("detail stats are missing the counts:\n%s", content)
+	}
+
+	bare := types.NewProductDetail(types.ProductDetailOptions{Product: types.NewProduct("Bare", "", nil, 0, 0, "bare", "", 1, 0, false)})
+	if content, _ := renderDetail(bare, defaultTextFormat); strings.Contains(content, "upvotes") || strings.Contains(content, "comments •") {
+		t.Errorf("counts rendered for a page without them:\n%s", content)
+	}
+}
+
+func TestRenderDetailGallery(t *testing.T) {
+	detail := types.NewProductDetail(types.ProductDetailOptions{
+		Product:       testProduct("Demo", "demo", 1),
+		GalleryImages: []string{"https://ph-files.imgix.net/one.png", "https://ph-files.imgix.net/two.png"},
+	})
+	content, _ := renderDetail(detail, defaultTextFormat)
+	if !strings.Contains(content, "Gallery:\n  • https://ph-files.imgix.net/one.png\n  • https://ph-files.imgix.net/two.png\n") {
+		t.Errorf("gallery images not listed:\n%s", content)
+	}
+}
+
+func TestChangeFeedBadges(t *testing.T) {
+	first := []types.Product{testProduct("A", "a", 1), testProduct("B", "b", 2), testProduct("C", "c", 3)}
+	second := []types.Product{testProduct("B", "b", 1), testProduct("D", "d", 2), testProduct("A", "a", 3)}
+	m := newTestModel(&fakeSource{})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: first})
+
+	m, _ = update(t, m, keyRunes("c"))
+	if !m.changeFeed {
+		t.Fatal("expected change feed on")
+	}
+	m, cmd := update(t, m, leaderboardMsg{requestID: m.requestID, products: second})
+	want := map[string]string{"b": "▲1", "d": "NEW", "a": "▼2"}
+	for slug, badge := range want {
+		if m.changeBadges[slug] != badge {
+			t.Errorf("badge[%s] = %q, want %q", slug, m.changeBadges[slug], badge)
+		}
+	}
+	if len(m.changeBadges) != len(want) {
+		t.Errorf("badges = %v, want %v", m.changeBadges, want)
+	}
+	if cmd == nil {
+		t.Fatal("expected a command to clear the highlights")
+	}
+	if view := m.renderProductList(); !strings.Contains(view, "NEW") || !strings.Contains(view, "▼2") {
+		t.Errorf("list view missing badges:\n%s", view)
+	}
+
+	// A stale clear from an earlier load leaves the badges alone.
+	m, _ = update(t, m, clearChangesMsg{seq: m.changeSeq - 1})
+	if len(m.changeBadges) == 0 {
+		t.Fatal("stale clear removed badges")
+	}
+	m, _ = update(t, m, clearChangesMsg{seq: m.changeSeq})
+	if len(m.changeBadges) != 0 {
+		t.Fatalf("badges not cleared: %v", m.changeBadges)
+	}
+
+	// An unchanged reload produces no badges.
+	m, cmd = update(t, m, leaderboardMsg{requestID: m.requestID, products: second})
+	if len(m.changeBadges) != 0 || cmd != nil {
+		t.Fatalf("unexpected badges for unchanged leaderboard: %v", m.changeBadges)
+	}
+}
+
+// cachingSource serves the leaderboard it last fetched until it is
+// invalidated, like a source with a long cache TTL.
+type cachingSource struct {
+	fakeSource
+	cached []types.Product
+}
+
+func (s *cachingSource) GetLeaderboard(types.Period, time.Time) ([]types.Product, error) {
+	if s.cached == nil {
+		s.cached = s.leaderboard
+	}
+	return s.cached, nil
+}
+
+func (s *cachingSource) InvalidateLeaderboard(types.Period, time.Time) {
+	s.cached = nil
+}
+
+// refresh presses r and feeds the resulting leaderboard load back in.
+func refresh(t *testing.T, m Model) Model {
+	t.Helper()
+	m, cmd := update(t, m, keyRunes("r"))
+	if cmd == nil {
+		t.Fatal("refresh returned no command")
+	}
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(leaderboardMsg); ok {
+			m, _ = update(t, m, msg)
+			return m
+		}
+	}
+	t.Fatal("refresh didn't load the leaderboard")
+	return m
+}
+
+func TestRefreshBypassesCache(t *testing.T) {
+	src := &cachingSource{fakeSource: fakeSource{leaderboard: []types.Product{testProduct("A", "a", 1), testProduct("B", "b", 2)}}}
+	m := newTestModel(src)
+	m, _ = update(t, m, keyRunes("c"))
+	m = refresh(t, m)
+
+	src.leaderboard = []types.Product{testProduct("B", "b", 1), testProduct("A", "a", 2)}
+	m = refresh(t, m)
+	if m.changeBadges["b"] != "▲1" || m.changeBadges["a"] != "▼1" {
+		t.Fatalf("badges = %v, want b ▲1 and a ▼1 after the source changed", m.changeBadges)
+	}
+}
+
+func TestNoCategoriesAvailable(t *testing.T) {
+	prev := types.AllCategories
+	types.AllCategories = nil
+	defer func() { types.AllCategories = prev }()
+
+	m := newTestModel(&fakeSource{})
+	m, cmd := update(t, m, keyRunes("4"))
+	if m.categorySelectMode || cmd != nil {
+		t.Fatal("categories tab should be disabled with no categories")
+	}
+	if m.statusMsg != noCategoriesMsg {
+		t.Errorf("status = %q, want %q", m.statusMsg, noCategoriesMsg)
+	}
+	_ = m.View()
+	tabs, _ := m.lastBarRegions.get()
+	for _, r := range tabs {
+		if r.isCategory {
+			t.Fatal("disabled categories tab should not be clickable")
+		}
+	}
+
+	// Tab from Monthly skips the disabled Categories tab.
+	m.period = types.Monthly
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.categorySelectMode || m.statusMsg != "Loading upcoming..." {
+		t.Fatalf("tab from monthly: categorySelect=%v status=%q", m.categorySelectMode, m.statusMsg)
+	}
+	m.loading = false
+
+	// A split pane left open renders an explicit empty state.
+	m.categorySelectMode = true
+	if pane := m.renderCategoryPane(30, 10); !strings.Contains(pane, noCategoriesMsg) {
+		t.Errorf("category pane = %q", pane)
+	}
+}
+
+func TestTabClicksPerModel(t *testing.T) {
+	// Each model clicks its own tab against the regions of its own render;
+	// run with -race to catch models sharing them.
+	var wg sync.WaitGroup
+	for _, period := range []types.Period{types.Weekly, types.Monthly} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			base := newTestModel(&fakeSource{})
+			for i := 0; i < 50; i++ {
+				m := base
+				_ = m.View()
+				tabs, _ := m.lastBarRegions.get()
+				x := -1
+				for _, r := range tabs {
+					if r.period == period && !r.isCategory && !r.isUpcoming {
+						x = r.xStart
+					}
+				}
+				if x < 0 {
+					t.Errorf("no %s tab region in %v", period, tabs)
+					return
+				}
+				updated, _ := m.Update(tea.MouseMsg{X: x, Y: 0, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+				if got := updated.(Model).period; got != period {
+					t.Errorf("click on the %s tab switched to %s", period, got)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWeeklyDateBarYearBoundary(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		firstGoto time.Time // where the bar's first week opens
+		firstURL  string
+		label     string // formatDate after opening the first week
+	}{
+		{
+			// Jan 1-3, 2021 are in ISO week 53 of 2020.
+			name:      "early January in the previous ISO year",
+			date:      time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			firstGoto: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			firstURL:  "/leaderboard/weekly/2020/53",
+			label:     "Week 53, 2020",
+		},
+		{
+			// Dec 30, 2024 starts ISO week 1 of 2025.
+			name:      "late December in the next ISO year",
+			date:      time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			firstGoto: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			firstURL:  "/leaderboard/weekly/2024/48",
+			label:     "Week 48, 2024",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(&fakeSource{})
+			m.period = types.Weekly
+			m.date = tt.date
+
+			_, regions := m.buildWeeklyDateBar()
+			var gotos []dateRegion
+			for _, r := range regions {
+				if r.action == "goto" {
+					gotos = append(gotos, r)
+				}
+			}
+			if len(gotos) == 0 {
+				t.Fatal("no week regions")
+			}
+			var active []dateRegion
+			for _, r := range gotos {
+				if sameISOWeek(r.date, m.date) {
+					active = append(active, r)
+				}
+			}
+			if len(active) != 1 {
+				t.Fatalf("%d weeks match the current ISO week, want 1", len(active))
+			}
+			if !gotos[0].date.Equal(tt.firstGoto) {
+				t.Errorf("first week opens %v, want %v", gotos[0].date, tt.firstGoto)
+			}
+
+			// The highlighted week reopens the current leaderboard.
+			m = clickDateRegion(t, m, active[0])
+			if got, want := types.Weekly.URLPath(m.date), types.Weekly.URLPath(tt.date); got != want {
+				t.Errorf("active week leaderboard = %s, want %s", got, want)
+			}
+
+			m = clickDateRegion(t, m, gotos[0])
+			if got := types.Weekly.URLPath(m.date); got != tt.firstURL {
+				t.Errorf("first week leaderboard = %s, want %s", got, tt.firstURL)
+			}
+			if m.date.Month() != tt.date.Month() {
+				t.Errorf("opening the first week moved the bar to %v", m.date)
+			}
+			if got := m.formatDate(); got != tt.label {
+				t.Errorf("formatDate = %q, want %q", got, tt.label)
+			}
+		})
+	}
+
+	if got := types.Weekly.URLPath(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)); got != "/leaderboard/weekly/2025/1" {
+		t.Errorf("Dec 30, 2024 leaderboard = %s, want /leaderboard/weekly/2025/1", got)
+	}
+}
+
+func clickDateRegion(t *testing.T, m Model, r dateRegion) Model {
+	t.Helper()
+	updated, _ := m.handleDateBarClick(r)
+	m = updated.(Model)
+	m.loading = false
+	return m
+}
+
+type upcomingFakeSource struct {
+	*fakeSource
+	upcoming []types.Product
+}
+
+func (f *upcomingFakeSource) GetUpcomingProducts() ([]types.Product, error) {
+	return f.upcoming, nil
+}
+
+func TestUpcomingTab(t *testing.T) {
+	src := &upcomingFakeSource{
+		fakeSource: &fakeSource{leaderboard: []types.Product{testProduct("Daily", "daily", 1)}},
+		upcoming: []types.Product{
+			types.NewProduct("Soon", "", nil, 0, 0, "soon", "", 1, 0, false),
+			types.NewProduct("Later", "Coming later", nil, 0, 0, "later", "", 2, 0, false),
+		},
+	}
+	m := newTestModel(src)
+
+	m, cmd := update(t, m, keyRunes("5"))
+	if cmd == nil || !m.loading {
+		t.Fatal("5 should start loading upcoming products")
+	}
+	m, _ = update(t, m, fetchUpcoming(context.Background(), src, m.requestID)())
+	if !m.upcomingMode || m.loading {
+		t.Fatalf("upcomingMode=%v loading=%v", m.upcomingMode, m.loading)
+	}
+	if got := slugsOf(m.products); len(got) != 2 || got[0] != "soon" {
+		t.Fatalf("products = %v", got)
+	}
+	if m.pageURL() != scraper.UpcomingURL() {
+		t.Errorf("pageURL = %q", m.pageURL())
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Coming soon") {
+		t.Errorf("date bar should label the upcoming listing:\n%s", view)
+	}
+	found := false
+	tabs, _ := m.lastBarRegions.get()
+	for _, r := range tabs {
+		found = found || r.isUpcoming
+	}
+	if !found {
+		t.Error("upcoming tab should be clickable")
+	}
+
+	// Dates don't apply to the coming-soon listing.
+	date := m.date
+	if m, cmd = update(t, m, keyRunes("h")); cmd != nil || !m.date.Equal(date) {
+		t.Fatal("prev date should be a no-op in upcoming mode")
+	}
+
+	// Tab leaves Upcoming for the Daily leaderboard.
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = update(t, m, fetchLeaderboard(context.Background(), src, m.period, m.date, m.requestID)())
+	if m.upcomingMode || m.period != types.Daily {
+		t.Fatalf("after tab: upcomingMode=%v period=%v", m.upcomingMode, m.period)
+	}
+}
+
+func TestUpcomingUnsupportedSource(t *testing.T) {
+	m := newTestModel(&fakeSource{})
+	m, _ = update(t, m, keyRunes("5"))
+	m, _ = update(t, m, fetchUpcoming(context.Background(), m.source, m.requestID)())
+	if m.upcomingMode || m.err == nil {
+		t.Fatalf("unsupported source: upcomingMode=%v err=%v", m.upcomingMode, m.err)
+	}
+}
+
+type relatedCategoriesFakeSource struct {
+	*fakeSource
+	related []types.CategoryLink
+}
+
+func (f *relatedCategoriesFakeSource) GetCategoryProducts(string) ([]types.Product, []types.CategoryLink, error) {
+	return f.catProducts, f.related, nil
+}
+
+func TestBrowsingMergesRelatedCategories(t *testing.T) {
+	prevCategories, prevIndices := types.AllCategories, allCategoryIndices
+	types.AllCategories = []types.CategoryLink{
+		types.NewCategoryLink("AI Agents", "ai-agents"),
+		types.NewCategoryLink("LLMs", "llms"),
+	}
+	allCategoryIndices = categoryIndices(len(types.AllCategories))
+	defer func() { types.AllCategories, allCategoryIndices = prevCategories, prevIndices }()
+
+	src := &relatedCategoriesFakeSource{
+		fakeSource: &fakeSource{catProducts: []types.Product{testProduct("Agent", "agent", 1)}},
+		related: []types.CategoryLink{
+			types.NewCategoryLink("Large Language Models", "llms"),
+			types.NewCategoryLink("Vibe coding", "vibe-coding"),
+			types.NewCategoryLink("Vibe coding", "vibe-coding"),
+		},
+	}
+	m := newTestModel(src)
+	m, cmd := update(t, m, keyRunes("4"))
+	if cmd == nil {
+		t.Fatal("expected the selected category to load")
+	}
+	m, _ = update(t, m, fetchCategoryProducts(context.Background(), src, "ai-agents", m.splitRequestID)())
+
+	var got []string
+	for _, c := range types.AllCategories {
+		got = append(got, c.Slug()+":"+c.Name())
+	}
+	want := "ai-agents:AI Agents,llms:LLMs,vibe-coding:Vibe coding"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("categories = %v, want %s", got, want)
+	}
+	if len(m.catVisibleList()) != 3 {
+		t.Errorf("picker shows %d categories, want 3", len(m.catVisibleList()))
+	}
+	if m.catSelectIdx != 0 {
+		t.Errorf("cursor moved to %d, want 0", m.catSelectIdx)
+	}
+	if !strings.Contains(m.statusMsg, "1 new categories") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	// Browsing again adds nothing new.
+	m, _ = update(t, m, fetchCategoryProducts(context.Background(), src, "ai-agents", m.splitRequestID)())
+	if len(types.AllCategories) != 3 || strings.Contains(m.statusMsg, "new categories") {
+		t.Fatalf("second browse: %d categories, status %q", len(types.AllCategories), m.statusMsg)
+	}
+}
+
+// fetchCountingSource records which categories were fetched.
+type fetchCountingSource struct {
+	*fakeSource
+	fetched []string
+}
+
+func (f *fetchCountingSource) GetCategoryProducts(slug string) ([]types.Product, []types.CategoryLink, error) {
+	f.fetched = append(f.fetched, slug)
+	return f.fakeSource.GetCategoryProducts(slug)
+}
+
+func TestSplitPaneDebouncesCategoryFetches(t *testing.T) {
+	prevCategories, prevIndices := types.AllCategories, allCategoryIndices
+	types.AllCategories = []types.CategoryLink{
+		types.NewCategoryLink("AI Agents", "ai-agents"),
+		types.NewCategoryLink("LLMs", "llms"),
+		types.NewCategoryLink("Design", "design"),
+		types.NewCategoryLink("Fintech", "fintech"),
+	}
+	allCategoryIndices = categoryIndices(len(types.AllCategories))
+	prevDebounce := categoryDebounce
+	categoryDebounce = time.Millisecond
+	defer func() {
+		types.AllCategories, allCategoryIndices = prevCategories, prevIndices
+		categoryDebounce = prevDebounce
+	}()
+
+	src := &fetchCountingSource{fakeSource: &fakeSource{catProducts: []types.Product{testProduct("Agent", "agent", 1)}}}
+	m := newTestModel(src)
+	m, _ = update(t, m, keyRunes("4"))
+	m, _ = update(t, m, fetchCategoryProducts(context.Background(), src, "ai-agents", m.splitRequestID)())
+	src.fetched = nil
+
+	// Arrow down three times before any pause ends.
+	var pauses []tea.Cmd
+	for range 3 {
+		var cmd tea.Cmd
+		m, cmd = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+		if cmd == nil {
+			t.Fatal("moving the cursor should start a pause")
+		}
+		pauses = append(pauses, cmd)
+	}
+	if len(src.fetched) != 0 {
+		t.Fatalf("fetched %v while moving, want nothing", src.fetched)
+	}
+	if !m.splitLoading {
+		t.Error("right pane should show loading during the pause")
+	}
+
+	var fetches []tea.Cmd
+	for _, pause := range pauses {
+		var cmd tea.Cmd
+		m, cmd = update(t, m, pause())
+		if cmd != nil {
+			fetches = append(fetches, cmd)
+		}
+	}
+	if len(fetches) != 1 {
+		t.Fatalf("%d fetches fired, want only the last", len(fetches))
+	}
+	for _, c := range fetches[0]().(tea.BatchMsg) {
+		if msg, ok := c().(categoryProductsMsg); ok {
+			m, _ = update(t, m, msg)
+		}
+	}
+	if strings.Join(src.fetched, ",") != "fintech" {
+		t.Fatalf("fetched %v, want [fintech]", src.fetched)
+	}
+	if m.splitSlug != "fintech" || m.splitLoading {
+		t.Fatalf("split pane shows %q (loading %v), want fintech loaded", m.splitSlug, m.splitLoading)
+	}
+
+	// A late response for a category passed over is dropped.
+	m, _ = update(t, m, fetchCategoryProducts(context.Background(), src, "llms", m.splitRequestID-1)())
+	if m.splitSlug != "fintech" {
+		t.Fatalf("stale response replaced the pane with %q", m.splitSlug)
+	}
+}
+
+type categoryIndexFakeSource struct {
+	*fakeSource
+	categories []types.CategoryLink
+	err        error
+}
+
+func (f *categoryIndexFakeSource) GetAllCategories() ([]types.CategoryLink, error) {
+	return f.categories, f.err
+}
+
+func TestReloadCategoriesMerge(t *testing.T) {
+	prevCategories, prevIndices := types.AllCategories, allCategoryIndices
+	types.AllCategories = []types.CategoryLink{
+		types.NewCategoryLink("AI Agents", "ai-agents"),
+		types.NewCategoryLink("LLMs", "llms"),
+	}
+	allCategoryIndices = categoryIndices(len(types.AllCategories))
+	defer func() { types.AllCategories, allCategoryIndices = prevCategories, prevIndices }()
+
+	src := &categoryIndexFakeSource{
+		fakeSource: &fakeSource{},
+		categories: []types.CategoryLink{
+			types.NewCategoryLink("Large Language Models", "llms"),
+			types.NewCategoryLink("Vibe coding", "vibe-coding"),
+		},
+	}
+	m := newTestModel(src)
+	m, _ = update(t, m, keyRunes("4"))
+	m, _ = update(t, m, keyRunes("j"))
+	m, cmd := update(t, m, keyRunes("r"))
+	if !m.catReloading || cmd == nil {
+		t.Fatal("expected category reload to start")
+	}
+	if pane := m.renderCategoryPane(40, 10); !strings.Contains(pane, "Loading categories") {
+		t.Errorf("left pane should show loading state, got %q", pane)
+	}
+
+	msg := fetchCategoryIndex(context.Background(), src)()
+	m, _ = update(t, m, msg)
+	if m.catReloading {
+		t.Fatal("reload still in progress")
+	}
+	var got []string
+	for _, c := range types.AllCategories {
+		got = append(got, c.Slug()+":"+c.Name())
+	}
+	want := "ai-agents:AI Agents,llms:Large Language Models,vibe-coding:Vibe coding"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("merged categories = %v, want %s", got, want)
+	}
+	if m.catSelectIdx != 1 {
+		t.Errorf("cursor moved to %d, want 1 (llms)", m.catSelectIdx)
+	}
+
+	// A failed reload keeps the current list.
+	src.err = errors.New("boom")
+	m, _ = update(t, m, keyRunes("r"))
+	m, _ = update(t, m, fetchCategoryIndex(context.Background(), src)())
+	if len(types.AllCategories) != 3 || !strings.Contains(m.statusMsg, "reload failed") {
+		t.Fatalf("after failure: %d categories, status %q", len(types.AllCategories), m.statusMsg)
+	}
+}
+
+func TestLeaderboardParseEmptyWarning(t *testing.T) {
+	m := newTestModel(&fakeSource{})
+	err := fmt.Errorf("parse leaderboard: %w", scraper.ErrParseEmpty)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, err: err})
+	if !strings.Contains(m.statusMsg, "layout may have changed") {
+		t.Fatalf("status = %q, want a parser breakage warning", m.statusMsg)
+	}
+}
+
+func TestDetailUnavailableMessage(t *testing.T) {
+	m := newTestModel(&fakeSource{})
+	err := fmt.Errorf("parse product detail: %w", types.ErrProductUnavailable)
+	m, _ = update(t, m, productDetailMsg{requestID: m.requestID, err: err})
+	if m.statusMsg != "This product is hidden or no longer available on Product Hunt" {
+		t.Fatalf("status = %q, want the unavailable message", m.statusMsg)
+	}
+}
+
+func TestPeriodSwitchDate(t *testing.T) {
+	past := time.Date(2025, 6, 10, 0, 0, 0, 0, types.Timezone())
+	tests := []struct {
+		env  string
+		want time.Time
+	}{
+		{"", past},
+		{"keep", past},
+		{"today", types.Today()},
+	}
+	for _, tt := range tests {
+		t.Run("env="+tt.env, func(t *testing.T) {
+			t.Setenv("PHTUI_PERIOD_SWITCH_DATE", tt.env)
+			m := newTestModel(&fakeSource{})
+			m.date = past
+			m, _ = update(t, m, keyRunes("2"))
+			if m.period != types.Weekly {
+				t.Fatalf("period = %v, want weekly", m.period)
+			}
+			if !m.date.Equal(tt.want) {
+				t.Errorf("date after switch = %v, want %v", m.date, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitialCategory(t *testing.T) {
+	prevCategories, prevIndices := types.AllCategories, allCategoryIndices
+	types.AllCategories = []types.CategoryLink{
+		types.NewCategoryLink("AI Agents", "ai-agents"),
+		types.NewCategoryLink("LLMs", "llms"),
+		types.NewCategoryLink("Design", "design"),
+	}
+	allCategoryIndices = categoryIndices(len(types.AllCategories))
+	defer func() { types.AllCategories, allCategoryIndices = prevCategories, prevIndices }()
+
+	tests := []struct {
+		env      string
+		wantIdx  int
+		wantSlug string
+	}{
+		{"", 0, "ai-agents"},
+		{"Design", 2, "design"},
+		{"no-such-category", 0, "ai-agents"},
+	}
+	for _, tt := range tests {
+		t.Run("env="+tt.env, func(t *testing.T) {
+			t.Setenv("PHTUI_DEFAULT_CATEGORY", tt.env)
+			src := &fetchCountingSource{fakeSource: &fakeSource{}}
+			m := newTestModel(src)
+			m, cmd := update(t, m, keyRunes("4"))
+			if m.catSelectIdx != tt.wantIdx {
+				t.Fatalf("cursor = %d, want %d", m.catSelectIdx, tt.wantIdx)
+			}
+			for _, c := range cmd().(tea.BatchMsg) {
+				c()
+			}
+			if strings.Join(src.fetched, ",") != tt.wantSlug {
+				t.Errorf("loaded %v, want [%s]", src.fetched, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestFocusMode(t *testing.T) {
+	var products []types.Product
+	for i := 1; i <= 30; i++ {
+		products = append(products, testProduct(fmt.Sprintf("P%d", i), fmt.Sprintf("p%d", i), i))
+	}
+	m := newTestModel(&fakeSource{leaderboard: products})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+	visible := func(view string) int {
+		return strings.Count(view, " tagline")
+	}
+	height, view := m.listHeight(), m.View()
+
+	m, _ = update(t, m, keyRunes("f"))
+	if !m.focusMode {
+		t.Fatal("f did not turn on focus mode")
+	}
+	if got, want := m.listHeight(), height+listChrome-focusChrome; got != want {
+		t.Fatalf("focus list height = %d, want %d", got, want)
+	}
+	focused := m.View()
+	if lines := strings.Count(focused, "\n") + 1; lines > m.height {
+		t.Errorf("focused view is %d lines, taller than the %d-line terminal", lines, m.height)
+	}
+	if visible(focused) <= visible(view) {
+		t.Errorf("focus mode shows %d products, no more than %d before", visible(focused), visible(view))
+	}
+	for _, chrome := range []string{"Monthly", "selected #1 of 30", "quit"} {
+		if strings.Contains(focused, chrome) {
+			t.Errorf("focused view still shows %q", chrome)
+		}
+	}
+	if !strings.Contains(focused, "Focus mode") {
+		t.Error("focused view is missing the status bar")
+	}
+
+	m, _ = update(t, m, keyRunes("f"))
+	if m.focusMode || m.listHeight() != height || !strings.Contains(m.View(), "Monthly") {
+		t.Errorf("second f left focus mode on or the chrome hidden")
+	}
+}
+
+func TestContextHeader(t *testing.T) {
+	var products []types.Product
+	for i := 1; i <= 30; i++ {
+		products = append(products, testProduct(fmt.Sprintf("P%d", i), fmt.Sprintf("p%d", i), i))
+	}
+	src := &fakeSource{leaderboard: products}
+	m := newTestModel(src)
+	m.date = time.Date(2026, 2, 18, 0, 0, 0, 0, time.UTC)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+	for i := 0; i < 11; i++ {
+		m, _ = update(t, m, keyRunes("j"))
+	}
+
+	if got, want := m.contextHeader(), "Daily • February 18, 2026 • selected #12 of 30"; got != want {
+		t.Fatalf("contextHeader = %q, want %q", got, want)
+	}
+	// The header stays on screen and the list makes room for it.
+	view := m.View()
+	if !strings.Contains(view, "selected #12 of 30") {
+		t.Error("view is missing the context header")
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > m.height {
+		t.Errorf("view is %d lines, taller than the %d-line terminal", lines, m.height)
+	}
+
+	m.categoryMode, m.categoryName = true, "AI Agents"
+	if got, want := m.contextHeader(), "Category: AI Agents • selected #12 of 30"; got != want {
+		t.Errorf("category contextHeader = %q, want %q", got, want)
+	}
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 2, products: products[:3]})
+	if got, want := m.contextHeader(), `Search "notes" • page 2 • selected #1 of 3`; got != want {
+		t.Errorf("search contextHeader = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultNameMinWidth is the room kept for a product's name on narrow rows
+// unless PHTUI_NAME_MIN_WIDTH sets another.
+const defaultNameMinWidth = 8
+
+// nameColumn lays out the product name on a list row: the least room it
+// keeps when the rank, badges and votes are wide, and which side of its
+// column it sits on.
+type nameColumn struct {
+	minWidth   int
+	alignRight bool
+}
+
+// defaultNameColumn is the layout for rows rendered without a model.
+var defaultNameColumn = nameColumn{minWidth: defaultNameMinWidth}
+
+// nameColumnFromEnv reads PHTUI_NAME_MIN_WIDTH (0 lets the name collapse on
+// narrow rows, as before) and PHTUI_NAME_ALIGN (left or right).
+func nameColumnFromEnv() nameColumn {
+	col := defaultNameColumn
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("PHTUI_NAME_MIN_WIDTH"))); err == nil && n >= 0 {
+		col.minWidth = n
+	}
+	col.alignRight = strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_NAME_ALIGN")), "right")
+	return col
+}
+
+// nameWidth returns the width for the name given what the rank and badges
+// (fixed) and the votes and sparkline (flexible) take of width. Below the
+// minimum, the name takes room from the votes but never from the rank.
+func (c nameColumn) nameWidth(width, fixed, flexible int) int {
+	available := width - fixed - flexible
+	if available < c.minWidth {
+		available = min(c.minWidth, width-fixed)
+	}
+	if available <= 1 {
+		return 0
+	}
+	return available
+}
+
+// pad fills the name column out with width spaces after the name, or
+// before it when right-aligned, keeping one after to set it off from the
+// votes.
+func (c nameColumn) pad(s string, width int) string {
+	if c.alignRight && width > 0 {
+		return strings.Repeat(" ", width-1) + s + " "
+	}
+	return s + strings.Repeat(" ", width)
+}
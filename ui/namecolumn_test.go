@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/types"
+)
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func TestNameStaysVisibleOnWideNumbers(t *testing.T) {
+	p := types.NewProduct("Supercalifragilistic", "tagline", nil, 1234567, 0, "super", "", 1000, 0, false)
+	for _, width := range []int{14, 16, 20, 24, 40} {
+		for _, selected := range []bool{false, true} {
+			line := firstLine(renderProductItem(p, selected, width, "▲12", "▁▅█", true, defaultNameColumn, defaultTextFormat))
+			if !strings.Contains(line, "Super") {
+				t.Errorf("width %d: name collapsed: %q", width, line)
+			}
+			if strings.Contains(line, "…▲") || strings.HasSuffix(line, "▲ …") {
+				t.Errorf("width %d: votes cut off instead of dropped: %q", width, line)
+			}
+			if got := lipgloss.Width(strings.TrimPrefix(line, "│ ")); got > width {
+				t.Errorf("width %d: row is %d wide: %q", width, got, line)
+			}
+		}
+	}
+
+	// Without a minimum the name gives way to the votes, as before.
+	line := firstLine(renderProductItem(p, false, 14, "", "", false, nameColumn{}, defaultTextFormat))
+	if strings.Contains(line, "Su") || !strings.Contains(line, "1.2M") {
+		t.Errorf("no minimum: %q", line)
+	}
+}
+
+func TestNameColumnAlignment(t *testing.T) {
+	p := types.NewProduct("Alpha", "tagline", nil, 120, 0, "alpha", "", 1, 0, false)
+	left := firstLine(renderProductItem(p, false, 40, "", "", false, defaultNameColumn, defaultTextFormat))
+	right := firstLine(renderProductItem(p, false, 40, "", "", false, nameColumn{minWidth: 8, alignRight: true}, defaultTextFormat))
+	if lipgloss.Width(left) != lipgloss.Width(right) {
+		t.Fatalf("alignment changed the row width: %q vs %q", left, right)
+	}
+	if !strings.HasPrefix(left, "#1 Alpha   ") {
+		t.Errorf("left-aligned row = %q", left)
+	}
+	if !strings.HasSuffix(right, "   Alpha ▲ 120") {
+		t.Errorf("right-aligned row = %q", right)
+	}
+}
+
+func TestNameColumnFromEnv(t *testing.T) {
+	t.Setenv("PHTUI_NAME_MIN_WIDTH", "12")
+	t.Setenv("PHTUI_NAME_ALIGN", "Right")
+	if got := nameColumnFromEnv(); got != (nameColumn{minWidth: 12, alignRight: true}) {
+		t.Errorf("from env = %+v", got)
+	}
+	t.Setenv("PHTUI_NAME_MIN_WIDTH", "wide")
+	t.Setenv("PHTUI_NAME_ALIGN", "")
+	if got := nameColumnFromEnv(); got != defaultNameColumn {
+		t.Errorf("bad values = %+v, want the default", got)
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// pasteClient uploads lists to the paste service.
+var pasteClient = &http.Client{Timeout: 10 * time.Second}
+
+// pasteUploadMsg reports the outcome of uploading the list to the paste
+// service.
+type pasteUploadMsg struct {
+	url string
+	err error
+}
+
+// formatListMarkdown renders products as a ranked Markdown list headed by
+// title, with a link back to source when it is set.
+func formatListMarkdown(title, source string, products []types.Product) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Product Hunt: %s\n\n", title)
+	for i, p := range products {
+		rank := p.Rank()
+		if rank <= 0 {
+			rank = i + 1
+		}
+		fmt.Fprintf(&b, "%d. [%s](%s)", rank, p.Name(), scraper.ProductURL(p.Slug()))
+		if p.Tagline() != "" {
+			fmt.Fprintf(&b, " — %s", p.Tagline())
+		}
+		fmt.Fprintf(&b, " (▲ %d)\n", p.VoteCount())
+	}
+	if source != "" {
+		fmt.Fprintf(&b, "\nSource: %s\n", source)
+	}
+	return b.String()
+}
+
+// uploadPaste POSTs text to a pastebin-style endpoint and returns the URL of
+// the new paste, which the service must send back as the response body.
+func uploadPaste(client *http.Client, endpoint, text string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(text))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/markdown; charset=utf-8")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("paste service returned %s", resp.Status)
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+	u, err := url.Parse(strings.TrimSpace(first))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errors.New("paste service did not return a URL")
+	}
+	return u.String(), nil
+}
+
+func uploadList(endpoint, text string) tea.Cmd {
+	return func() tea.Msg {
+		pasteURL, err := uploadPaste(pasteClient, endpoint, text)
+		return pasteUploadMsg{url: pasteURL, err: err}
+	}
+}
+
+// startPasteUpload uploads the product list on screen as Markdown to the
+// paste service set by PHTUI_PASTE_URL.
+func (m *Model) startPasteUpload() tea.Cmd {
+	if m.pasteURL == "" {
+		m.statusMsg = "Upload is off; set PHTUI_PASTE_URL to a paste service"
+		return nil
+	}
+	if m.state != ListView || m.categorySelectMode || len(m.products) == 0 {
+		m.statusMsg = "Nothing to upload"
+		return nil
+	}
+	m.statusMsg = "Uploading..."
+	text := formatListMarkdown(strings.Join(m.listContext(), " • "), m.pageURL(), m.products)
+	return uploadList(m.pasteURL, text)
+}
+
+// finishPasteUpload reports an upload result, copying the paste URL to the
+// clipboard when PHTUI_PASTE_COPY is set.
+func (m *Model) finishPasteUpload(msg pasteUploadMsg) {
+	if msg.err != nil {
+		m.statusMsg = "Upload failed: " + msg.err.Error()
+		return
+	}
+	if m.pasteCopy {
+		if err := copyToClipboard(msg.url); err != nil {
+			m.statusMsg = "Uploaded to " + msg.url + " (copy failed: " + err.Error() + ")"
+			return
+		}
+		m.statusMsg = "Uploaded and copied " + msg.url
+		return
+	}
+	m.statusMsg = "Uploaded to " + msg.url
+}
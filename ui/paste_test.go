@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestUploadListToPasteService(t *testing.T) {
+	var uploaded string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method", http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		uploaded = string(body)
+		io.WriteString(w, "https://paste.example/abc123\n")
+	}))
+	defer srv.Close()
+
+	t.Setenv("PHTUI_PASTE_URL", srv.URL)
+	t.Setenv("PHTUI_PASTE_COPY", "true")
+	var copied string
+	prev := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = prev }()
+
+	src := &fakeSource{leaderboard: []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: src.leaderboard})
+	m, cmd := update(t, m, keyRunes("u"))
+	if cmd == nil {
+		t.Fatalf("upload should start, status = %q", m.statusMsg)
+	}
+	m, _ = update(t, m, cmd())
+
+	if m.statusMsg != "Uploaded and copied https://paste.example/abc123" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if copied != "https://paste.example/abc123" {
+		t.Errorf("clipboard = %q", copied)
+	}
+	for _, want := range []string{"# Product Hunt: Daily", "1. [Alpha](https://www.producthunt.com/products/alpha)", "2. [Beta]"} {
+		if !strings.Contains(uploaded, want) {
+			t.Errorf("upload missing %q:\n%s", want, uploaded)
+		}
+	}
+}
+
+func TestUploadListFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/html" {
+			io.WriteString(w, "<html>welcome</html>")
+			return
+		}
+		http.Error(w, "quota exceeded", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	products := []types.Product{testProduct("Alpha", "alpha", 1)}
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"", "Upload is off; set PHTUI_PASTE_URL to a paste service"},
+		{srv.URL, "Upload failed: paste service returned 503 Service Unavailable"},
+		{srv.URL + "/html", "Upload failed: paste service did not return a URL"},
+	}
+	for _, tt := range tests {
+		t.Setenv("PHTUI_PASTE_URL", tt.endpoint)
+		m := newTestModel(&fakeSource{leaderboard: products})
+		m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+		m, cmd := update(t, m, keyRunes("u"))
+		if cmd != nil {
+			m, _ = update(t, m, cmd())
+		}
+		if m.statusMsg != tt.want {
+			t.Errorf("endpoint %q: status = %q, want %q", tt.endpoint, m.statusMsg, tt.want)
+		}
+	}
+}
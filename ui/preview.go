@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/types"
+)
+
+// previewMinWidth is the narrowest terminal the preview pane is shown in;
+// below it the list keeps the full width.
+const previewMinWidth = 100
+
+// previewDebounce is how long the list cursor must rest on a product before
+// its detail is fetched for the preview pane.
+var previewDebounce = 150 * time.Millisecond
+
+// previewDebounceMsg ends the pause started by a list cursor move.
+type previewDebounceMsg struct {
+	requestID int
+	slug      string
+}
+
+type previewDetailMsg struct {
+	requestID int
+	slug      string
+	detail    types.ProductDetail
+	err       error
+}
+
+func fetchPreviewDetail(ctx context.Context, source types.ProductSource, slug string, requestID int) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := types.FetchProductDetail(ctx, source, slug)
+		return previewDetailMsg{requestID: requestID, slug: slug, detail: detail, err: err}
+	}
+}
+
+// previewActive reports whether the list is drawn with the preview pane.
+func (m Model) previewActive() bool {
+	return m.previewPane && m.state == ListView && !m.categorySelectMode && m.width >= previewMinWidth
+}
+
+// listWidth is the width of the product list: the whole terminal, or its
+// left part while the preview pane is shown.
+func (m Model) listWidth() int {
+	if !m.previewActive() {
+		return m.width
+	}
+	return m.width * 55 / 100
+}
+
+func (m *Model) togglePreview() {
+	m.previewPane = !m.previewPane
+	switch {
+	case !m.previewPane:
+		m.statusMsg = "Preview pane off"
+	case m.width < previewMinWidth:
+		m.statusMsg = fmt.Sprintf("Preview pane on; it shows once the terminal is %d columns wide", previewMinWidth)
+	default:
+		m.statusMsg = "Preview pane on"
+	}
+}
+
+// syncPreview points the preview pane at the selected product. The detail is
+// fetched once the cursor has rested for previewDebounce, so scrolling
+// through the list only loads the product it stops on; each move takes a new
+// previewRequestID, which drops the pauses and fetches of the ones passed over.
+func (m *Model) syncPreview() tea.Cmd {
+	if !m.previewActive() || m.source == nil {
+		return nil
+	}
+	p, ok := m.selectedProduct()
+	if !ok || p.Slug() == "" || p.Slug() == m.previewSlug {
+		return nil
+	}
+	m.previewSlug = p.Slug()
+	m.previewReady = false
+	m.previewRequestID++
+	id, slug := m.previewRequestID, p.Slug()
+	return tea.Tick(previewDebounce, func(time.Time) tea.Msg {
+		return previewDebounceMsg{requestID: id, slug: slug}
+	})
+}
+
+func (m *Model) finishPreview(msg previewDetailMsg) {
+	if msg.requestID != m.previewRequestID || msg.slug != m.previewSlug {
+		return
+	}
+	m.previewReady = true
+	m.previewDetail = msg.detail
+	m.previewErr = msg.err
+	if msg.err == nil {
+		m.refreshSparklines() // the detail fetch may have cached rank history
+	}
+}
+
+// renderPreviewPane renders the selected product's detail, wrapped to the
+// space right of the list.
+func (m Model) renderPreviewPane() string {
+	width := m.width - m.listWidth() - 2 // selection marker and separator
+	height := m.listHeight()
+	placeholder := func(text string) string {
+		msg := lipgloss.NewStyle().Foreground(DraculaComment).Render(text)
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, msg)
+	}
+
+	p, ok := m.selectedProduct()
+	switch {
+	case !ok || p.Slug() != m.previewSlug:
+		return ""
+	case !m.previewReady:
+		return placeholder("Loading preview...")
+	case errors.Is(m.previewErr, types.ErrProductUnavailable):
+		return placeholder("No longer available on Product Hunt")
+	case m.previewErr != nil:
+		return placeholder("Preview unavailable")
+	}
+
+	content, _ := renderDetail(m.previewDetail, m.format)
+	wrapped := lipgloss.NewStyle().Width(width).PaddingLeft(1).Render(strings.TrimRight(content, "\n"))
+	lines := strings.Split(wrapped, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/types"
+)
+
+// detailCountingSource records which product details were fetched.
+type detailCountingSource struct {
+	*fakeSource
+	fetched []string
+}
+
+func (f *detailCountingSource) GetProductDetail(slug string) (types.ProductDetail, error) {
+	f.fetched = append(f.fetched, slug)
+	return f.fakeSource.GetProductDetail(slug)
+}
+
+func TestPreviewPane(t *testing.T) {
+	prevDebounce := previewDebounce
+	previewDebounce = time.Millisecond
+	defer func() { previewDebounce = prevDebounce }()
+
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2), testProduct("Gamma", "gamma", 3)}
+	details := make(map[string]types.ProductDetail)
+	for _, p := range products {
+		details[p.Slug()] = types.NewProductDetail(types.ProductDetailOptions{
+			Product:     p,
+			Description: p.Name() + " in depth",
+		})
+	}
+	src := &detailCountingSource{fakeSource: &fakeSource{leaderboard: products, details: details}}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+
+	m, pause := update(t, m, keyRunes("v"))
+	if pause == nil || !m.previewActive() {
+		t.Fatal("toggling the preview should start loading the selected product")
+	}
+	if view := m.View(); !strings.Contains(view, "Loading preview...") {
+		t.Errorf("view should show the preview loading:\n%s", view)
+	}
+
+	// Move down twice before any pause ends: only Gamma is fetched.
+	pauses := []tea.Cmd{pause}
+	for range 2 {
+		var cmd tea.Cmd
+		m, cmd = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+		if cmd == nil {
+			t.Fatal("moving the cursor should start a pause")
+		}
+		pauses = append(pauses, cmd)
+	}
+	var fetches []tea.Cmd
+	for _, pause := range pauses {
+		var cmd tea.Cmd
+		m, cmd = update(t, m, pause())
+		if cmd != nil {
+			fetches = append(fetches, cmd)
+		}
+	}
+	if len(fetches) != 1 {
+		t.Fatalf("%d fetches after the pauses, want 1", len(fetches))
+	}
+	m, _ = update(t, m, fetches[0]())
+	if strings.Join(src.fetched, ",") != "gamma" {
+		t.Fatalf("fetched %v, want [gamma]", src.fetched)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Gamma in depth", "Alpha", "│"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if m.listWidth() >= m.width {
+		t.Errorf("list width = %d, want narrower than the terminal (%d)", m.listWidth(), m.width)
+	}
+
+	// Resting on the product already shown fetches nothing more.
+	if _, cmd := update(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); cmd != nil {
+		t.Error("an unrelated key should not reload the preview")
+	}
+
+	// Narrow terminals drop the pane but keep the setting.
+	m, _ = update(t, m, tea.WindowSizeMsg{Width: previewMinWidth - 1, Height: 40})
+	if m.previewActive() || strings.Contains(m.View(), "Gamma in depth") {
+		t.Error("the preview should be hidden below previewMinWidth")
+	}
+	if m.listWidth() != m.width {
+		t.Errorf("list width = %d, want the full %d", m.listWidth(), m.width)
+	}
+	m, _ = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	if !strings.Contains(m.View(), "Gamma in depth") {
+		t.Error("widening the terminal should bring the preview back")
+	}
+
+	m, _ = update(t, m, keyRunes("v"))
+	if m.previewActive() || strings.Contains(m.View(), "Gamma in depth") {
+		t.Error("toggling again should close the preview")
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"context"
+	"sync"
+)
+
+// requestContexts hands out one context per request ID so a fetch whose
+// response would be dropped stops early: asking for a newer ID cancels the
+// previous one's context, and cancel ends them all when the program quits.
+type requestContexts struct {
+	mu     sync.Mutex
+	id     int
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// forRequest returns the context for request id, shared by every fetch
+// made under it. A superseded id gets an already canceled context.
+func (r *requestContexts) forRequest(id int) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx != nil && id <= r.id {
+		if id == r.id {
+			return r.ctx
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.id = id
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	return r.ctx
+}
+
+// cancelAll cancels the current request's context.
+func (r *requestContexts) cancelAll() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// requestCtx returns the context for fetches made under m.requestID.
+func (m Model) requestCtx() context.Context {
+	return m.requests.forRequest(m.requestID)
+}
+
+// cancelRequests stops every in-flight fetch.
+func (m Model) cancelRequests() {
+	m.requests.cancelAll()
+	m.splitRequests.cancelAll()
+	m.previewRequests.cancelAll()
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestRequestContexts(t *testing.T) {
+	r := &requestContexts{}
+	first := r.forRequest(1)
+	if r.forRequest(1) != first {
+		t.Fatal("fetches under one request ID should share its context")
+	}
+	second := r.forRequest(2)
+	if !errors.Is(first.Err(), context.Canceled) {
+		t.Errorf("superseded context err = %v, want context.Canceled", first.Err())
+	}
+	if second.Err() != nil {
+		t.Fatalf("current context err = %v", second.Err())
+	}
+	if stale := r.forRequest(1); stale.Err() == nil {
+		t.Error("a superseded request ID got a live context")
+	}
+	r.cancelAll()
+	if second.Err() == nil {
+		t.Error("cancelAll left the current context running")
+	}
+
+	var unset *requestContexts
+	if ctx := unset.forRequest(3); ctx.Err() != nil {
+		t.Errorf("nil requestContexts gave a done context: %v", ctx.Err())
+	}
+}
+
+func TestQuitCancelsFetches(t *testing.T) {
+	products := []types.Product{testProduct("Alpha", "alpha", 1)}
+	m := newTestModel(&fakeSource{leaderboard: products})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+	ctx := m.requestCtx()
+
+	update(t, m, keyRunes("q"))
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("in-flight fetch context err = %v after quit, want context.Canceled", ctx.Err())
+	}
+}
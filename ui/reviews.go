@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultReviewThreshold is the review count the review filter key requires
+// unless PHTUI_MIN_REVIEWS sets another.
+const defaultReviewThreshold = 10
+
+func reviewThresholdFromEnv() int {
+	n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("PHTUI_MIN_REVIEWS")))
+	if err != nil || n <= 0 {
+		return defaultReviewThreshold
+	}
+	return n
+}
+
+// toggleReviewFilter hides or shows category products with fewer than
+// reviewThreshold reviews. The filter stays on for the categories opened
+// after it.
+func (m *Model) toggleReviewFilter() {
+	if !m.categoryMode {
+		return
+	}
+	if m.minReviews > 0 {
+		m.minReviews = 0
+	} else {
+		m.minReviews = m.reviewThreshold
+	}
+	m.applyPricingFilter()
+}
+
+// reviewFilterStatus describes the review filter for the status bar.
+func reviewFilterStatus(minReviews, hidden int) string {
+	return fmt.Sprintf("%d+ reviews, %d hidden (n to change)", minReviews, hidden)
+}
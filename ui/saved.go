@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// savedSearch is a search saved under a name: the query and page with the
+// sort and pricing filter that were applied to its results.
+type savedSearch struct {
+	Name    string          `json:"name"`
+	Query   string          `json:"query"`
+	Page    int             `json:"page,omitempty"`
+	Sort    types.SortOrder `json:"sort,omitempty"`
+	Pricing string          `json:"pricing,omitempty"`
+}
+
+// savedSearchesPath returns the saved searches file: PHTUI_SAVED_SEARCHES_FILE
+// when set, otherwise saved_searches.json in the data directory.
+func savedSearchesPath() string {
+	if path := strings.TrimSpace(os.Getenv("PHTUI_SAVED_SEARCHES_FILE")); path != "" {
+		return path
+	}
+	dir, err := scraper.DefaultDataDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "saved_searches.json")
+}
+
+// loadSavedSearches reads the searches saved at path. A missing file has
+// none. Unknown sorts and pricing filters are dropped rather than failing
+// the whole file.
+func loadSavedSearches(path string) ([]savedSearch, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var searches []savedSearch
+	if err := json.Unmarshal(data, &searches); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i, s := range searches {
+		if !slices.Contains(types.SortOrders, s.Sort) {
+			searches[i].Sort = ""
+		}
+		if s.Pricing != "free" && s.Pricing != "paid" {
+			searches[i].Pricing = ""
+		}
+	}
+	return searches, nil
+}
+
+func saveSavedSearches(path string, searches []savedSearch) error {
+	if path == "" {
+		return errors.New("no data directory")
+	}
+	data, err := json.MarshalIndent(searches, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ensureSavedSearches loads the saved searches the first time they're
+// needed, so starting up never touches the file.
+func (m *Model) ensureSavedSearches() error {
+	if m.savedLoaded {
+		return nil
+	}
+	searches, err := loadSavedSearches(m.savedPath)
+	if err != nil {
+		return err
+	}
+	m.savedSearches, m.savedLoaded = searches, true
+	return nil
+}
+
+// startSaveSearch prompts for a name to save the search on screen under,
+// suggesting its query.
+func (m *Model) startSaveSearch() {
+	if !m.searchResults {
+		return
+	}
+	m.savingSearch = true
+	m.saveName = m.searchQuery
+	m.statusMsg = m.saveNameStatus()
+}
+
+func (m Model) saveNameStatus() string {
+	return "Save search as: " + m.saveName
+}
+
+// updateSaveName edits the name being typed for a saved search.
+func (m *Model) updateSaveName(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.savingSearch = false
+		m.statusMsg = m.searchStatus()
+		return
+	case tea.KeyEnter:
+		m.saveCurrentSearch(strings.TrimSpace(m.saveName))
+		return
+	case tea.KeyCtrlU:
+		m.saveName = ""
+	case tea.KeySpace:
+		m.saveName += " "
+	case tea.KeyBackspace, tea.KeyDelete:
+		_, size := utf8.DecodeLastRuneInString(m.saveName)
+		m.saveName = m.saveName[:len(m.saveName)-size]
+	case tea.KeyRunes:
+		m.saveName += string(msg.Runes)
+	}
+	m.statusMsg = m.saveNameStatus()
+}
+
+// saveCurrentSearch saves the search on screen under name, replacing any
+// search already saved under it.
+func (m *Model) saveCurrentSearch(name string) {
+	if name == "" {
+		m.statusMsg = "A saved search needs a name"
+		return
+	}
+	m.savingSearch = false
+	if err := m.ensureSavedSearches(); err != nil {
+		m.statusMsg = "Couldn't read saved searches: " + err.Error()
+		return
+	}
+	saved := savedSearch{Name: name, Query: m.searchQuery, Page: m.searchPage, Sort: m.searchSort, Pricing: m.pricingFilter}
+	if saved.Sort == types.SortRelevance {
+		saved.Sort = ""
+	}
+	searches := slices.DeleteFunc(slices.Clone(m.savedSearches), func(s savedSearch) bool { return s.Name == name })
+	searches = append(searches, saved)
+	if err := saveSavedSearches(m.savedPath, searches); err != nil {
+		m.statusMsg = "Couldn't save search: " + err.Error()
+		return
+	}
+	m.savedSearches = searches
+	m.statusMsg = fmt.Sprintf("Saved search %q (W to list)", name)
+}
+
+// toggleSavedSearches shows or hides the saved searches list.
+func (m *Model) toggleSavedSearches() {
+	if m.savedMode {
+		m.savedMode = false
+		m.statusMsg = "Ready"
+		return
+	}
+	if err := m.ensureSavedSearches(); err != nil {
+		m.statusMsg = "Couldn't read saved searches: " + err.Error()
+		return
+	}
+	m.savedMode = true
+	m.savedIdx = min(m.savedIdx, max(len(m.savedSearches)-1, 0))
+	m.statusMsg = fmt.Sprintf("%d saved searches • enter to run, x to delete, esc to close", len(m.savedSearches))
+}
+
+// updateSavedList handles keys while the saved searches list is shown.
+func (m *Model) updateSavedList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.savedIdx = max(m.savedIdx-1, 0)
+	case key.Matches(msg, m.keys.Down):
+		m.savedIdx = min(m.savedIdx+1, max(len(m.savedSearches)-1, 0))
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.SavedList):
+		m.toggleSavedSearches()
+	case key.Matches(msg, m.keys.Enter):
+		if m.savedIdx < len(m.savedSearches) {
+			return m.runSavedSearch(m.savedSearches[m.savedIdx])
+		}
+	case key.Matches(msg, m.keys.Remove):
+		m.deleteSavedSearch()
+	}
+	return *m, nil
+}
+
+func (m *Model) deleteSavedSearch() {
+	if m.savedIdx >= len(m.savedSearches) {
+		return
+	}
+	name := m.savedSearches[m.savedIdx].Name
+	searches := slices.Delete(slices.Clone(m.savedSearches), m.savedIdx, m.savedIdx+1)
+	if err := saveSavedSearches(m.savedPath, searches); err != nil {
+		m.statusMsg = "Couldn't delete saved search: " + err.Error()
+		return
+	}
+	m.savedSearches = searches
+	m.savedIdx = min(m.savedIdx, max(len(searches)-1, 0))
+	m.statusMsg = fmt.Sprintf("Deleted saved search %q", name)
+}
+
+// runSavedSearch fetches s's query and page with its sort. Its pricing
+// filter is applied once the results arrive, as the pricing key would.
+func (m *Model) runSavedSearch(s savedSearch) (tea.Model, tea.Cmd) {
+	m.savedMode = false
+	m.searchSort = s.Sort
+	m.pendingPricing = s.Pricing
+	m.state = ListView
+	m.loading = true
+	m.statusMsg = "Searching..."
+	if m.source == nil {
+		return *m, nil
+	}
+	m.requestID++
+	return *m, tea.Batch(m.spinner.Tick, fetchSearchResults(m.requestCtx(), m.source, s.Query, max(s.Page, 1), m.requestID))
+}
+
+// describeSavedSearch summarizes s after its name, e.g. `"notes" • page 2 •
+// sort: votes • free`.
+func describeSavedSearch(s savedSearch) string {
+	parts := []string{"Trending today"}
+	if s.Query != "" {
+		parts[0] = fmt.Sprintf("%q", s.Query)
+	}
+	if s.Page > 1 {
+		parts = append(parts, fmt.Sprintf("page %d", s.Page))
+	}
+	if s.Sort != "" && s.Sort != types.SortRelevance {
+		parts = append(parts, "sort: "+string(s.Sort))
+	}
+	if s.Pricing != "" {
+		parts = append(parts, s.Pricing)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// renderSavedSearches lists the saved searches in place of the product list.
+func (m Model) renderSavedSearches(height int) string {
+	lines := []string{ContextHeaderStyle.Render("Saved searches")}
+	if len(m.savedSearches) == 0 {
+		msg := lipgloss.NewStyle().Foreground(DraculaComment).Render("No saved searches. Press w on search results to save one.")
+		return lines[0] + "\n" + lipgloss.Place(m.width, max(height-1, 1), lipgloss.Center, lipgloss.Center, msg)
+	}
+	nameStyle := lipgloss.NewStyle().Foreground(DraculaCyan)
+	descStyle := lipgloss.NewStyle().Foreground(DraculaComment)
+	start := max(m.savedIdx-(height-2), 0)
+	for i := start; i < len(m.savedSearches) && len(lines) < height; i++ {
+		s := m.savedSearches[i]
+		marker := "  "
+		style := nameStyle
+		if i == m.savedIdx {
+			marker = "> "
+			style = lipgloss.NewStyle().Foreground(DraculaPink).Bold(true)
+		}
+		name := m.format.truncate(s.Name, m.width-2)
+		desc := m.format.truncate(describeSavedSearch(s), m.width-4-lipgloss.Width(name))
+		lines = append(lines, marker+style.Render(name)+"  "+descStyle.Render(desc))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/types"
+)
+
+// searchRecorder is a fakeSource that records the searches it runs.
+type searchRecorder struct {
+	fakeSource
+	queries []string
+	pages   []int
+}
+
+func (s *searchRecorder) SearchProductsPage(query string, page int) ([]types.Product, int, bool, bool, int, error) {
+	s.queries = append(s.queries, query)
+	s.pages = append(s.pages, page)
+	return s.fakeSource.SearchProductsPage(query, page)
+}
+
+// searchResultFrom runs cmd and the commands it batches, returning the
+// search results among their messages.
+func searchResultFrom(t *testing.T, cmd tea.Cmd) searchResultsMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("no command")
+	}
+	switch msg := cmd().(type) {
+	case searchResultsMsg:
+		return msg
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if c == nil {
+				continue
+			}
+			if res, ok := c().(searchResultsMsg); ok {
+				return res
+			}
+		}
+	}
+	t.Fatal("command didn't search")
+	return searchResultsMsg{}
+}
+
+func TestSavedSearchesPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phtui", "saved_searches.json")
+	if got, err := loadSavedSearches(path); err != nil || got != nil {
+		t.Fatalf("missing file = %v, %v", got, err)
+	}
+	want := []savedSearch{
+		{Name: "notes", Query: "note taking", Page: 2, Sort: types.SortVotes, Pricing: "free"},
+		{Name: "trending", Query: ""},
+	}
+	if err := saveSavedSearches(path, want); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	got, err := loadSavedSearches(path)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("loaded %+v, %v; want %+v", got, err, want)
+	}
+
+	os.WriteFile(path, []byte(`[{"name":"odd","query":"x","sort":"hype","pricing":"cheap"}]`), 0o644)
+	got, err = loadSavedSearches(path)
+	if err != nil || got[0].Sort != "" || got[0].Pricing != "" {
+		t.Errorf("unknown filters = %+v, %v; want them dropped", got, err)
+	}
+	os.WriteFile(path, []byte(`not json`), 0o644)
+	if _, err := loadSavedSearches(path); err == nil {
+		t.Error("expected an error for a corrupt file")
+	}
+}
+
+func TestSaveAndRecallSearch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saved_searches.json")
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}
+	src := &searchRecorder{fakeSource: fakeSource{search: products}}
+
+	m := newTestModel(src)
+	m.savedPath = path
+	m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 3, products: products})
+	m, _ = update(t, m, keyRunes("s")) // sort by votes
+	m, _ = update(t, m, keyRunes("w"))
+	if !m.savingSearch || m.saveName != "notes" {
+		t.Fatalf("w: saving %v, suggested name %q", m.savingSearch, m.saveName)
+	}
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	m, _ = update(t, m, keyRunes("quick"))
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeySpace})
+	m, _ = update(t, m, keyRunes("notes"))
+	if _, cmd := update(t, m, tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil || cmd() != tea.Quit() {
+		t.Fatal("ctrl+c while naming a search didn't quit")
+	}
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.savingSearch {
+		t.Fatalf("still naming after enter: %q", m.statusMsg)
+	}
+	saved, err := loadSavedSearches(path)
+	want := []savedSearch{{Name: "quick notes", Query: "notes", Page: 3, Sort: types.SortVotes}}
+	if err != nil || !reflect.DeepEqual(saved, want) {
+		t.Fatalf("saved %+v, %v; want %+v", saved, err, want)
+	}
+
+	// A later run recalls it with the same query, page and sort.
+	m = newTestModel(src)
+	m.savedPath = path
+	m, _ = update(t, m, keyRunes("W"))
+	if !m.savedMode || !strings.Contains(m.View(), "quick notes") {
+		t.Fatalf("saved list not shown:\n%s", m.View())
+	}
+	m, cmd := update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.savedMode || !m.loading || m.searchSort != types.SortVotes {
+		t.Fatalf("enter: list %v, loading %v, sort %q", m.savedMode, m.loading, m.searchSort)
+	}
+	res := searchResultFrom(t, cmd)
+	if !reflect.DeepEqual(src.queries, []string{"notes"}) || !reflect.DeepEqual(src.pages, []int{3}) {
+		t.Errorf("fetched queries %v pages %v, want notes page 3", src.queries, src.pages)
+	}
+	if res.requestID != m.requestID {
+		t.Errorf("fetch request %d, model %d", res.requestID, m.requestID)
+	}
+	m, _ = update(t, m, res)
+	if got := slugsOf(m.products); !reflect.DeepEqual(got, []string{"beta", "alpha"}) {
+		t.Errorf("recalled results = %v, want sorted by votes", got)
+	}
+
+	// Deleting it from the list removes it from the file.
+	m, _ = update(t, m, keyRunes("W"))
+	m, _ = update(t, m, keyRunes("x"))
+	if saved, _ := loadSavedSearches(path); len(saved) != 0 || len(m.savedSearches) != 0 {
+		t.Errorf("after delete: file %+v, model %+v", saved, m.savedSearches)
+	}
+}
+
+func TestSavedSearchPricingAppliedOnRecall(t *testing.T) {
+	products := []types.Product{testProduct("Alpha", "alpha", 1)}
+	m := newTestModel(&fakeSource{search: products})
+	m.savedLoaded = true
+	m.savedSearches = []savedSearch{{Name: "free", Query: "notes", Pricing: "free"}}
+	m, _ = update(t, m, keyRunes("W"))
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.pendingPricing != "free" {
+		t.Fatalf("pending pricing = %q", m.pendingPricing)
+	}
+	m, cmd := update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 1, products: products})
+	if m.pricingFilter != "free" || m.pendingPricing != "" || !m.loading || cmd == nil {
+		t.Errorf("results: filter %q, pending %q, loading %v", m.pricingFilter, m.pendingPricing, m.loading)
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/scraper"
+	"github.com/qyinm/phtui/types"
+)
+
+// sessionSaveInterval is how often the session is saved while running, so a
+// crash or killed terminal loses little.
+var sessionSaveInterval = 30 * time.Second
+
+// Views a session can resume.
+const (
+	sessionLeaderboard = "leaderboard"
+	sessionCategory    = "category"
+	sessionSearch      = "search"
+	sessionUpcoming    = "upcoming"
+)
+
+// session is the listing on screen, saved so the next run can reopen it.
+type session struct {
+	View     string `json:"view"`
+	Period   string `json:"period,omitempty"`
+	Date     string `json:"date,omitempty"` // YYYY-MM-DD, leaderboards only
+	Category string `json:"category,omitempty"`
+	Query    string `json:"query,omitempty"`
+	Page     int    `json:"page,omitempty"`
+	Selected int    `json:"selected"`
+}
+
+type sessionSaveMsg struct{}
+
+// sessionPath returns the session file when resuming is enabled
+// (PHTUI_RESUME=true): PHTUI_SESSION_FILE when set, otherwise session.json
+// in the data directory. It returns "" when resuming is off.
+func sessionPath() string {
+	if !strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_RESUME")), "true") {
+		return ""
+	}
+	if path := strings.TrimSpace(os.Getenv("PHTUI_SESSION_FILE")); path != "" {
+		return path
+	}
+	dir, err := scraper.DefaultDataDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "session.json")
+}
+
+// loadSession reads the session saved at path. A missing file is reported
+// with os.ErrNotExist.
+func loadSession(path string) (session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return session{}, err
+	}
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return session{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func saveSession(path string, s session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// parseSessionPeriod is the inverse of types.Period.String.
+func parseSessionPeriod(s string) (types.Period, bool) {
+	for _, p := range []types.Period{types.Daily, types.Weekly, types.Monthly} {
+		if p.String() == s {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// currentSession describes the listing on screen.
+func (m Model) currentSession() session {
+	s := session{View: sessionLeaderboard, Selected: m.selected}
+	switch {
+	case m.categorySelectMode && m.splitSlug != "":
+		s.View, s.Category, s.Selected = sessionCategory, m.splitSlug, m.splitSelected
+	case m.searchResults:
+		s.View, s.Query, s.Page = sessionSearch, m.searchQuery, m.searchPage
+	case m.upcomingMode:
+		s.View = sessionUpcoming
+	case m.categoryMode:
+		s.View, s.Category = sessionCategory, m.categorySlug
+	default:
+		s.Period = m.period.String()
+		s.Date = m.date.Format("2006-01-02")
+	}
+	return s
+}
+
+// resumeSession applies s to a model that hasn't fetched anything yet, so
+// Init loads the saved listing instead of today's leaderboard. Invalid
+// sessions, such as ones dated in the future, are rejected whole.
+func (m *Model) resumeSession(s session, today time.Time) error {
+	switch s.View {
+	case sessionLeaderboard:
+		period, ok := parseSessionPeriod(s.Period)
+		if !ok {
+			return fmt.Errorf("unknown period %q", s.Period)
+		}
+		date, err := time.ParseInLocation("2006-01-02", s.Date, types.Timezone())
+		if err != nil {
+			return fmt.Errorf("invalid date %q", s.Date)
+		}
+		if date.After(today) {
+			return fmt.Errorf("date %s is in the future", s.Date)
+		}
+		m.period, m.date = period, date
+	case sessionCategory:
+		if strings.TrimSpace(s.Category) == "" {
+			return errors.New("category has no slug")
+		}
+		m.categorySlug = s.Category
+	case sessionSearch:
+		m.searchQuery = s.Query
+		m.searchPage = max(s.Page, 1)
+	case sessionUpcoming:
+	default:
+		return fmt.Errorf("unknown view %q", s.View)
+	}
+	m.resumeView = s.View
+	// Clamped to the list once it loads.
+	m.resumeSelected = max(s.Selected, 0)
+	return nil
+}
+
+// initialFetch loads the resumed listing, or today's leaderboard.
+func (m Model) initialFetch() tea.Cmd {
+	switch m.resumeView {
+	case sessionCategory:
+		return fetchCategoryProducts(m.requestCtx(), m.source, m.categorySlug, m.requestID)
+	case sessionSearch:
+		return fetchSearchResults(m.requestCtx(), m.source, m.searchQuery, m.searchPage, m.requestID)
+	case sessionUpcoming:
+		return fetchUpcoming(m.requestCtx(), m.source, m.requestID)
+	}
+	return fetchLeaderboard(m.requestCtx(), m.source, m.period, m.date, m.requestID)
+}
+
+// applyResumedSelection moves the cursor to the resumed selection once the
+// first listing has loaded, clamped to its length.
+func (m *Model) applyResumedSelection() {
+	if m.resumeView == "" || m.loading {
+		return
+	}
+	if len(m.products) > 0 {
+		m.selected = min(m.resumeSelected, len(m.products)-1)
+	}
+	m.resumeView = ""
+	m.resumeSelected = 0
+}
+
+// saveCurrentSession writes the session file, if resuming is enabled.
+// Errors are ignored: a stale session only means resuming an older view.
+func (m Model) saveCurrentSession() {
+	if m.sessionPath == "" || m.resumeView != "" {
+		return // nothing worth saving before the resumed listing loads
+	}
+	_ = saveSession(m.sessionPath, m.currentSession())
+}
+
+func sessionSaveTick() tea.Cmd {
+	return tea.Tick(sessionSaveInterval, func(time.Time) tea.Msg { return sessionSaveMsg{} })
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qyinm/phtui/types"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2), testProduct("Gamma", "gamma", 3)}
+	src := &fakeSource{leaderboard: products, catProducts: products, search: products}
+	path := filepath.Join(t.TempDir(), "session.json")
+	today := types.Today()
+
+	tests := []struct {
+		name  string
+		setup func(m Model) Model
+		check func(t *testing.T, m Model)
+	}{
+		{
+			name: "weekly leaderboard",
+			setup: func(m Model) Model {
+				m.period, m.date = types.Weekly, today.AddDate(0, 0, -14)
+				m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+				return m
+			},
+			check: func(t *testing.T, m Model) {
+				if m.period != types.Weekly || !m.date.Equal(today.AddDate(0, 0, -14)) {
+					t.Errorf("period %v date %v, want weekly two weeks ago", m.period, m.date)
+				}
+			},
+		},
+		{
+			name: "category",
+			setup: func(m Model) Model {
+				m.loading = true
+				m, _ = update(t, m, categoryProductsMsg{requestID: m.requestID, slug: "ai-agents", products: products})
+				return m
+			},
+			check: func(t *testing.T, m Model) {
+				if !m.categoryMode || m.categorySlug != "ai-agents" {
+					t.Errorf("category mode %v slug %q, want ai-agents", m.categoryMode, m.categorySlug)
+				}
+			},
+		},
+		{
+			name: "search",
+			setup: func(m Model) Model {
+				m, _ = update(t, m, searchResultsMsg{requestID: m.requestID, query: "notes", page: 2, products: products})
+				return m
+			},
+			check: func(t *testing.T, m Model) {
+				if !m.searchResults || m.searchQuery != "notes" || m.searchPage != 2 {
+					t.Errorf("search %v %q page %d, want notes page 2", m.searchResults, m.searchQuery, m.searchPage)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.setup(newTestModel(src))
+			m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+			m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+			m.sessionPath = path
+			m.saveCurrentSession()
+
+			saved, err := loadSession(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resumed := NewModel(src)
+			if err := resumed.resumeSession(saved, today); err != nil {
+				t.Fatalf("resume %+v: %v", saved, err)
+			}
+			// Run the initial fetch the way the program would.
+			for _, cmd := range resumed.Init()().(tea.BatchMsg) {
+				resumed, _ = update(t, resumed, cmd())
+			}
+			tt.check(t, resumed)
+			if resumed.selected != 2 || resumed.resumeView != "" {
+				t.Errorf("selected %d (pending %q), want 2", resumed.selected, resumed.resumeView)
+			}
+		})
+	}
+}
+
+func TestResumeSessionRejectsInvalidState(t *testing.T) {
+	today := types.Today()
+	for name, s := range map[string]session{
+		"future date":    {View: sessionLeaderboard, Period: "daily", Date: today.AddDate(0, 0, 2).Format("2006-01-02")},
+		"bad date":       {View: sessionLeaderboard, Period: "daily", Date: "yesterday"},
+		"unknown period": {View: sessionLeaderboard, Period: "hourly", Date: today.Format("2006-01-02")},
+		"unknown view":   {View: "settings"},
+		"empty category": {View: sessionCategory},
+	} {
+		m := NewModel(&fakeSource{})
+		if err := m.resumeSession(s, today); err == nil {
+			t.Errorf("%s: resumed %+v", name, s)
+		}
+		if m.resumeView != "" || !m.date.Equal(today) {
+			t.Errorf("%s: a rejected session changed the model", name)
+		}
+	}
+
+	// An out-of-range selection is clamped to the list that loads.
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}
+	m := NewModel(&fakeSource{leaderboard: products})
+	if err := m.resumeSession(session{View: sessionUpcoming, Selected: 40}, today); err != nil {
+		t.Fatal(err)
+	}
+	m, _ = update(t, m, upcomingMsg{requestID: m.requestID, products: products})
+	if m.selected != 1 {
+		t.Errorf("selected = %d, want clamped to 1", m.selected)
+	}
+}
+
+func TestNewModelResumesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	t.Setenv("PHTUI_SESSION_FILE", path)
+	t.Setenv("PHTUI_KEYS_FILE", filepath.Join(t.TempDir(), "keys.json"))
+
+	t.Setenv("PHTUI_RESUME", "")
+	if m := NewModel(&fakeSource{}); m.sessionPath != "" {
+		t.Fatal("resuming should be off unless PHTUI_RESUME=true")
+	}
+
+	t.Setenv("PHTUI_RESUME", "true")
+	if m := NewModel(&fakeSource{}); m.statusMsg != "Ready" || m.resumeView != "" {
+		t.Errorf("no session file: status %q, resume %q", m.statusMsg, m.resumeView)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if m := NewModel(&fakeSource{}); !strings.HasPrefix(m.statusMsg, "Session not resumed") {
+		t.Errorf("corrupt session: status %q", m.statusMsg)
+	}
+
+	yesterday := types.Today().AddDate(0, 0, -1)
+	if err := saveSession(path, session{View: sessionLeaderboard, Period: "daily", Date: yesterday.Format("2006-01-02"), Selected: 3}); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(&fakeSource{})
+	if m.resumeView != sessionLeaderboard || !m.date.Equal(yesterday) || m.statusMsg != "Resuming last session" {
+		t.Errorf("resumed view %q date %v status %q", m.resumeView, m.date, m.statusMsg)
+	}
+
+	// Quitting saves the view on screen.
+	m.resumeView = ""
+	m.period = types.Monthly
+	if _, cmd := update(t, m, keyRunes("q")); cmd == nil {
+		t.Fatal("q should quit")
+	}
+	saved, err := loadSession(path)
+	if err != nil || saved.Period != "monthly" {
+		t.Errorf("saved on quit = %+v, %v; want monthly", saved, err)
+	}
+}
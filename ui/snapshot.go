@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/scraper"
+)
+
+// snapshotDir returns where snapshots are written: PHTUI_SNAPSHOT_DIR when
+// set, otherwise the snapshots subdirectory of the data directory.
+func snapshotDir() (string, error) {
+	if dir := strings.TrimSpace(os.Getenv("PHTUI_SNAPSHOT_DIR")); dir != "" {
+		return dir, nil
+	}
+	dir, err := scraper.DefaultDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snapshots"), nil
+}
+
+// writeSnapshot saves frame, a rendered View() with its ANSI styling, to a
+// timestamped .ans file in dir and returns the file's path. `cat` the file
+// in a terminal to show the frame again.
+func writeSnapshot(dir, frame string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("phtui-%s.ans", now.Format("20060102-150405.000")))
+	if err := os.WriteFile(path, []byte(frame), 0o644); err != nil {
+		return "", err
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path, nil
+}
+
+// saveSnapshot writes the current frame to disk and reports the path in the
+// status bar.
+func (m *Model) saveSnapshot() {
+	frame := m.View()
+	dir, err := snapshotDir()
+	if err != nil {
+		m.statusMsg = "Snapshot failed: " + err.Error()
+		return
+	}
+	path, err := writeSnapshot(dir, frame, time.Now())
+	if err != nil {
+		m.statusMsg = "Snapshot failed: " + err.Error()
+		return
+	}
+	m.statusMsg = "Snapshot saved to " + path
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestSnapshotMatchesView(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PHTUI_SNAPSHOT_DIR", dir)
+	src := &fakeSource{leaderboard: []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: src.leaderboard})
+
+	want := m.View()
+	m, _ = update(t, m, keyRunes("e"))
+	path, ok := strings.CutPrefix(m.statusMsg, "Snapshot saved to ")
+	if !ok {
+		t.Fatalf("status = %q, want the snapshot path", m.statusMsg)
+	}
+	if filepath.Dir(path) != dir || filepath.Ext(path) != ".ans" {
+		t.Errorf("snapshot path = %s, want a .ans file in %s", path, dir)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("snapshot differs from View():\ngot  %q\nwant %q", got, want)
+	}
+}
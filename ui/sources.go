@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// sourceBadgeHydration marks, while source markers are on, leaderboard
+// products the parser found only in the page's hydration data.
+const sourceBadgeHydration = "hyd"
+
+// hydrationOnlySource is implemented by sources that track which
+// leaderboard products were parsed only from hydration data.
+type hydrationOnlySource interface {
+	GetHydrationOnlyContext(ctx context.Context, period types.Period, date time.Time) (map[string]bool, error)
+}
+
+// debugSourcesFromEnv reports whether the source markers key is enabled
+// (PHTUI_DEBUG_SOURCES=true). The markers are a parser diagnostic, so the
+// key is off by default.
+func debugSourcesFromEnv() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("PHTUI_DEBUG_SOURCES")), "true")
+}
+
+// showingSources reports whether the list marks hydration-only products:
+// the markers are on and a single leaderboard is shown.
+func (m Model) showingSources() bool {
+	return m.showSources && !m.searchResults && !m.categoryMode && !m.upcomingMode && !m.combinedMode && !m.compareMode
+}
+
+// sourceBadge returns the badge for p while source markers are shown:
+// sourceBadgeHydration for hydration-only products, none for the rest.
+func (m Model) sourceBadge(p types.Product) string {
+	if m.hydrationOnly[p.Slug()] {
+		return sourceBadgeHydration
+	}
+	return ""
+}
+
+// toggleSources turns the source markers on or off. While on they replace
+// the change-feed badges on the leaderboard.
+func (m *Model) toggleSources() {
+	if m.searchResults || m.categoryMode || m.upcomingMode || m.combinedMode || m.compareMode {
+		m.statusMsg = "Source markers only apply to a single leaderboard"
+		return
+	}
+	m.showSources = !m.showSources
+	switch {
+	case !m.showSources:
+		m.statusMsg = "Source markers off"
+	case m.hydrationOnly == nil:
+		if _, ok := m.source.(hydrationOnlySource); ok {
+			// The cache held the leaderboard without its slugs.
+			m.statusMsg = "Source markers on; unknown for this cached leaderboard until it is fetched again"
+		} else {
+			m.statusMsg = "Source markers on; this source doesn't report where products were parsed"
+		}
+	default:
+		hydrated := 0
+		for _, p := range m.products {
+			if m.hydrationOnly[p.Slug()] {
+				hydrated++
+			}
+		}
+		m.statusMsg = fmt.Sprintf("Source markers on: %d of %d products only in hydration data (%s)", hydrated, len(m.products), sourceBadgeHydration)
+	}
+}
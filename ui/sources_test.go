@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestSourceMarkers(t *testing.T) {
+	t.Setenv("PHTUI_DEBUG_SOURCES", "true")
+	m := newTestModel(&fakeSource{})
+	products := []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products, hydrationOnly: map[string]bool{"beta": true}})
+
+	m, _ = update(t, m, keyRunes("D"))
+	if !m.showSources || !strings.Contains(m.statusMsg, "1 of 2 products") {
+		t.Fatalf("showSources = %v, status = %q", m.showSources, m.statusMsg)
+	}
+	if got := m.changeBadge(products[0]); got != "" {
+		t.Errorf("server-rendered product badge = %q, want none", got)
+	}
+	if got := m.changeBadge(products[1]); got != sourceBadgeHydration {
+		t.Errorf("hydration-only product badge = %q, want %q", got, sourceBadgeHydration)
+	}
+	if !strings.Contains(m.View(), sourceBadgeHydration+" ") {
+		t.Error("list doesn't show the source marker")
+	}
+
+	m, _ = update(t, m, keyRunes("D"))
+	if m.showSources || m.changeBadge(products[1]) != "" {
+		t.Errorf("markers still shown after toggling off")
+	}
+}
+
+// cachedHydrationSource tracks hydration-only products but, like a
+// leaderboard cached without its slugs, has none to report.
+type cachedHydrationSource struct {
+	fakeSource
+}
+
+func (s *cachedHydrationSource) GetHydrationOnlyContext(context.Context, types.Period, time.Time) (map[string]bool, error) {
+	return nil, nil
+}
+
+func TestSourceMarkersUnknownFromDiskCache(t *testing.T) {
+	t.Setenv("PHTUI_DEBUG_SOURCES", "true")
+	products := []types.Product{testProduct("Alpha", "alpha", 1)}
+	for _, tt := range []struct {
+		name   string
+		source types.ProductSource
+		want   string
+	}{
+		{"untracked", &fakeSource{}, "doesn't report"},
+		{"cached", &cachedHydrationSource{}, "unknown for this cached leaderboard"},
+	} {
+		m := newTestModel(tt.source)
+		m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: products})
+		m, _ = update(t, m, keyRunes("D"))
+		if !strings.Contains(m.statusMsg, tt.want) {
+			t.Errorf("%s: status = %q, want %q", tt.name, m.statusMsg, tt.want)
+		}
+	}
+}
+
+func TestSourceMarkersNeedDebugFlag(t *testing.T) {
+	t.Setenv("PHTUI_DEBUG_SOURCES", "")
+	m := newTestModel(&fakeSource{})
+	m, _ = update(t, m, keyRunes("D"))
+	if m.showSources {
+		t.Fatal("source markers toggled without PHTUI_DEBUG_SOURCES")
+	}
+}
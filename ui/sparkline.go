@@ -0,0 +1,68 @@
+package ui
+
+import "github.com/qyinm/phtui/types"
+
+// sparklineGlyphs are the bar heights of a rank sparkline, lowest first.
+var sparklineGlyphs = []rune("▁▂▃▄▅▆▇█")
+
+// sparklineLength is how many of the most recent ranks a sparkline shows.
+const sparklineLength = 8
+
+// cachedRankHistorySource is implemented by sources that can report rank
+// history they already hold without fetching anything.
+type cachedRankHistorySource interface {
+	CachedRankHistory(slug string) ([]types.RankPoint, bool)
+}
+
+// rankSparkline draws ranks, oldest first, as block glyphs. A better (lower)
+// rank draws a taller bar, so a rising product trends upward. It returns ""
+// for fewer than two ranks, which show no trend.
+func rankSparkline(ranks []int) string {
+	if len(ranks) > sparklineLength {
+		ranks = ranks[len(ranks)-sparklineLength:]
+	}
+	if len(ranks) < 2 {
+		return ""
+	}
+	best, worst := ranks[0], ranks[0]
+	for _, r := range ranks {
+		best, worst = min(best, r), max(worst, r)
+	}
+	top := len(sparklineGlyphs) - 1
+	out := make([]rune, len(ranks))
+	for i, r := range ranks {
+		level := top / 2
+		if worst > best {
+			// Round to the nearest level: best maps to top, worst to 0.
+			level = ((worst-r)*top + (worst-best)/2) / (worst - best)
+		}
+		out[i] = sparklineGlyphs[level]
+	}
+	return string(out)
+}
+
+// refreshSparklines recomputes the sparklines for the listed products from
+// rank history the source has cached, e.g. from earlier detail views.
+func (m *Model) refreshSparklines() {
+	m.sparklines = nil
+	cached, ok := m.source.(cachedRankHistorySource)
+	if !ok {
+		return
+	}
+	for _, p := range m.products {
+		history, ok := cached.CachedRankHistory(p.Slug())
+		if !ok {
+			continue
+		}
+		ranks := make([]int, len(history))
+		for i, point := range history {
+			ranks[i] = point.Rank()
+		}
+		if spark := rankSparkline(ranks); spark != "" {
+			if m.sparklines == nil {
+				m.sparklines = make(map[string]string)
+			}
+			m.sparklines[p.Slug()] = spark
+		}
+	}
+}
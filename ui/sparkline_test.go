@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func TestRankSparkline(t *testing.T) {
+	tests := []struct {
+		name  string
+		ranks []int
+		want  string
+	}{
+		{"rising", []int{10, 5, 1}, "▁▅█"},
+		{"falling", []int{1, 8, 15}, "█▅▁"},
+		{"flat", []int{3, 3}, "▄▄"},
+		{"single launch", []int{4}, ""},
+		{"none", nil, ""},
+		{"keeps the latest ranks", []int{99, 99, 8, 7, 6, 5, 4, 3, 2, 1}, "▁▂▃▄▅▆▇█"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankSparkline(tt.ranks); got != tt.want {
+				t.Errorf("rankSparkline(%v) = %q, want %q", tt.ranks, got, tt.want)
+			}
+		})
+	}
+}
+
+// historyFakeSource serves cached rank history for some slugs.
+type historyFakeSource struct {
+	*fakeSource
+	history map[string][]types.RankPoint
+}
+
+func (f *historyFakeSource) CachedRankHistory(slug string) ([]types.RankPoint, bool) {
+	h, ok := f.history[slug]
+	return h, ok
+}
+
+func TestSparklineShownWithCachedHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	src := &historyFakeSource{
+		fakeSource: &fakeSource{leaderboard: []types.Product{testProduct("Alpha", "alpha", 1), testProduct("Beta", "beta", 2)}},
+		history: map[string][]types.RankPoint{
+			"alpha": {types.NewRankPoint(day(1), 9), types.NewRankPoint(day(8), 1)},
+			"beta":  {types.NewRankPoint(day(2), 2)},
+		},
+	}
+	m := newTestModel(src)
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: src.leaderboard})
+
+	if got := m.sparklines["alpha"]; got != "▁█" {
+		t.Errorf("alpha sparkline = %q, want ▁█", got)
+	}
+	if _, ok := m.sparklines["beta"]; ok {
+		t.Error("a single launch should have no sparkline")
+	}
+	if view := m.View(); !strings.Contains(view, "▁█") {
+		t.Errorf("view is missing the sparkline:\n%s", view)
+	}
+}
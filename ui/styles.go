@@ -42,6 +42,20 @@ var (
 			Foreground(DraculaComment)
 	ErrorStyle = lipgloss.NewStyle().
 			Foreground(DraculaRed)
+	// Sticky context line above the product list
+	ContextHeaderStyle = lipgloss.NewStyle().
+				Foreground(DraculaPurple).
+				Bold(true)
+	// Rank trend next to a product's votes
+	SparklineStyle = lipgloss.NewStyle().
+			Foreground(DraculaGreen)
+	// Marker for products that are no longer online
+	OfflineMarkerStyle = lipgloss.NewStyle().
+				Foreground(DraculaRed).
+				Faint(true)
+	// Marker for leaderboard products parsed only from hydration data
+	SourceMarkerStyle = lipgloss.NewStyle().
+				Foreground(DraculaComment)
 
 	// Help
 	HelpKeyStyle = lipgloss.NewStyle().
@@ -67,3 +81,50 @@ var (
 	DateItemDimStyle = lipgloss.NewStyle().
 				Foreground(DraculaComment)
 )
+
+// HeatScale maps a numeric value to a style by magnitude. Thresholds are
+// ascending lower bounds; Styles has one more entry than Thresholds so that
+// Styles[0] covers values below the first threshold. Colors go through
+// lipgloss, so NO_COLOR and the detected terminal profile are honored.
+type HeatScale struct {
+	Thresholds []float64
+	Styles     []lipgloss.Style
+}
+
+// Style returns the style for the highest threshold v reaches.
+func (h HeatScale) Style(v float64) lipgloss.Style {
+	if len(h.Styles) == 0 {
+		return lipgloss.NewStyle()
+	}
+	level := 0
+	for i, t := range h.Thresholds {
+		if v < t {
+			break
+		}
+		level = i + 1
+	}
+	if level >= len(h.Styles) {
+		level = len(h.Styles) - 1
+	}
+	return h.Styles[level]
+}
+
+// Heat scales for vote counts and ratings (override to re-theme).
+var (
+	VoteHeat = HeatScale{
+		Thresholds: []float64{100, 500},
+		Styles: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(DraculaComment),
+			lipgloss.NewStyle().Foreground(DraculaForeground),
+			lipgloss.NewStyle().Foreground(DraculaGreen),
+		},
+	}
+	RatingHeat = HeatScale{
+		Thresholds: []float64{4.0, 4.7},
+		Styles: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(DraculaComment),
+			lipgloss.NewStyle().Foreground(DraculaForeground),
+			lipgloss.NewStyle().Foreground(DraculaGreen),
+		},
+	}
+)
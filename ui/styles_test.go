@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHeatScaleStyle(t *testing.T) {
+	low := lipgloss.NewStyle().Foreground(DraculaComment)
+	mid := lipgloss.NewStyle().Foreground(DraculaForeground)
+	high := lipgloss.NewStyle().Foreground(DraculaGreen)
+	scale := HeatScale{Thresholds: []float64{100, 500}, Styles: []lipgloss.Style{low, mid, high}}
+
+	cases := []struct {
+		value float64
+		want  lipgloss.TerminalColor
+	}{
+		{0, DraculaComment},
+		{99, DraculaComment},
+		{100, DraculaForeground},
+		{499, DraculaForeground},
+		{500, DraculaGreen},
+		{5000, DraculaGreen},
+	}
+	for _, tc := range cases {
+		if got := scale.Style(tc.value).GetForeground(); got != tc.want {
+			t.Errorf("Style(%v) foreground = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestHeatScaleEmpty(t *testing.T) {
+	var scale HeatScale
+	if got := scale.Style(10).GetForeground(); got != (lipgloss.NoColor{}) {
+		t.Errorf("empty scale foreground = %v, want NoColor", got)
+	}
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+// trendingCount is how many of the fastest-gaining products are marked.
+const trendingCount = 3
+
+// trendingMarker is shown beside products gaining votes fastest.
+const trendingMarker = "🔥"
+
+// voteSnapshot is a leaderboard's vote counts by slug when it was loaded.
+type voteSnapshot struct {
+	at    time.Time
+	votes map[string]int
+}
+
+func newVoteSnapshot(products []types.Product, at time.Time) voteSnapshot {
+	votes := make(map[string]int, len(products))
+	for _, p := range products {
+		votes[p.Slug()] = p.VoteCount()
+	}
+	return voteSnapshot{at: at, votes: votes}
+}
+
+// voteVelocity returns the votes per hour each product gained between prev
+// and cur. Products on only one snapshot, or that gained nothing, are left
+// out.
+func voteVelocity(prev, cur voteSnapshot) map[string]float64 {
+	hours := cur.at.Sub(prev.at).Hours()
+	if hours <= 0 {
+		return nil
+	}
+	velocity := make(map[string]float64)
+	for slug, votes := range cur.votes {
+		before, ok := prev.votes[slug]
+		if !ok || votes <= before {
+			continue
+		}
+		velocity[slug] = float64(votes-before) / hours
+	}
+	return velocity
+}
+
+// fastestGaining returns the n slugs with the highest velocity, ties broken
+// by slug so the markers don't jump between equal products.
+func fastestGaining(velocity map[string]float64, n int) map[string]bool {
+	slugs := make([]string, 0, len(velocity))
+	for slug := range velocity {
+		slugs = append(slugs, slug)
+	}
+	sort.Slice(slugs, func(i, j int) bool {
+		if velocity[slugs[i]] != velocity[slugs[j]] {
+			return velocity[slugs[i]] > velocity[slugs[j]]
+		}
+		return slugs[i] < slugs[j]
+	})
+	if len(slugs) > n {
+		slugs = slugs[:n]
+	}
+	if len(slugs) == 0 {
+		return nil
+	}
+	trending := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		trending[slug] = true
+	}
+	return trending
+}
+
+// recordVoteVelocity compares products with the vote counts from the last
+// load of the same leaderboard and marks the fastest-gaining ones. A reload
+// that changed no counts, such as revisiting a period served from the cache,
+// keeps the earlier snapshot and markers; refresh refetches, so its counts
+// are current.
+func (m *Model) recordVoteVelocity(products []types.Product, now time.Time) {
+	key := leaderboardKey(m.period, m.date)
+	cur := newVoteSnapshot(products, now)
+	prev, ok := m.voteSnapshots[key]
+	if ok && sameVotes(prev.votes, cur.votes) {
+		return
+	}
+	if m.voteSnapshots == nil {
+		m.voteSnapshots = make(map[string]voteSnapshot)
+	}
+	m.voteSnapshots[key] = cur
+	m.trending = nil
+	if ok {
+		m.trending = fastestGaining(voteVelocity(prev, cur), trendingCount)
+	}
+}
+
+func sameVotes(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for slug, votes := range a {
+		if other, ok := b[slug]; !ok || other != votes {
+			return false
+		}
+	}
+	return true
+}
+
+// clearTrending forgets the vote snapshots and markers, as when switching
+// to another period.
+func (m *Model) clearTrending() {
+	m.voteSnapshots = nil
+	m.trending = nil
+}
+
+// isTrending reports whether p is marked as gaining votes fastest on the
+// leaderboard view.
+func (m Model) isTrending(p types.Product) bool {
+	if m.searchResults || m.categoryMode || m.upcomingMode || m.combinedMode || m.compareMode {
+		return false
+	}
+	return m.trending[p.Slug()]
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qyinm/phtui/types"
+)
+
+func votedProduct(name, slug string, rank, votes int) types.Product {
+	return types.NewProduct(name, name+" tagline", nil, votes, 0, slug, "", rank, 0, false)
+}
+
+func TestVoteVelocity(t *testing.T) {
+	start := time.Date(2026, 2, 18, 9, 0, 0, 0, time.UTC)
+	prev := newVoteSnapshot([]types.Product{
+		votedProduct("A", "a", 1, 300),
+		votedProduct("B", "b", 2, 200),
+		votedProduct("C", "c", 3, 100),
+		votedProduct("D", "d", 4, 90),
+	}, start)
+	cur := newVoteSnapshot([]types.Product{
+		votedProduct("A", "a", 1, 330),
+		votedProduct("C", "c", 2, 220),
+		votedProduct("B", "b", 3, 210),
+		votedProduct("D", "d", 4, 90),
+		votedProduct("E", "e", 5, 80),
+	}, start.Add(30*time.Minute))
+
+	velocity := voteVelocity(prev, cur)
+	want := map[string]float64{"a": 60, "b": 20, "c": 240}
+	if !reflect.DeepEqual(velocity, want) {
+		t.Fatalf("velocity = %v, want %v", velocity, want)
+	}
+	if got := fastestGaining(velocity, 2); !reflect.DeepEqual(got, map[string]bool{"c": true, "a": true}) {
+		t.Errorf("fastest gaining = %v, want c and a", got)
+	}
+	if got := voteVelocity(cur, prev); len(got) != 0 {
+		t.Errorf("snapshots out of order gave velocity %v", got)
+	}
+}
+
+func TestTrendingMarkers(t *testing.T) {
+	first := []types.Product{votedProduct("A", "a", 1, 300), votedProduct("B", "b", 2, 200)}
+	second := []types.Product{votedProduct("B", "b", 1, 320), votedProduct("A", "a", 2, 310)}
+	m := newTestModel(&fakeSource{})
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: first})
+	if len(m.trending) != 0 {
+		t.Fatalf("first load marked %v", m.trending)
+	}
+
+	// Backdate the first snapshot so the reload is an hour later.
+	key := leaderboardKey(m.period, m.date)
+	snap := m.voteSnapshots[key]
+	snap.at = snap.at.Add(-time.Hour)
+	m.voteSnapshots[key] = snap
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: second})
+	if !m.trending["b"] || !m.trending["a"] {
+		t.Fatalf("trending = %v, want a and b", m.trending)
+	}
+	if view := m.View(); !strings.Contains(view, trendingMarker) {
+		t.Error("trending marker not rendered")
+	}
+
+	// A cached reload with the same counts keeps the markers.
+	m, _ = update(t, m, leaderboardMsg{requestID: m.requestID, products: second})
+	if len(m.trending) != 2 {
+		t.Errorf("unchanged reload cleared trending: %v", m.trending)
+	}
+
+	// Switching period forgets them.
+	m, _ = update(t, m, keyRunes("2"))
+	if m.trending != nil || m.voteSnapshots != nil {
+		t.Errorf("period change kept trending %v, snapshots %v", m.trending, m.voteSnapshots)
+	}
+}
+
+func TestRefreshUpdatesTrending(t *testing.T) {
+	src := &cachingSource{fakeSource: fakeSource{leaderboard: []types.Product{votedProduct("A", "a", 1, 300), votedProduct("B", "b", 2, 200)}}}
+	m := newTestModel(src)
+	m = refresh(t, m)
+
+	key := leaderboardKey(m.period, m.date)
+	snap := m.voteSnapshots[key]
+	snap.at = snap.at.Add(-time.Hour)
+	m.voteSnapshots[key] = snap
+
+	src.leaderboard = []types.Product{votedProduct("B", "b", 1, 320), votedProduct("A", "a", 2, 310)}
+	m = refresh(t, m)
+	if !m.trending["a"] || !m.trending["b"] {
+		t.Fatalf("trending = %v, want a and b once refresh skips the cache", m.trending)
+	}
+}